@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exif "github.com/imclaren/go-exif"
+)
+
+const (
+	jpegMarkerPrefix = 0xff
+	jpegMarkerSoi    = 0xd8
+	jpegMarkerEoi    = 0xd9
+	jpegMarkerSos    = 0xda
+	jpegMarkerApp0   = 0xe0
+	jpegMarkerApp1   = 0xe1
+
+	// jpegMaxSegmentPayload is the largest payload that fits in one segment
+	// (the length field is sixteen bits and includes itself).
+	jpegMaxSegmentPayload = 0xffff - 2
+)
+
+var (
+	jpegExifHeader = []byte("Exif\000\000")
+)
+
+var (
+	// tiffImageTagIds are the tags that point to image data stored outside of
+	// the IFDs (strips, tiles, and sub-IFDs). A file that has any of them is a
+	// TIFF-based image (TIFF, DNG, and most RAW formats) rather than a raw
+	// EXIF blob, and re-encoding the IFDs would lose the image.
+	tiffImageTagIds = map[uint16]struct{}{
+		0x0111: {}, // StripOffsets
+		0x0144: {}, // TileOffsets
+		0x014a: {}, // SubIFDs
+	}
+)
+
+const (
+	// tiffMaxIfdChain is the most IFDs that we look at when deciding whether a
+	// file is a TIFF-based image.
+	tiffMaxIfdChain = 16
+)
+
+var (
+	// ErrNotWritable means that we can find EXIF in the file but do not know
+	// how to write it back.
+	ErrNotWritable = errors.New("writing is only supported for JPEG and raw EXIF files")
+
+	// ErrTiffNotWritable means that the file is a TIFF-based image. Writing
+	// the re-encoded EXIF would drop the image data.
+	ErrTiffNotWritable = fmt.Errorf("%w (TIFF-based images can not be rewritten)", ErrNotWritable)
+
+	// ErrExifTooLarge means that the EXIF does not fit in a JPEG APP1 segment.
+	ErrExifTooLarge = errors.New("EXIF too large for a JPEG APP1 segment")
+)
+
+// container is a file that hosts an EXIF blob. We understand JPEGs and raw
+// EXIF blobs (which start with the TIFF header and have nothing but IFDs)
+// well enough to also write them. TIFF-based images also start with the TIFF
+// header but we can only read them, as for anything else.
+type container struct {
+	data []byte
+
+	isJpeg  bool
+	isRaw   bool
+	isTiff  bool
+	hasExif bool
+
+	// segmentStart and segmentEnd are the bounds of the APP1 segment (marker
+	// included) that hosts the EXIF in a JPEG. If there is no such segment,
+	// both point to where one should be inserted.
+	segmentStart int
+	segmentEnd   int
+
+	exifData []byte
+}
+
+// loadContainer reads the given file and locates the EXIF in it.
+func loadContainer(filepath string) (c *container, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	data, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	c, err = newContainer(data)
+	log.PanicIf(err)
+
+	return c, nil
+}
+
+// newContainer locates the EXIF in the given file data.
+func newContainer(data []byte) (c *container, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	c = &container{
+		data: data,
+	}
+
+	if len(data) >= 2 && data[0] == jpegMarkerPrefix && data[1] == jpegMarkerSoi {
+		c.isJpeg = true
+
+		err := c.findJpegExif()
+		log.PanicIf(err)
+
+		return c, nil
+	}
+
+	if eh, err := exif.ParseExifHeader(data); err == nil {
+		if hasTiffImageData(data, eh) == true {
+			c.isTiff = true
+		} else {
+			c.isRaw = true
+		}
+
+		c.hasExif = true
+		c.exifData = data
+
+		return c, nil
+	}
+
+	exifData, err := exif.SearchAndExtractExif(data)
+	if err != nil {
+		if log.Is(err, exif.ErrNoExif) == true {
+			return c, nil
+		}
+
+		log.Panic(err)
+	}
+
+	c.hasExif = true
+	c.exifData = exifData
+
+	return c, nil
+}
+
+// findJpegExif walks the JPEG segments up to the start of the image data and
+// finds the APP1 segment with the EXIF.
+func (c *container) findJpegExif() (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	data := c.data
+
+	// By default, a new segment goes right after the SOI (or after the JFIF
+	// APP0 segment, if there is one, since that has to come first).
+	c.segmentStart = 2
+	c.segmentEnd = 2
+
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != jpegMarkerPrefix {
+			log.Panicf("JPEG segment marker expected at offset (%d)", i)
+		}
+
+		// Markers may be preceded by any number of fill bytes.
+		marker := data[i+1]
+		if marker == jpegMarkerPrefix {
+			i++
+			continue
+		}
+
+		if marker == jpegMarkerSos || marker == jpegMarkerEoi {
+			break
+		}
+
+		// Standalone markers don't have a length.
+		if marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7) {
+			i += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+
+		if length < 2 || end > len(data) {
+			log.Panicf("JPEG segment at offset (%d) is truncated", i)
+		}
+
+		payload := data[i+4 : end]
+
+		if marker == jpegMarkerApp0 && i == 2 {
+			c.segmentStart = end
+			c.segmentEnd = end
+		} else if marker == jpegMarkerApp1 && bytes.HasPrefix(payload, jpegExifHeader) == true {
+			c.hasExif = true
+			c.segmentStart = i
+			c.segmentEnd = end
+			c.exifData = payload[len(jpegExifHeader):]
+
+			return nil
+		}
+
+		i = end
+	}
+
+	return nil
+}
+
+// hasTiffImageData walks the IFD chain and returns true if any IFD has a tag
+// that points to image data. This only looks at the tag IDs, so it's safe to
+// call on damaged data; anything that we can't read is simply skipped.
+func hasTiffImageData(data []byte, eh exif.ExifHeader) bool {
+	visited := make(map[uint32]struct{})
+	offset := eh.FirstIfdOffset
+
+	for i := 0; i < tiffMaxIfdChain && offset != 0; i++ {
+		if _, found := visited[offset]; found == true {
+			break
+		}
+
+		visited[offset] = struct{}{}
+
+		if uint64(offset)+2 > uint64(len(data)) {
+			break
+		}
+
+		count := uint64(eh.ByteOrder.Uint16(data[offset : offset+2]))
+		entriesOffset := uint64(offset) + 2
+
+		for j := uint64(0); j < count; j++ {
+			entryOffset := entriesOffset + j*12
+			if entryOffset+2 > uint64(len(data)) {
+				return false
+			}
+
+			tagId := eh.ByteOrder.Uint16(data[entryOffset : entryOffset+2])
+			if _, found := tiffImageTagIds[tagId]; found == true {
+				return true
+			}
+		}
+
+		nextOffset := entriesOffset + count*12
+		if nextOffset+4 > uint64(len(data)) {
+			break
+		}
+
+		offset = eh.ByteOrder.Uint32(data[nextOffset : nextOffset+4])
+	}
+
+	return false
+}
+
+// isWritable returns true if we can write an updated EXIF back.
+func (c *container) isWritable() bool {
+	return c.isJpeg == true || c.isRaw == true
+}
+
+// withExif returns the complete file data with the EXIF replaced (or added).
+func (c *container) withExif(exifData []byte) (data []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if c.isRaw == true {
+		return exifData, nil
+	} else if c.isTiff == true {
+		log.Panic(ErrTiffNotWritable)
+	} else if c.isJpeg == false {
+		log.Panic(ErrNotWritable)
+	}
+
+	payloadLength := len(jpegExifHeader) + len(exifData)
+	if payloadLength > jpegMaxSegmentPayload {
+		log.Panic(ErrExifTooLarge)
+	}
+
+	b := new(bytes.Buffer)
+
+	b.Write(c.data[:c.segmentStart])
+	b.Write([]byte{jpegMarkerPrefix, jpegMarkerApp1})
+
+	err = binary.Write(b, binary.BigEndian, uint16(payloadLength+2))
+	log.PanicIf(err)
+
+	b.Write(jpegExifHeader)
+	b.Write(exifData)
+	b.Write(c.data[c.segmentEnd:])
+
+	return b.Bytes(), nil
+}
+
+// withoutExif returns the complete file data without the EXIF segment.
+func (c *container) withoutExif() (data []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if c.isTiff == true {
+		log.Panic(ErrTiffNotWritable)
+	} else if c.isJpeg == false {
+		log.Panicf("%s (and stripping a raw EXIF file would leave nothing)", ErrNotWritable.Error())
+	}
+
+	data = make([]byte, 0, len(c.data)-(c.segmentEnd-c.segmentStart))
+	data = append(data, c.data[:c.segmentStart]...)
+	data = append(data, c.data[c.segmentEnd:]...)
+
+	return data, nil
+}
+
+// String returns a descriptive string.
+func (c *container) String() string {
+	return fmt.Sprintf("container<JPEG=[%v] RAW=[%v] TIFF=[%v] HAS-EXIF=[%v] EXIF-SIZE=(%d)>", c.isJpeg, c.isRaw, c.isTiff, c.hasExif, len(c.exifData))
+}
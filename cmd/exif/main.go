@@ -0,0 +1,1008 @@
+// Package main is a command-line tool for reading and editing EXIF data. It
+// understands JPEGs and raw EXIF blobs (files that start with the TIFF
+// header and have no image data) for both reading and writing. EXIF in any
+// other kind of file, including TIFF-based images such as TIFF, DNG, and most
+// RAW formats, can only be read.
+//
+// Exit codes:
+//
+//	0: success (or, for "diff", no differences)
+//	1: "diff" found differences
+//	2: usage error
+//	3: no EXIF data was found
+//	4: the requested IFD, tag, or thumbnail was not found
+//	5: any other failure
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"encoding/csv"
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exif "github.com/imclaren/go-exif"
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+const (
+	exitOk        = 0
+	exitDifferent = 1
+	exitUsage     = 2
+	exitNoExif    = 3
+	exitNotFound  = 4
+	exitFailure   = 5
+)
+
+const (
+	thumbnailExtract = "extract"
+)
+
+const (
+	formatText = "text"
+	formatJson = "json"
+	formatCsv  = "csv"
+)
+
+var (
+	// errDifferent is returned by "diff" when differences were found.
+	errDifferent = errors.New("differences found")
+)
+
+// usageError is an error in how we were invoked.
+type usageError struct {
+	message string
+}
+
+func (ue usageError) Error() string {
+	return ue.message
+}
+
+func newUsageError(format string, args ...interface{}) error {
+	return usageError{
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+type command struct {
+	name        string
+	arguments   string
+	description string
+	run         func(fs *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+var (
+	commands = []command{
+		{
+			name:        "dump",
+			arguments:   "[-tree] [-format text|json|csv] FILE",
+			description: "Print all tags.",
+			run:         runDump,
+		},
+		{
+			name:        "get",
+			arguments:   "[-format text|json] FILE [IFD-PATH] TAG",
+			description: "Print the value of one tag. TAG is a name or a hex ID (e.g. 0x010f). Without an IFD-path, the first IFD that has the tag is used.",
+			run:         runGet,
+		},
+		{
+			name:        "set",
+			arguments:   "[-o OUTPUT] FILE IFD-PATH TAG VALUE...",
+			description: "Set the value of a standard tag. Give one VALUE per unit. BYTE values are in hex and rationals are given as N/D.",
+			run:         runSet,
+		},
+		{
+			name:        "delete",
+			arguments:   "[-o OUTPUT] FILE IFD-PATH TAG",
+			description: "Delete all occurrences of a tag. TAG is a name or a hex ID.",
+			run:         runDelete,
+		},
+		{
+			name:        "strip",
+			arguments:   "[-o OUTPUT] FILE",
+			description: "Remove the EXIF data from a JPEG.",
+			run:         runStrip,
+		},
+		{
+			name:        "thumbnail",
+			arguments:   "extract [-o OUTPUT] FILE",
+			description: "Extract the thumbnail. It is written to STDOUT unless an output is given.",
+			run:         runThumbnail,
+		},
+		{
+			name:        "diff",
+			arguments:   "[-format text|json] FILE1 FILE2",
+			description: "Compare the EXIF of two files.",
+			run:         runDiff,
+		},
+	}
+)
+
+func main() {
+	code := run(os.Args[1:], os.Stdout, os.Stderr)
+	os.Exit(code)
+}
+
+// run executes the command-line and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	name := args[0]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		printUsage(stdout)
+		return exitOk
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+
+		fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+
+		fs.Usage = func() {
+			fmt.Fprintf(stderr, "Usage: exif %s %s\n\n%s\n\n", c.name, c.arguments, c.description)
+			fs.PrintDefaults()
+		}
+
+		err := c.run(fs, args[1:], stdout)
+		if err == flag.ErrHelp {
+			return exitOk
+		}
+
+		code := exitCodeForError(err)
+		if err != nil && code != exitDifferent {
+			fmt.Fprintf(stderr, "exif %s: %s\n", c.name, err.Error())
+
+			if code == exitUsage {
+				fs.Usage()
+			}
+		}
+
+		return code
+	}
+
+	fmt.Fprintf(stderr, "exif: unknown command [%s]\n\n", name)
+	printUsage(stderr)
+
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: exif COMMAND [OPTIONS] ARGUMENTS\n\nCommands:\n\n")
+
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s %s\n      %s\n", c.name, c.arguments, c.description)
+	}
+
+	fmt.Fprintf(w, "\nIFD-PATH is fully-qualified (e.g. \"IFD\", \"IFD/Exif\", \"IFD1\"). Edits are written in place unless an output is given.\n")
+}
+
+// exitCodeForError maps the error returned by a command to an exit code.
+func exitCodeForError(err error) int {
+	if err == nil {
+		return exitOk
+	} else if err == errDifferent {
+		return exitDifferent
+	} else if err == flag.ErrHelp {
+		return exitUsage
+	}
+
+	if _, ok := err.(usageError); ok == true {
+		return exitUsage
+	}
+
+	if isError(err, exif.ErrNoExif) == true {
+		return exitNoExif
+	}
+
+	notFoundErrors := []error{
+		exif.ErrTagNotFound,
+		exif.ErrTagNotKnown,
+		exif.ErrTagEntryNotFound,
+		exif.ErrChildIbNotFound,
+		exif.ErrNoThumbnail,
+		errIfdNotFound,
+	}
+
+	for _, notFoundErr := range notFoundErrors {
+		if isError(err, notFoundErr) == true {
+			return exitNotFound
+		}
+	}
+
+	return exitFailure
+}
+
+// isError returns true if the error is, or wraps, the given error. This
+// handles both our own wrapping and the standard-library's.
+func isError(err, against error) bool {
+	return log.Is(err, against) == true || errors.Is(err, against) == true
+}
+
+// parseFlags parses the flags and checks the count of the positional
+// arguments.
+func parseFlags(fs *flag.FlagSet, args []string, minimumArgs, maximumArgs int) (positional []string, err error) {
+	err = fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return nil, err
+		}
+
+		return nil, usageError{message: err.Error()}
+	}
+
+	positional = fs.Args()
+
+	if len(positional) < minimumArgs || (maximumArgs >= 0 && len(positional) > maximumArgs) {
+		return nil, newUsageError("wrong number of arguments")
+	}
+
+	return positional, nil
+}
+
+// checkFormat validates the given output format.
+func checkFormat(format string, allowed ...string) error {
+	for _, allowedFormat := range allowed {
+		if format == allowedFormat {
+			return nil
+		}
+	}
+
+	return newUsageError("format [%s] not valid here; must be one of: %s", format, strings.Join(allowed, ", "))
+}
+
+// exifFile is a loaded file along with its parsed EXIF.
+type exifFile struct {
+	filepath  string
+	container *container
+	index     exif.IfdIndex
+
+	ifdMapping *exifcommon.IfdMapping
+	tagIndex   *exif.TagIndex
+}
+
+// loadExifFile loads the file and parses the EXIF in it.
+func loadExifFile(filepath string) (ef *exifFile, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	c, err := loadContainer(filepath)
+	log.PanicIf(err)
+
+	if c.hasExif == false {
+		log.Panic(exif.ErrNoExif)
+	}
+
+	im := exif.NewIfdMappingWithStandard()
+	ti := exif.NewTagIndex()
+
+	s, err := exif.NewScannerLimitFromBytes(c.exifData, exif.DefaultStartLimit, exif.DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, index, err := exif.Collect(s, im, ti)
+	log.PanicIf(err)
+
+	ef = &exifFile{
+		filepath:   filepath,
+		container:  c,
+		index:      index,
+		ifdMapping: im,
+		tagIndex:   ti,
+	}
+
+	return ef, nil
+}
+
+var (
+	errIfdNotFound = errors.New("IFD not found")
+)
+
+// findIfd returns the IFD with the given FQ IFD-path.
+func (ef *exifFile) findIfd(fqIfdPath string) (ifd *exif.Ifd, err error) {
+	ifd, found := ef.index.Lookup[fqIfdPath]
+	if found == false {
+		return nil, fmt.Errorf("%w: [%s]", errIfdNotFound, fqIfdPath)
+	}
+
+	return ifd, nil
+}
+
+// findTag returns the first occurrence of the tag in the given IFD or, if no
+// IFD-path is given, in the first IFD that has it (in the order that they
+// were parsed).
+func (ef *exifFile) findTag(fqIfdPath, tag string) (ite *exif.IfdTagEntry, err error) {
+	tagId, isId, err := parseTagId(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	ifds := ef.index.Ifds
+	if fqIfdPath != "" {
+		ifd, err := ef.findIfd(fqIfdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		ifds = []*exif.Ifd{ifd}
+	}
+
+	// A name that isn't known in any of the IFDs is reported as such.
+	isKnown := false
+
+	for _, ifd := range ifds {
+		var results []*exif.IfdTagEntry
+		if isId == true {
+			results, err = ifd.FindTagWithId(tagId)
+		} else {
+			results, err = ifd.FindTagWithName(tag)
+		}
+
+		if err == nil {
+			return results[0], nil
+		} else if isError(err, exif.ErrTagNotKnown) == true {
+			continue
+		} else if isError(err, exif.ErrTagNotFound) == false {
+			return nil, err
+		}
+
+		isKnown = true
+	}
+
+	if isId == false && isKnown == false {
+		return nil, fmt.Errorf("%w: [%s]", exif.ErrTagNotKnown, tag)
+	}
+
+	return nil, fmt.Errorf("%w: [%s]", exif.ErrTagNotFound, tag)
+}
+
+// parseTagId parses a tag given as a hex ID (e.g. "0x010f"). The second
+// return value is false if it's not an ID (and, therefore, a name).
+func parseTagId(tag string) (tagId uint16, isId bool, err error) {
+	if strings.HasPrefix(tag, "0x") == false && strings.HasPrefix(tag, "0X") == false {
+		return 0, false, nil
+	}
+
+	n, err := strconv.ParseUint(tag[2:], 16, 16)
+	if err != nil {
+		return 0, true, newUsageError("tag ID [%s] not valid", tag)
+	}
+
+	return uint16(n), true, nil
+}
+
+// tagName resolves the name of a standard tag given either its name or ID.
+func (ef *exifFile) tagName(fqIfdPath, tag string) (tagName string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagId, isId, err := parseTagId(tag)
+	if err != nil {
+		return "", err
+	} else if isId == false {
+		return tag, nil
+	}
+
+	ii, err := exifcommon.NewIfdIdentityFromString(ef.ifdMapping, fqIfdPath)
+	log.PanicIf(err)
+
+	it, err := ef.tagIndex.Get(ii, tagId)
+	if err != nil {
+		if log.Is(err, exif.ErrTagNotFound) == true {
+			log.Panic(exif.ErrTagNotKnown)
+		}
+
+		log.Panic(err)
+	}
+
+	return it.Name, nil
+}
+
+// writeOutput writes the updated file, either in place (via a temporary file
+// in the same directory) or to the given output ("-" is STDOUT).
+func writeOutput(inputFilepath, outputFilepath string, data []byte, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if outputFilepath == "-" {
+		_, err := stdout.Write(data)
+		log.PanicIf(err)
+
+		return nil
+	} else if outputFilepath != "" {
+		err := ioutil.WriteFile(outputFilepath, data, 0644)
+		log.PanicIf(err)
+
+		return nil
+	}
+
+	fi, err := os.Stat(inputFilepath)
+	log.PanicIf(err)
+
+	f, err := ioutil.TempFile(path.Dir(inputFilepath), ".exif-")
+	log.PanicIf(err)
+
+	tempFilepath := f.Name()
+
+	defer func() {
+		// This is a no-op once the file has been renamed.
+		os.Remove(tempFilepath)
+	}()
+
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		log.Panic(err)
+	}
+
+	err = f.Close()
+	log.PanicIf(err)
+
+	err = os.Chmod(tempFilepath, fi.Mode())
+	log.PanicIf(err)
+
+	err = os.Rename(tempFilepath, inputFilepath)
+	log.PanicIf(err)
+
+	return nil
+}
+
+// writeExif re-encodes the given IB and writes the file out.
+func (ef *exifFile) writeExif(rootIb *exif.IfdBuilder, outputFilepath string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if ef.container.isTiff == true {
+		log.Panic(ErrTiffNotWritable)
+	} else if ef.container.isWritable() == false {
+		log.Panic(ErrNotWritable)
+	}
+
+	ibe := exif.NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	data, err := ef.container.withExif(exifData)
+	log.PanicIf(err)
+
+	err = writeOutput(ef.filepath, outputFilepath, data, stdout)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func runDump(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	isTree := fs.Bool("tree", false, "Print the IFD hierarchy rather than a flat list")
+	format := fs.String("format", formatText, "Output format")
+
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if *isTree == true {
+		if err := checkFormat(*format, formatText, formatJson); err != nil {
+			return err
+		}
+
+		ef, err := loadExifFile(positional[0])
+		if err != nil {
+			return err
+		}
+
+		err = dumpTree(ef.index, *format, stdout)
+		log.PanicIf(err)
+
+		return nil
+	}
+
+	if err := checkFormat(*format, formatText, formatJson, formatCsv); err != nil {
+		return err
+	}
+
+	c, err := loadContainer(positional[0])
+	log.PanicIf(err)
+
+	if c.hasExif == false {
+		return exif.ErrNoExif
+	}
+
+	exifTags, err := exif.GetFlatExifDataFromBytes(c.exifData)
+	log.PanicIf(err)
+
+	err = dumpFlat(exifTags, *format, stdout)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func dumpFlat(exifTags []exif.ExifTag, format string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch format {
+	case formatJson:
+		data, err := json.MarshalIndent(exifTags, "", "  ")
+		log.PanicIf(err)
+
+		_, err = fmt.Fprintln(stdout, string(data))
+		log.PanicIf(err)
+	case formatCsv:
+		w := csv.NewWriter(stdout)
+
+		err := w.Write([]string{"ifd_path", "id", "name", "type", "unit_count", "value"})
+		log.PanicIf(err)
+
+		for _, et := range exifTags {
+			record := []string{
+				et.IfdPath,
+				fmt.Sprintf("0x%04x", et.TagId),
+				et.TagName,
+				et.TagTypeName,
+				strconv.FormatUint(uint64(et.UnitCount), 10),
+				et.Formatted,
+			}
+
+			err := w.Write(record)
+			log.PanicIf(err)
+		}
+
+		w.Flush()
+
+		err = w.Error()
+		log.PanicIf(err)
+	default:
+		tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+
+		for _, et := range exifTags {
+			fmt.Fprintf(tw, "%s\t0x%04x\t%s\t%s(%d)\t%s\n", et.IfdPath, et.TagId, et.TagName, et.TagTypeName, et.UnitCount, et.FormattedFirst)
+		}
+
+		err := tw.Flush()
+		log.PanicIf(err)
+	}
+
+	return nil
+}
+
+// treeIfd is the JSON representation of one IFD in the tree dump.
+type treeIfd struct {
+	IfdPath  string    `json:"ifd_path"`
+	Offset   uint32    `json:"offset"`
+	Tags     []treeTag `json:"tags"`
+	Children []treeIfd `json:"children,omitempty"`
+}
+
+// treeTag is the JSON representation of one tag in the tree dump.
+type treeTag struct {
+	TagId     uint16 `json:"id"`
+	TagName   string `json:"name"`
+	TagType   string `json:"type_name"`
+	UnitCount uint32 `json:"unit_count"`
+	Value     string `json:"value,omitempty"`
+	ChildIfd  string `json:"child_ifd_path,omitempty"`
+}
+
+func newTreeIfds(ifd *exif.Ifd) (tis []treeIfd, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tis = make([]treeIfd, 0)
+
+	for ; ifd != nil; ifd = ifd.NextIfd {
+		ti := treeIfd{
+			IfdPath: ifd.IfdIdentity().String(),
+			Offset:  ifd.Offset,
+			Tags:    make([]treeTag, len(ifd.Entries)),
+		}
+
+		for i, ite := range ifd.Entries {
+			tt := treeTag{
+				TagId:     ite.TagId(),
+				TagName:   ite.TagName(),
+				TagType:   ite.TagType().String(),
+				UnitCount: ite.UnitCount(),
+				ChildIfd:  ite.ChildFqIfdPath(),
+			}
+
+			if ite.IsThumbnailOffset() == true {
+				tt.Value = fmt.Sprintf("(%d bytes)", ite.UnitCount())
+			} else if ite.ChildIfdPath() == "" {
+				tt.Value, err = ite.Format()
+				log.PanicIf(err)
+			}
+
+			ti.Tags[i] = tt
+		}
+
+		for _, childIfd := range ifd.Children {
+			children, err := newTreeIfds(childIfd)
+			log.PanicIf(err)
+
+			ti.Children = append(ti.Children, children...)
+		}
+
+		tis = append(tis, ti)
+	}
+
+	return tis, nil
+}
+
+func dumpTree(index exif.IfdIndex, format string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if format == formatJson {
+		tis, err := newTreeIfds(index.RootIfd)
+		log.PanicIf(err)
+
+		data, err := json.MarshalIndent(tis, "", "  ")
+		log.PanicIf(err)
+
+		_, err = fmt.Fprintln(stdout, string(data))
+		log.PanicIf(err)
+
+		return nil
+	}
+
+	for _, line := range index.RootIfd.DumpTree() {
+		_, err := fmt.Fprintln(stdout, line)
+		log.PanicIf(err)
+	}
+
+	return nil
+}
+
+func runGet(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	format := fs.String("format", formatText, "Output format")
+
+	positional, err := parseFlags(fs, args, 2, 3)
+	if err != nil {
+		return err
+	} else if err := checkFormat(*format, formatText, formatJson); err != nil {
+		return err
+	}
+
+	ef, err := loadExifFile(positional[0])
+	if err != nil {
+		return err
+	}
+
+	fqIfdPath := ""
+	tag := positional[len(positional)-1]
+
+	if len(positional) == 3 {
+		fqIfdPath = positional[1]
+	}
+
+	ite, err := ef.findTag(fqIfdPath, tag)
+	if err != nil {
+		return err
+	}
+
+	phrase, err := ite.Format()
+	log.PanicIf(err)
+
+	if *format == formatJson {
+		tt := treeTag{
+			TagId:     ite.TagId(),
+			TagName:   ite.TagName(),
+			TagType:   ite.TagType().String(),
+			UnitCount: ite.UnitCount(),
+			Value:     phrase,
+			ChildIfd:  ite.ChildFqIfdPath(),
+		}
+
+		data, err := json.MarshalIndent(tt, "", "  ")
+		log.PanicIf(err)
+
+		phrase = string(data)
+	}
+
+	_, err = fmt.Fprintln(stdout, phrase)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func runSet(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	outputFilepath := fs.String("o", "", "Output file (\"-\" for STDOUT); defaults to updating in place")
+
+	positional, err := parseFlags(fs, args, 4, -1)
+	if err != nil {
+		return err
+	}
+
+	ef, err := loadExifFile(positional[0])
+	if err != nil {
+		return err
+	}
+
+	fqIfdPath := positional[1]
+
+	tagName, err := ef.tagName(fqIfdPath, positional[2])
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(positional)-3)
+	for i, valueString := range positional[3:] {
+		values[i] = valueString
+	}
+
+	patch := &exif.Patch{
+		Operations: []exif.PatchOperation{
+			{
+				Op:      exif.PatchOpSet,
+				IfdPath: fqIfdPath,
+				Tag:     tagName,
+				Value:   values,
+			},
+		},
+	}
+
+	rootIb, err := exif.NewIfdBuilderFromExistingChainWithError(ef.index.RootIfd)
+	if err != nil {
+		return err
+	}
+
+	results, err := patch.Apply(rootIb)
+	if err != nil {
+		if log.Is(err, exif.ErrPatchNotValid) == true {
+			return newUsageError("%s", results[0].Message)
+		} else if log.Is(err, exif.ErrPatchFailed) == true {
+			return results[0].Err
+		}
+
+		log.Panic(err)
+	}
+
+	err = ef.writeExif(rootIb, *outputFilepath, stdout)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func runDelete(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	outputFilepath := fs.String("o", "", "Output file (\"-\" for STDOUT); defaults to updating in place")
+
+	positional, err := parseFlags(fs, args, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	ef, err := loadExifFile(positional[0])
+	if err != nil {
+		return err
+	}
+
+	fqIfdPath := positional[1]
+	tag := positional[2]
+
+	ifd, err := ef.findIfd(fqIfdPath)
+	if err != nil {
+		return err
+	}
+
+	tagId, isId, err := parseTagId(tag)
+	if err != nil {
+		return err
+	} else if isId == false {
+		results, err := ifd.FindTagWithName(tag)
+		if err != nil {
+			return err
+		}
+
+		tagId = results[0].TagId()
+	}
+
+	rootIb, err := exif.NewIfdBuilderFromExistingChainWithError(ef.index.RootIfd)
+	if err != nil {
+		return err
+	}
+
+	ib, err := exif.FindIbFromRootIb(rootIb, fqIfdPath)
+	if err != nil {
+		return err
+	}
+
+	n, err := ib.DeleteAll(tagId)
+	log.PanicIf(err)
+
+	if n == 0 {
+		return exif.ErrTagNotFound
+	}
+
+	err = ef.writeExif(rootIb, *outputFilepath, stdout)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func runStrip(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	outputFilepath := fs.String("o", "", "Output file (\"-\" for STDOUT); defaults to updating in place")
+
+	positional, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	inputFilepath := positional[0]
+
+	c, err := loadContainer(inputFilepath)
+	log.PanicIf(err)
+
+	if c.hasExif == false {
+		return exif.ErrNoExif
+	}
+
+	data, err := c.withoutExif()
+	log.PanicIf(err)
+
+	err = writeOutput(inputFilepath, *outputFilepath, data, stdout)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func runThumbnail(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	outputFilepath := fs.String("o", "-", "Output file (\"-\" for STDOUT)")
+
+	// "extract" is the only subcommand, for now. We still parse the flags
+	// when it's missing so that "-h" works.
+	if len(args) == 0 || args[0] != thumbnailExtract {
+		if _, err := parseFlags(fs, args, 0, -1); err != nil {
+			return err
+		}
+
+		return newUsageError("thumbnail subcommand must be [%s]", thumbnailExtract)
+	}
+
+	positional, err := parseFlags(fs, args[1:], 1, 1)
+	if err != nil {
+		return err
+	}
+
+	ef, err := loadExifFile(positional[0])
+	if err != nil {
+		return err
+	}
+
+	var thumbnailData []byte
+	for _, ifd := range ef.index.Ifds {
+		thumbnailData, err = ifd.Thumbnail()
+		if err == nil {
+			break
+		} else if log.Is(err, exif.ErrNoThumbnail) == false {
+			log.Panic(err)
+		}
+	}
+
+	if thumbnailData == nil {
+		return exif.ErrNoThumbnail
+	}
+
+	err = writeOutput("", *outputFilepath, thumbnailData, stdout)
+	log.PanicIf(err)
+
+	return nil
+}
+
+func runDiff(fs *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	format := fs.String("format", formatText, "Output format")
+
+	positional, err := parseFlags(fs, args, 2, 2)
+	if err != nil {
+		return err
+	} else if err := checkFormat(*format, formatText, formatJson); err != nil {
+		return err
+	}
+
+	efA, err := loadExifFile(positional[0])
+	if err != nil {
+		return err
+	}
+
+	efB, err := loadExifFile(positional[1])
+	if err != nil {
+		return err
+	}
+
+	diff, err := exif.DiffIfdIndexes(efA.index, efB.index)
+	log.PanicIf(err)
+
+	if *format == formatJson {
+		data, err := diff.Json()
+		log.PanicIf(err)
+
+		_, err = fmt.Fprintln(stdout, string(data))
+		log.PanicIf(err)
+	} else {
+		_, err := fmt.Fprint(stdout, diff.String())
+		log.PanicIf(err)
+	}
+
+	if diff.HasDifferences() == true {
+		return errDifferent
+	}
+
+	return nil
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"encoding/binary"
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exif "github.com/imclaren/go-exif"
+)
+
+var (
+	assetsPath = path.Join("..", "..", "assets")
+)
+
+// copyAsset copies the given asset into a temporary directory so that we can
+// modify it.
+func copyAsset(t *testing.T, filename string) string {
+	data, err := ioutil.ReadFile(path.Join(assetsPath, filename))
+	log.PanicIf(err)
+
+	filepath := path.Join(t.TempDir(), filename)
+
+	err = ioutil.WriteFile(filepath, data, 0644)
+	log.PanicIf(err)
+
+	return filepath
+}
+
+func runTest(args ...string) (code int, stdout, stderr string) {
+	stdoutB := new(bytes.Buffer)
+	stderrB := new(bytes.Buffer)
+
+	code = run(args, stdoutB, stderrB)
+
+	return code, stdoutB.String(), stderrB.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	if code, _, _ := runTest(); code != exitUsage {
+		t.Fatalf("Exit code not correct for no arguments: (%d)", code)
+	}
+
+	if code, _, stderr := runTest("bogus"); code != exitUsage {
+		t.Fatalf("Exit code not correct for unknown command: (%d)", code)
+	} else if strings.Contains(stderr, "unknown command") == false {
+		t.Fatalf("Error not printed: [%s]", stderr)
+	}
+
+	if code, stdout, _ := runTest("help"); code != exitOk {
+		t.Fatalf("Exit code not correct for help: (%d)", code)
+	} else if strings.Contains(stdout, "thumbnail") == false {
+		t.Fatalf("Usage not printed: [%s]", stdout)
+	}
+
+	if code, _, _ := runTest("get", "only-one-argument"); code != exitUsage {
+		t.Fatalf("Exit code not correct for missing arguments: (%d)", code)
+	}
+
+	filepath := copyAsset(t, "NDM_8901.jpg.exif")
+
+	if code, _, _ := runTest("dump", "-tree", "-format", "csv", filepath); code != exitUsage {
+		t.Fatalf("Exit code not correct for unsupported format: (%d)", code)
+	}
+}
+
+func TestRun_Dump(t *testing.T) {
+	filepath := copyAsset(t, "NDM_8901.jpg.exif")
+
+	code, stdout, _ := runTest("dump", filepath)
+	if code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if strings.Contains(stdout, "Canon EOS 5D Mark III") == false {
+		t.Fatalf("Model not in output: [%s]", stdout)
+	}
+
+	code, stdout, _ = runTest("dump", "-format", "json", filepath)
+	if code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	}
+
+	exifTags := make([]exif.ExifTag, 0)
+
+	err := json.Unmarshal([]byte(stdout), &exifTags)
+	log.PanicIf(err)
+
+	if len(exifTags) == 0 {
+		t.Fatalf("No tags in JSON output.")
+	}
+
+	code, stdout, _ = runTest("dump", "-format", "csv", filepath)
+	if code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if strings.HasPrefix(stdout, "ifd_path,id,name,type,unit_count,value\n") == false {
+		t.Fatalf("CSV header not correct: [%s]", stdout)
+	} else if strings.Contains(stdout, "IFD,0x0110,Model,ASCII,22,Canon EOS 5D Mark III\n") == false {
+		t.Fatalf("CSV row not correct: [%s]", stdout)
+	}
+
+	code, stdout, _ = runTest("dump", "-tree", filepath)
+	if code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if strings.Contains(stdout, "[IFD/Exif]") == false {
+		t.Fatalf("Tree not correct: [%s]", stdout)
+	}
+
+	code, stdout, _ = runTest("dump", "-tree", "-format", "json", filepath)
+	if code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	}
+
+	tis := make([]treeIfd, 0)
+
+	err = json.Unmarshal([]byte(stdout), &tis)
+	log.PanicIf(err)
+
+	if len(tis) != 2 || tis[0].IfdPath != "IFD" || tis[1].IfdPath != "IFD1" {
+		t.Fatalf("Tree JSON not correct: %v", tis)
+	}
+}
+
+func TestRun_Get(t *testing.T) {
+	filepath := copyAsset(t, "NDM_8901.jpg.exif")
+
+	if code, stdout, _ := runTest("get", filepath, "IFD", "Model"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if stdout != "Canon EOS 5D Mark III\n" {
+		t.Fatalf("Value not correct: [%s]", stdout)
+	}
+
+	if code, stdout, _ := runTest("get", filepath, "IFD/Exif", "0x9003"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if stdout != "2017:12:02 08:18:50\n" {
+		t.Fatalf("Value not correct: [%s]", stdout)
+	}
+
+	if code, _, _ := runTest("get", filepath, "IFD", "Software"); code != exitNotFound {
+		t.Fatalf("Exit code not correct for missing tag: (%d)", code)
+	}
+
+	if code, _, _ := runTest("get", filepath, "IFD/Invalid", "Model"); code != exitNotFound {
+		t.Fatalf("Exit code not correct for missing IFD: (%d)", code)
+	}
+
+	if code, _, _ := runTest("get", path.Join(assetsPath, "tags.yaml"), "IFD", "Model"); code != exitNoExif {
+		t.Fatalf("Exit code not correct for no EXIF: (%d)", code)
+	}
+}
+
+func TestRun_Get_AnyIfd(t *testing.T) {
+	filepath := copyAsset(t, "NDM_8901.jpg.exif")
+
+	if code, stdout, _ := runTest("get", filepath, "Model"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if stdout != "Canon EOS 5D Mark III\n" {
+		t.Fatalf("Value not correct: [%s]", stdout)
+	}
+
+	// Only in the Exif IFD.
+
+	if code, stdout, _ := runTest("get", filepath, "DateTimeOriginal"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if stdout != "2017:12:02 08:18:50\n" {
+		t.Fatalf("Value not correct: [%s]", stdout)
+	}
+
+	if code, stdout, _ := runTest("get", filepath, "0x9003"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if stdout != "2017:12:02 08:18:50\n" {
+		t.Fatalf("Value not correct: [%s]", stdout)
+	}
+
+	if code, _, _ := runTest("get", filepath, "Software"); code != exitNotFound {
+		t.Fatalf("Exit code not correct for missing tag: (%d)", code)
+	}
+
+	if code, _, _ := runTest("get", filepath, "NotARealTag"); code != exitNotFound {
+		t.Fatalf("Exit code not correct for unknown tag: (%d)", code)
+	}
+}
+
+func TestRun_SetAndDelete(t *testing.T) {
+	filepath := copyAsset(t, "NDM_8901.jpg.exif")
+
+	if code, _, stderr := runTest("set", filepath, "IFD", "Artist", "Some Artist"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d) [%s]", code, stderr)
+	}
+
+	if _, stdout, _ := runTest("get", filepath, "IFD", "Artist"); stdout != "Some Artist\n" {
+		t.Fatalf("Value not updated: [%s]", stdout)
+	}
+
+	if code, _, _ := runTest("set", filepath, "IFD", "Orientation", "not-a-number"); code != exitUsage {
+		t.Fatalf("Exit code not correct for invalid value: (%d)", code)
+	}
+
+	outputFilepath := path.Join(t.TempDir(), "output.exif")
+
+	if code, _, stderr := runTest("delete", "-o", outputFilepath, filepath, "IFD", "0x013b"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d) [%s]", code, stderr)
+	}
+
+	if code, _, _ := runTest("get", outputFilepath, "IFD", "Artist"); code != exitNotFound {
+		t.Fatalf("Tag not deleted: (%d)", code)
+	}
+
+	// The original should not have been touched.
+	if _, stdout, _ := runTest("get", filepath, "IFD", "Artist"); stdout != "Some Artist\n" {
+		t.Fatalf("Original was modified: [%s]", stdout)
+	}
+
+	if code, _, _ := runTest("delete", outputFilepath, "IFD", "Artist"); code != exitNotFound {
+		t.Fatalf("Exit code not correct for missing tag: (%d)", code)
+	}
+}
+
+func TestRun_Jpeg(t *testing.T) {
+	filepath := copyAsset(t, "gps.jpg")
+
+	original, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	if code, _, stderr := runTest("set", filepath, "IFD", "Artist", "Some Artist"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d) [%s]", code, stderr)
+	}
+
+	updated, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	// The image data after the EXIF should be untouched.
+	if bytes.HasSuffix(updated, original[len(original)-1000:]) == false {
+		t.Fatalf("Image data was not preserved.")
+	}
+
+	code, stdout, _ := runTest("diff", path.Join(assetsPath, "gps.jpg"), filepath)
+	if code != exitDifferent {
+		t.Fatalf("Exit code not correct for diff: (%d)", code)
+	} else if strings.Contains(stdout, "tag-added [IFD] (0x013b) [Artist]") == false {
+		t.Fatalf("Diff not correct: [%s]", stdout)
+	}
+
+	if code, _, _ := runTest("diff", filepath, filepath); code != exitOk {
+		t.Fatalf("Exit code not correct for identical diff: (%d)", code)
+	}
+
+	if code, _, stderr := runTest("strip", filepath); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d) [%s]", code, stderr)
+	}
+
+	if code, _, _ := runTest("dump", filepath); code != exitNoExif {
+		t.Fatalf("EXIF not stripped: (%d)", code)
+	}
+
+	if code, _, _ := runTest("strip", filepath); code != exitNoExif {
+		t.Fatalf("Exit code not correct for already-stripped file: (%d)", code)
+	}
+}
+
+// writeTiffAsset writes a minimal, uncompressed TIFF with one strip of image
+// data and returns its path along with the image data.
+func writeTiffAsset(t *testing.T) (filepath string, imageData []byte) {
+	imageData = []byte{0x11, 0x22, 0x33, 0x44}
+
+	// Header, an IFD with five entries and no next IFD, then the strip.
+	const ifdOffset = 8
+	const imageOffset = ifdOffset + 2 + 5*12 + 4
+
+	b := new(bytes.Buffer)
+	b.Write([]byte("II*\000"))
+
+	writeUint16 := func(n uint16) {
+		err := binary.Write(b, binary.LittleEndian, n)
+		log.PanicIf(err)
+	}
+
+	writeUint32 := func(n uint32) {
+		err := binary.Write(b, binary.LittleEndian, n)
+		log.PanicIf(err)
+	}
+
+	writeUint32(ifdOffset)
+	writeUint16(5)
+
+	// ImageWidth, ImageLength, and BitsPerSample (SHORT).
+	for _, tagId := range []uint16{0x0100, 0x0101, 0x0102} {
+		writeUint16(tagId)
+		writeUint16(3)
+		writeUint32(1)
+		writeUint32(2)
+	}
+
+	// StripOffsets (LONG).
+	writeUint16(0x0111)
+	writeUint16(4)
+	writeUint32(1)
+	writeUint32(imageOffset)
+
+	// StripByteCounts (LONG).
+	writeUint16(0x0117)
+	writeUint16(4)
+	writeUint32(1)
+	writeUint32(uint32(len(imageData)))
+
+	writeUint32(0)
+	b.Write(imageData)
+
+	filepath = path.Join(t.TempDir(), "image.tiff")
+
+	err := ioutil.WriteFile(filepath, b.Bytes(), 0644)
+	log.PanicIf(err)
+
+	return filepath, imageData
+}
+
+func TestRun_Tiff(t *testing.T) {
+	filepath, imageData := writeTiffAsset(t)
+
+	original, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	if code, stdout, _ := runTest("get", filepath, "IFD", "ImageWidth"); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if stdout != "[2]\n" {
+		t.Fatalf("Value not correct: [%s]", stdout)
+	}
+
+	code, _, stderr := runTest("set", filepath, "IFD", "Artist", "Some Artist")
+	if code != exitFailure {
+		t.Fatalf("Exit code not correct for TIFF: (%d)", code)
+	} else if strings.Contains(stderr, ErrNotWritable.Error()) == false {
+		t.Fatalf("Error not correct: [%s]", stderr)
+	}
+
+	if code, _, _ := runTest("delete", filepath, "IFD", "ImageWidth"); code != exitFailure {
+		t.Fatalf("Exit code not correct for TIFF: (%d)", code)
+	}
+
+	if code, _, _ := runTest("strip", filepath); code != exitFailure {
+		t.Fatalf("Exit code not correct for TIFF: (%d)", code)
+	}
+
+	updated, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	if bytes.Equal(updated, original) != true {
+		t.Fatalf("TIFF was modified.")
+	} else if bytes.HasSuffix(updated, imageData) != true {
+		t.Fatalf("Image data was not preserved.")
+	}
+}
+
+func TestRun_Thumbnail(t *testing.T) {
+	filepath := copyAsset(t, "NDM_8901.jpg.exif")
+
+	expected, err := ioutil.ReadFile(path.Join(assetsPath, "NDM_8901.jpg.thumbnail"))
+	log.PanicIf(err)
+
+	code, stdout, _ := runTest("thumbnail", "extract", filepath)
+	if code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	} else if bytes.Equal([]byte(stdout), expected) == false {
+		t.Fatalf("Thumbnail not correct.")
+	}
+
+	outputFilepath := path.Join(t.TempDir(), "thumbnail.jpg")
+
+	if code, _, _ := runTest("thumbnail", "extract", "-o", outputFilepath, filepath); code != exitOk {
+		t.Fatalf("Exit code not correct: (%d)", code)
+	}
+
+	if _, err := os.Stat(outputFilepath); err != nil {
+		t.Fatalf("Thumbnail not written: %v", err)
+	}
+
+	if code, _, _ := runTest("thumbnail", filepath); code != exitUsage {
+		t.Fatalf("Exit code not correct for missing subcommand: (%d)", code)
+	}
+}
+
+func TestContainer_WithExif_Insert(t *testing.T) {
+	// A minimal JPEG with no EXIF: SOI, APP0, SOS (with some "image data"),
+	// EOI.
+	data := []byte{
+		0xff, 0xd8,
+		0xff, 0xe0, 0x00, 0x04, 0x01, 0x02,
+		0xff, 0xda, 0x00, 0x02, 0xaa, 0xbb,
+		0xff, 0xd9,
+	}
+
+	c, err := newContainer(data)
+	log.PanicIf(err)
+
+	if c.isJpeg != true || c.hasExif != false {
+		t.Fatalf("Container not correct: %s", c)
+	}
+
+	exifData := []byte("II*\000\010\000\000\000\000\000\000\000\000\000")
+
+	updated, err := c.withExif(exifData)
+	log.PanicIf(err)
+
+	// The APP1 segment should follow the APP0 segment.
+	expected := []byte{0xff, 0xe1, 0x00, byte(2 + 6 + len(exifData))}
+
+	if bytes.Equal(updated[8:12], expected) != true {
+		t.Fatalf("APP1 segment not inserted correctly: %x", updated)
+	}
+
+	c, err = newContainer(updated)
+	log.PanicIf(err)
+
+	if c.hasExif != true || bytes.Equal(c.exifData, exifData) != true {
+		t.Fatalf("Inserted EXIF not found: %s", c)
+	}
+
+	stripped, err := c.withoutExif()
+	log.PanicIf(err)
+
+	if bytes.Equal(stripped, data) != true {
+		t.Fatalf("Stripped data not correct: %x", stripped)
+	}
+
+	_, err = c.withExif(make([]byte, jpegMaxSegmentPayload))
+	if log.Is(err, ErrExifTooLarge) != true {
+		t.Fatalf("Expected too-large error: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package exifcommon
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrOffsetInvalid means that an offset points outside of the data.
+	ErrOffsetInvalid = errors.New("file offset invalid")
+
+	// ErrTagTypeNotValid means that the tag-type is not valid.
+	ErrTagTypeNotValid = errors.New("tag type invalid")
+)
+
+// OffsetError is returned when an IFD or a value is at an offset outside of
+// the data. It wraps `ErrOffsetInvalid`.
+type OffsetError struct {
+	// IfdPath is the path of the IFD, if known.
+	IfdPath string
+
+	// TagId is the ID of the tag whose value is at the offset, or zero if the
+	// offset is of an IFD.
+	TagId uint16
+
+	// Offset is the offset that is not valid.
+	Offset uint32
+
+	// DataLength is the length of the data that the offset was checked
+	// against.
+	DataLength int
+}
+
+// Error returns the message.
+func (oe *OffsetError) Error() string {
+	return fmt.Sprintf("%s: offset (0x%08x) is beyond the data (%d)%s", ErrOffsetInvalid.Error(), oe.Offset, oe.DataLength, describeErrorLocation(oe.IfdPath, oe.TagId))
+}
+
+// Unwrap returns `ErrOffsetInvalid`.
+func (oe *OffsetError) Unwrap() error {
+	return ErrOffsetInvalid
+}
+
+// TypeError is returned when a type is not valid or can not be handled. It
+// wraps `Err` (e.g. `ErrTagTypeNotValid`).
+type TypeError struct {
+	// IfdPath is the path of the IFD, if known.
+	IfdPath string
+
+	// TagId is the ID of the tag, if known.
+	TagId uint16
+
+	// TagType is the type that was found.
+	TagType TagTypePrimitive
+
+	// Err is the specific problem.
+	Err error
+}
+
+// Error returns the message.
+func (te *TypeError) Error() string {
+	return fmt.Sprintf("%s: type (%d) [%s]%s", te.Err.Error(), uint16(te.TagType), TypeNames[te.TagType], describeErrorLocation(te.IfdPath, te.TagId))
+}
+
+// Unwrap returns the specific problem.
+func (te *TypeError) Unwrap() error {
+	return te.Err
+}
+
+// TruncatedError is returned when there is less data than a structure or value
+// requires. It wraps `ErrNotEnoughData`.
+type TruncatedError struct {
+	// IfdPath is the path of the IFD, if known.
+	IfdPath string
+
+	// TagId is the ID of the tag, if known.
+	TagId uint16
+
+	// Offset is where the data starts.
+	Offset uint32
+
+	// Needed is the number of bytes required.
+	Needed int
+
+	// Available is the number of bytes that there actually are.
+	Available int
+}
+
+// Error returns the message.
+func (te *TruncatedError) Error() string {
+	return fmt.Sprintf("%s: (%d) bytes needed at offset (0x%08x) but only (%d) available%s", ErrNotEnoughData.Error(), te.Needed, te.Offset, te.Available, describeErrorLocation(te.IfdPath, te.TagId))
+}
+
+// Unwrap returns `ErrNotEnoughData`.
+func (te *TruncatedError) Unwrap() error {
+	return ErrNotEnoughData
+}
+
+// newTruncatedError returns a `TruncatedError` for data whose location is not
+// known.
+func newTruncatedError(needed, available int) *TruncatedError {
+	return &TruncatedError{
+		Needed:    needed,
+		Available: available,
+	}
+}
+
+// describeErrorLocation returns a suffix describing the IFD and tag that an
+// error applies to, if they are known.
+func describeErrorLocation(ifdPath string, tagId uint16) string {
+	if ifdPath == "" && tagId == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" for tag (0x%04x) in IFD [%s]", tagId, ifdPath)
+}
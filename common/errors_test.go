@@ -0,0 +1,107 @@
+package exifcommon
+
+import (
+	"errors"
+	"testing"
+
+	log "github.com/dsoprea/go-logging"
+)
+
+func TestOffsetError(t *testing.T) {
+	oe := &OffsetError{
+		IfdPath:    "IFD/Exif",
+		TagId:      0x9003,
+		Offset:     0x1000,
+		DataLength: 100,
+	}
+
+	if oe.Error() != "file offset invalid: offset (0x00001000) is beyond the data (100) for tag (0x9003) in IFD [IFD/Exif]" {
+		t.Fatalf("Message not correct: [%s]", oe.Error())
+	}
+
+	err := log.Wrap(oe)
+
+	var actual *OffsetError
+	if errors.As(err, &actual) == false || actual != oe {
+		t.Fatalf("Wrapped error not found.")
+	} else if errors.Is(err, ErrOffsetInvalid) == false {
+		t.Fatalf("Not ErrOffsetInvalid.")
+	} else if log.Is(err, ErrOffsetInvalid) == false {
+		t.Fatalf("Not ErrOffsetInvalid via log.Is.")
+	}
+}
+
+func TestTypeError(t *testing.T) {
+	te := &TypeError{
+		TagType: TagTypePrimitive(99),
+		Err:     ErrTagTypeNotValid,
+	}
+
+	if te.Error() != "tag type invalid: type (99) []" {
+		t.Fatalf("Message not correct: [%s]", te.Error())
+	} else if errors.Is(log.Wrap(te), ErrTagTypeNotValid) == false {
+		t.Fatalf("Not ErrTagTypeNotValid.")
+	}
+}
+
+func TestTruncatedError(t *testing.T) {
+	te := newTruncatedError(8, 3)
+
+	if te.Error() != "not enough data for type: (8) bytes needed at offset (0x00000000) but only (3) available" {
+		t.Fatalf("Message not correct: [%s]", te.Error())
+	} else if errors.Is(te, ErrNotEnoughData) == false {
+		t.Fatalf("Not ErrNotEnoughData.")
+	}
+}
+
+func TestValueContext_ReadRawEncoded_OffsetError(t *testing.T) {
+	addressableData := make([]byte, 10)
+
+	vc := NewValueContext("IFD", 0x010f, 8, 0x20, nil, addressableData, TypeAscii, TestDefaultByteOrder)
+
+	_, err := vc.ReadRawEncoded()
+
+	var oe *OffsetError
+	if errors.As(err, &oe) == false {
+		t.Fatalf("Expected offset error: %v", err)
+	} else if oe.Offset != 0x20 || oe.IfdPath != "IFD" || oe.TagId != 0x010f || oe.DataLength != 10 {
+		t.Fatalf("Offset error not correct: %v", oe)
+	}
+}
+
+func TestValueContext_ReadRawEncoded_TruncatedError(t *testing.T) {
+	addressableData := make([]byte, 10)
+
+	vc := NewValueContext("IFD", 0x010f, 8, 4, nil, addressableData, TypeAscii, TestDefaultByteOrder)
+
+	_, err := vc.ReadAscii()
+
+	var te *TruncatedError
+	if errors.As(err, &te) == false {
+		t.Fatalf("Expected truncated error: %v", err)
+	} else if te.Offset != 4 || te.Needed != 8 || te.Available != 6 {
+		t.Fatalf("Truncated error not correct: %v", te)
+	}
+
+	// A huge unit-count must not overflow.
+
+	vc = NewValueContext("IFD", 0x010f, 0xffffffff, 4, nil, addressableData, TypeRational, TestDefaultByteOrder)
+
+	_, err = vc.ReadRationals()
+	if errors.As(err, &te) == false {
+		t.Fatalf("Expected truncated error: %v", err)
+	}
+}
+
+func TestParser_ParseShorts_TruncatedError(t *testing.T) {
+	p := new(Parser)
+
+	_, err := p.ParseShorts([]byte{1, 2, 3}, 2, TestDefaultByteOrder)
+
+	var te *TruncatedError
+	if errors.As(err, &te) == false {
+		t.Fatalf("Expected truncated error: %v", err)
+	} else if te.Needed != 4 || te.Available != 3 {
+		t.Fatalf("Truncated error not correct: %v", te)
+	}
+}
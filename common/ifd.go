@@ -62,7 +62,7 @@ func NewIfdMapping() (ifdMapping *IfdMapping) {
 func NewIfdMappingWithStandard() (ifdMapping *IfdMapping) {
 	defer func() {
 		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
+			err := log.Wrap(state)
 			log.Panic(err)
 		}
 	}()
@@ -81,7 +81,7 @@ func NewIfdMappingWithStandard() (ifdMapping *IfdMapping) {
 func (im *IfdMapping) Get(parentPlacement []uint16) (childIfd *MappedIfd, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -101,7 +101,7 @@ func (im *IfdMapping) Get(parentPlacement []uint16) (childIfd *MappedIfd, err er
 func (im *IfdMapping) GetWithPath(pathPhrase string) (mi *MappedIfd, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -136,7 +136,7 @@ func (im *IfdMapping) GetWithPath(pathPhrase string) (mi *MappedIfd, err error)
 func (im *IfdMapping) GetChild(parentPathPhrase string, tagId uint16) (mi *MappedIfd, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -182,7 +182,7 @@ func (itii IfdTagIdAndIndex) String() string {
 func (im *IfdMapping) ResolvePath(pathPhrase string) (lineage []IfdTagIdAndIndex, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -260,7 +260,7 @@ func (im *IfdMapping) PathPhraseFromLineage(lineage []IfdTagIdAndIndex) (pathPhr
 func (im *IfdMapping) StripPathPhraseIndices(pathPhrase string) (strippedPathPhrase string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -278,7 +278,7 @@ func (im *IfdMapping) StripPathPhraseIndices(pathPhrase string) (strippedPathPhr
 func (im *IfdMapping) Add(parentPlacement []uint16, tagId uint16, name string) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -322,7 +322,7 @@ func (im *IfdMapping) Add(parentPlacement []uint16, tagId uint16, name string) (
 func (im *IfdMapping) dumpLineages(stack []*MappedIfd, input []string) (output []string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -354,7 +354,7 @@ func (im *IfdMapping) dumpLineages(stack []*MappedIfd, input []string) (output [
 func (im *IfdMapping) DumpLineages() (output []string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -371,7 +371,7 @@ func (im *IfdMapping) DumpLineages() (output []string, err error) {
 func LoadStandardIfds(im *IfdMapping) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -510,7 +510,7 @@ func NewIfdIdentity(ifdTag IfdTag, parts ...IfdIdentityPart) (ii *IfdIdentity) {
 func NewIfdIdentityFromString(im *IfdMapping, fqIfdPath string) (ii *IfdIdentity, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -2,8 +2,10 @@ package exifcommon
 
 import (
 	"bytes"
+	"math"
 
 	"encoding/binary"
+	"unicode/utf8"
 
 	"github.com/dsoprea/go-logging"
 )
@@ -20,7 +22,7 @@ type Parser struct {
 func (p *Parser) ParseBytes(data []byte, unitCount uint32) (value []uint8, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -29,7 +31,7 @@ func (p *Parser) ParseBytes(data []byte, unitCount uint32) (value []uint8, err e
 	count := int(unitCount)
 
 	if len(data) < (TypeByte.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeByte.Size()*count, len(data))
 	}
 
 	value = []uint8(data[:count])
@@ -42,7 +44,7 @@ func (p *Parser) ParseBytes(data []byte, unitCount uint32) (value []uint8, err e
 func (p *Parser) ParseAscii(data []byte, unitCount uint32) (value string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -51,10 +53,10 @@ func (p *Parser) ParseAscii(data []byte, unitCount uint32) (value string, err er
 	count := int(unitCount)
 
 	if len(data) < (TypeAscii.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeAscii.Size()*count, len(data))
 	}
 
-	if len(data) == 0 || data[count-1] != 0 {
+	if count == 0 || data[count-1] != 0 {
 		s := string(data[:count])
 		parserLogger.Warningf(nil, "ascii not terminated with nul as expected: [%v]", s)
 
@@ -72,7 +74,7 @@ func (p *Parser) ParseAscii(data []byte, unitCount uint32) (value string, err er
 func (p *Parser) ParseAsciiNoNul(data []byte, unitCount uint32) (value string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -81,17 +83,49 @@ func (p *Parser) ParseAsciiNoNul(data []byte, unitCount uint32) (value string, e
 	count := int(unitCount)
 
 	if len(data) < (TypeAscii.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeAscii.Size()*count, len(data))
 	}
 
 	return string(data[:count]), nil
 }
 
+// ParseUtf8 returns a string and auto-strips the trailing NUL character that
+// should be at the end of the encoding. Invalid sequences are kept as they
+// are.
+func (p *Parser) ParseUtf8(data []byte, unitCount uint32) (value string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	count := int(unitCount)
+
+	if len(data) < (TypeUtf8.Size() * count) {
+		return value, newTruncatedError(TypeUtf8.Size()*count, len(data))
+	}
+
+	if count == 0 || data[count-1] != 0 {
+		s := string(data[:count])
+		parserLogger.Warningf(nil, "utf8 not terminated with nul as expected: [%v]", s)
+
+		return s, nil
+	}
+
+	s := string(data[:count-1])
+
+	if utf8.ValidString(s) == false {
+		parserLogger.Warningf(nil, "utf8 not valid: [%v]", s)
+	}
+
+	return s, nil
+}
+
 // ParseShorts knows how to parse an encoded list of shorts.
 func (p *Parser) ParseShorts(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []uint16, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -100,7 +134,7 @@ func (p *Parser) ParseShorts(data []byte, unitCount uint32, byteOrder binary.Byt
 	count := int(unitCount)
 
 	if len(data) < (TypeShort.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeShort.Size()*count, len(data))
 	}
 
 	value = make([]uint16, count)
@@ -115,7 +149,7 @@ func (p *Parser) ParseShorts(data []byte, unitCount uint32, byteOrder binary.Byt
 func (p *Parser) ParseLongs(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -124,7 +158,7 @@ func (p *Parser) ParseLongs(data []byte, unitCount uint32, byteOrder binary.Byte
 	count := int(unitCount)
 
 	if len(data) < (TypeLong.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeLong.Size()*count, len(data))
 	}
 
 	value = make([]uint32, count)
@@ -139,7 +173,7 @@ func (p *Parser) ParseLongs(data []byte, unitCount uint32, byteOrder binary.Byte
 func (p *Parser) ParseRationals(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []Rational, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -148,7 +182,7 @@ func (p *Parser) ParseRationals(data []byte, unitCount uint32, byteOrder binary.
 	count := int(unitCount)
 
 	if len(data) < (TypeRational.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeRational.Size()*count, len(data))
 	}
 
 	value = make([]Rational, count)
@@ -164,7 +198,7 @@ func (p *Parser) ParseRationals(data []byte, unitCount uint32, byteOrder binary.
 func (p *Parser) ParseSignedLongs(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []int32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -173,7 +207,7 @@ func (p *Parser) ParseSignedLongs(data []byte, unitCount uint32, byteOrder binar
 	count := int(unitCount)
 
 	if len(data) < (TypeSignedLong.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeSignedLong.Size()*count, len(data))
 	}
 
 	b := bytes.NewBuffer(data)
@@ -192,7 +226,7 @@ func (p *Parser) ParseSignedLongs(data []byte, unitCount uint32, byteOrder binar
 func (p *Parser) ParseSignedRationals(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []SignedRational, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -201,7 +235,7 @@ func (p *Parser) ParseSignedRationals(data []byte, unitCount uint32, byteOrder b
 	count := int(unitCount)
 
 	if len(data) < (TypeSignedRational.Size() * count) {
-		log.Panic(ErrNotEnoughData)
+		return value, newTruncatedError(TypeSignedRational.Size()*count, len(data))
 	}
 
 	b := bytes.NewBuffer(data)
@@ -217,3 +251,47 @@ func (p *Parser) ParseSignedRationals(data []byte, unitCount uint32, byteOrder b
 
 	return value, nil
 }
+
+// ParseFloats knows how to parse an encoded list of floats.
+func (p *Parser) ParseFloats(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []float32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	count := int(unitCount)
+
+	if len(data) < (TypeFloat.Size() * count) {
+		return value, newTruncatedError(TypeFloat.Size()*count, len(data))
+	}
+
+	value = make([]float32, count)
+	for i := 0; i < count; i++ {
+		value[i] = math.Float32frombits(byteOrder.Uint32(data[i*4:]))
+	}
+
+	return value, nil
+}
+
+// ParseDoubles knows how to parse an encoded list of doubles.
+func (p *Parser) ParseDoubles(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []float64, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	count := int(unitCount)
+
+	if len(data) < (TypeDouble.Size() * count) {
+		return value, newTruncatedError(TypeDouble.Size()*count, len(data))
+	}
+
+	value = make([]float64, count)
+	for i := 0; i < count; i++ {
+		value[i] = math.Float64frombits(byteOrder.Uint64(data[i*8:]))
+	}
+
+	return value, nil
+}
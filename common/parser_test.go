@@ -71,6 +71,38 @@ func TestParser_ParseAscii(t *testing.T) {
 	}
 }
 
+func TestParser_ParseUtf8(t *testing.T) {
+	p := new(Parser)
+
+	original := "Grüße, 東京"
+
+	encoded := []byte(original)
+	encoded = append(encoded, 0)
+
+	value, err := p.ParseUtf8(encoded, uint32(len(encoded)))
+	log.PanicIf(err)
+
+	if value != original {
+		t.Fatalf("Encoding not correct (terminated): [%s]", value)
+	}
+
+	// Not terminated.
+
+	encoded = []byte(original)
+
+	value, err = p.ParseUtf8(encoded, uint32(len(encoded)))
+	log.PanicIf(err)
+
+	if value != original {
+		t.Fatalf("Encoding not correct (not terminated): [%s]", value)
+	}
+
+	_, err = p.ParseUtf8(encoded, uint32(len(encoded)+1))
+	if err == nil {
+		t.Fatalf("Expected error for truncated data.")
+	}
+}
+
 func TestParser_ParseAsciiNoNul(t *testing.T) {
 	p := new(Parser)
 
@@ -318,3 +350,49 @@ func TestParser_ParseSignedRationals__Multiple(t *testing.T) {
 		t.Fatalf("Encoding not correct (2): %v", value)
 	}
 }
+
+func TestParser_ParseFloats(t *testing.T) {
+	p := new(Parser)
+
+	encoded := []byte{
+		0x3f, 0xc0, 0x00, 0x00,
+		0xc0, 0x10, 0x00, 0x00,
+	}
+
+	value, err := p.ParseFloats(encoded, 2, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	expected := []float32{1.5, -2.25}
+
+	if reflect.DeepEqual(value, expected) != true {
+		t.Fatalf("Encoding not correct: %v", value)
+	}
+
+	_, err = p.ParseFloats(encoded, 3, TestDefaultByteOrder)
+	if err == nil {
+		t.Fatalf("Expected error for truncated data.")
+	}
+}
+
+func TestParser_ParseDoubles(t *testing.T) {
+	p := new(Parser)
+
+	encoded := []byte{
+		0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xc0, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	value, err := p.ParseDoubles(encoded, 2, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	expected := []float64{1.5, -2.25}
+
+	if reflect.DeepEqual(value, expected) != true {
+		t.Fatalf("Encoding not correct: %v", value)
+	}
+
+	_, err = p.ParseDoubles(encoded, 3, TestDefaultByteOrder)
+	if err == nil {
+		t.Fatalf("Expected error for truncated data.")
+	}
+}
@@ -63,6 +63,16 @@ const (
 	// TypeSignedRational describes an encoded list of signed rationals.
 	TypeSignedRational TagTypePrimitive = 10
 
+	// TypeFloat describes an encoded list of IEEE single-precision floats.
+	TypeFloat TagTypePrimitive = 11
+
+	// TypeDouble describes an encoded list of IEEE double-precision floats.
+	TypeDouble TagTypePrimitive = 12
+
+	// TypeUtf8 describes an encoded UTF-8 string that is terminated with a
+	// NUL in its encoded form. Added by EXIF 3.0.
+	TypeUtf8 TagTypePrimitive = 129
+
 	// TypeAsciiNoNul is just a pseudo-type, for our own purposes.
 	TypeAsciiNoNul TagTypePrimitive = 0xf0
 )
@@ -76,7 +86,7 @@ func (typeType TagTypePrimitive) String() string {
 func (tagType TagTypePrimitive) Size() int {
 	if tagType == TypeByte {
 		return 1
-	} else if tagType == TypeAscii || tagType == TypeAsciiNoNul {
+	} else if tagType == TypeAscii || tagType == TypeAsciiNoNul || tagType == TypeUtf8 {
 		return 1
 	} else if tagType == TypeShort {
 		return 2
@@ -88,6 +98,10 @@ func (tagType TagTypePrimitive) Size() int {
 		return 4
 	} else if tagType == TypeSignedRational {
 		return 8
+	} else if tagType == TypeFloat {
+		return 4
+	} else if tagType == TypeDouble {
+		return 8
 	} else {
 		log.Panicf("can not determine tag-value size for type (%d): [%s]", tagType, TypeNames[tagType])
 
@@ -109,7 +123,10 @@ func (tagType TagTypePrimitive) IsValid() bool {
 		tagType == TypeRational ||
 		tagType == TypeSignedLong ||
 		tagType == TypeSignedRational ||
-		tagType == TypeUndefined
+		tagType == TypeUndefined ||
+		tagType == TypeUtf8 ||
+		tagType == TypeFloat ||
+		tagType == TypeDouble
 }
 
 var (
@@ -123,6 +140,9 @@ var (
 		TypeUndefined:      "UNDEFINED",
 		TypeSignedLong:     "SLONG",
 		TypeSignedRational: "SRATIONAL",
+		TypeUtf8:           "UTF8",
+		TypeFloat:          "FLOAT",
+		TypeDouble:         "DOUBLE",
 
 		TypeAsciiNoNul: "_ASCII_NO_NUL",
 	}
@@ -156,7 +176,7 @@ type SignedRational struct {
 func FormatFromType(value interface{}, justFirst bool) (phrase string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -260,6 +280,36 @@ func FormatFromType(value interface{}, justFirst bool) (phrase string, err error
 		}
 
 		return fmt.Sprintf("%v", parts), nil
+	case []float32:
+		if len(t) == 0 {
+			return "", nil
+		}
+
+		if justFirst == true {
+			var valueSuffix string
+			if len(t) > 1 {
+				valueSuffix = "..."
+			}
+
+			return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
+		}
+
+		return fmt.Sprintf("%v", t), nil
+	case []float64:
+		if len(t) == 0 {
+			return "", nil
+		}
+
+		if justFirst == true {
+			var valueSuffix string
+			if len(t) > 1 {
+				valueSuffix = "..."
+			}
+
+			return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
+		}
+
+		return fmt.Sprintf("%v", t), nil
 	case fmt.Stringer:
 		// An undefined value that is documented (or that we otherwise support).
 		return t.String(), nil
@@ -277,7 +327,7 @@ func FormatFromType(value interface{}, justFirst bool) (phrase string, err error
 func FormatFromBytes(rawBytes []byte, tagType TagTypePrimitive, justFirst bool, byteOrder binary.ByteOrder) (phrase string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -313,6 +363,11 @@ func FormatFromBytes(rawBytes []byte, tagType TagTypePrimitive, justFirst bool,
 
 		value, err = parser.ParseAsciiNoNul(rawBytes, unitCount)
 		log.PanicIf(err)
+	case TypeUtf8:
+		var err error
+
+		value, err = parser.ParseUtf8(rawBytes, unitCount)
+		log.PanicIf(err)
 	case TypeShort:
 		var err error
 
@@ -338,6 +393,16 @@ func FormatFromBytes(rawBytes []byte, tagType TagTypePrimitive, justFirst bool,
 
 		value, err = parser.ParseSignedRationals(rawBytes, unitCount, byteOrder)
 		log.PanicIf(err)
+	case TypeFloat:
+		var err error
+
+		value, err = parser.ParseFloats(rawBytes, unitCount, byteOrder)
+		log.PanicIf(err)
+	case TypeDouble:
+		var err error
+
+		value, err = parser.ParseDoubles(rawBytes, unitCount, byteOrder)
+		log.PanicIf(err)
 	default:
 		// Affects only "unknown" values, in general.
 		log.Panicf("value of type [%s] can not be formatted into string", tagType.String())
@@ -355,11 +420,12 @@ func FormatFromBytes(rawBytes []byte, tagType TagTypePrimitive, justFirst bool,
 // TranslateStringToType converts user-provided strings to properly-typed
 // values. If a string, returns a string. Else, assumes that it's a single
 // number. If a list needs to be processed, it is the caller's responsibility to
-// split it (according to whichever convention has been established).
+// split it (according to whichever convention has been established). BYTE
+// values are unsigned hex (00 to ff); negative values are rejected.
 func TranslateStringToType(tagType TagTypePrimitive, valueString string) (value interface{}, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -369,11 +435,11 @@ func TranslateStringToType(tagType TagTypePrimitive, valueString string) (value
 	}
 
 	if tagType == TypeByte {
-		wide, err := strconv.ParseInt(valueString, 16, 8)
+		wide, err := strconv.ParseUint(valueString, 16, 8)
 		log.PanicIf(err)
 
 		return byte(wide), nil
-	} else if tagType == TypeAscii || tagType == TypeAsciiNoNul {
+	} else if tagType == TypeAscii || tagType == TypeAsciiNoNul || tagType == TypeUtf8 {
 		// Whether or not we're putting an NUL on the end is only relevant for
 		// byte-level encoding. This function really just supports a user
 		// interface.
@@ -420,12 +486,60 @@ func TranslateStringToType(tagType TagTypePrimitive, valueString string) (value
 			Numerator:   int32(numerator),
 			Denominator: int32(denominator),
 		}, nil
+	} else if tagType == TypeFloat {
+		n, err := strconv.ParseFloat(valueString, 32)
+		log.PanicIf(err)
+
+		return float32(n), nil
+	} else if tagType == TypeDouble {
+		n, err := strconv.ParseFloat(valueString, 64)
+		log.PanicIf(err)
+
+		return n, nil
 	}
 
 	log.Panicf("from-string encoding for type not supported; this shouldn't happen: [%s]", tagType.String())
 	return nil, nil
 }
 
+// TranslateStringsToType converts a list of user-provided strings to a single,
+// properly-typed value that can be passed to the value-encoder (e.g. a
+// []uint16 for SHORT). ASCII values must be given as exactly one string.
+func TranslateStringsToType(tagType TagTypePrimitive, valueStrings []string) (value interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if tagType == TypeAscii || tagType == TypeAsciiNoNul || tagType == TypeUtf8 {
+		if len(valueStrings) != 1 {
+			log.Panicf("ASCII values must be given as exactly one string: (%d)", len(valueStrings))
+		}
+
+		return valueStrings[0], nil
+	}
+
+	if len(valueStrings) == 0 {
+		log.Panicf("at least one value is required")
+	}
+
+	var values reflect.Value
+
+	for i, valueString := range valueStrings {
+		item, err := TranslateStringToType(tagType, valueString)
+		log.PanicIf(err)
+
+		if i == 0 {
+			values = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(item)), 0, len(valueStrings))
+		}
+
+		values = reflect.Append(values, reflect.ValueOf(item))
+	}
+
+	return values.Interface(), nil
+}
+
 // GetTypeByName returns the `TagTypePrimitive` for the given type name.
 // Returns (0) if not valid.
 func GetTypeByName(typeName string) (tagType TagTypePrimitive, found bool) {
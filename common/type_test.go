@@ -1,6 +1,7 @@
 package exifcommon
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/dsoprea/go-logging"
@@ -24,6 +25,24 @@ func TestTypeAsciiNoNul_String(t *testing.T) {
 	}
 }
 
+func TestTypeUtf8_String(t *testing.T) {
+	if TypeUtf8.String() != "UTF8" {
+		t.Fatalf("Type name not correct (UTF-8): [%s]", TypeUtf8.String())
+	}
+}
+
+func TestTypeFloat_String(t *testing.T) {
+	if TypeFloat.String() != "FLOAT" {
+		t.Fatalf("Type name not correct (float): [%s]", TypeFloat.String())
+	}
+}
+
+func TestTypeDouble_String(t *testing.T) {
+	if TypeDouble.String() != "DOUBLE" {
+		t.Fatalf("Type name not correct (double): [%s]", TypeDouble.String())
+	}
+}
+
 func TestTypeShort_String(t *testing.T) {
 	if TypeShort.String() != "SHORT" {
 		t.Fatalf("Type name not correct (short): [%s]", TypeShort.String())
@@ -72,6 +91,30 @@ func TestTypeAsciiNoNul_Size(t *testing.T) {
 	}
 }
 
+func TestTypeUtf8_Size(t *testing.T) {
+	if TypeUtf8.Size() != 1 {
+		t.Fatalf("Type size not correct (UTF-8): (%d)", TypeUtf8.Size())
+	} else if TypeUtf8.IsValid() != true {
+		t.Fatalf("UTF-8 type should be valid.")
+	}
+}
+
+func TestTypeFloat_Size(t *testing.T) {
+	if TypeFloat.Size() != 4 {
+		t.Fatalf("Type size not correct (float): (%d)", TypeFloat.Size())
+	} else if TypeFloat.IsValid() != true {
+		t.Fatalf("Float type should be valid.")
+	}
+}
+
+func TestTypeDouble_Size(t *testing.T) {
+	if TypeDouble.Size() != 8 {
+		t.Fatalf("Type size not correct (double): (%d)", TypeDouble.Size())
+	} else if TypeDouble.IsValid() != true {
+		t.Fatalf("Double type should be valid.")
+	}
+}
+
 func TestTypeShort_Size(t *testing.T) {
 	if TypeShort.Size() != 2 {
 		t.Fatalf("Type size not correct (short): (%d)", TypeShort.Size())
@@ -135,6 +178,18 @@ func TestFormat__AsciiNoNul(t *testing.T) {
 	}
 }
 
+func TestFormat__Utf8(t *testing.T) {
+	r := []byte("Grüße, 東京")
+	r = append(r, 0)
+
+	s, err := FormatFromBytes(r, TypeUtf8, false, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if s != "Grüße, 東京" {
+		t.Fatalf("Format output not correct (UTF-8): [%s]", s)
+	}
+}
+
 func TestFormat__Short(t *testing.T) {
 	r := []byte{0, 1, 0, 2}
 
@@ -196,6 +251,48 @@ func TestFormat__SignedRational(t *testing.T) {
 	}
 }
 
+func TestFormat__Float(t *testing.T) {
+	r := []byte{
+		0x3f, 0xc0, 0x00, 0x00,
+		0xc0, 0x10, 0x00, 0x00,
+	}
+
+	s, err := FormatFromBytes(r, TypeFloat, false, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if s != "[1.5 -2.25]" {
+		t.Fatalf("Format output not correct (floats): [%s]", s)
+	}
+
+	s, err = FormatFromBytes(r, TypeFloat, true, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if s != "1.5..." {
+		t.Fatalf("Format output not correct (floats, first): [%s]", s)
+	}
+}
+
+func TestFormat__Double(t *testing.T) {
+	r := []byte{
+		0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xc0, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	s, err := FormatFromBytes(r, TypeDouble, false, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if s != "[1.5 -2.25]" {
+		t.Fatalf("Format output not correct (doubles): [%s]", s)
+	}
+
+	s, err = FormatFromBytes(r, TypeDouble, true, TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if s != "1.5..." {
+		t.Fatalf("Format output not correct (doubles, first): [%s]", s)
+	}
+}
+
 func TestFormat__Undefined(t *testing.T) {
 	r := []byte{'a', 'b'}
 
@@ -225,6 +322,33 @@ func TestTranslateStringToType__TypeByte(t *testing.T) {
 	}
 }
 
+func TestTranslateStringToType__TypeByte_HighValues(t *testing.T) {
+	testCases := map[string]byte{
+		"80": 0x80,
+		"9a": 0x9a,
+		"fe": 0xfe,
+		"ff": 0xff,
+	}
+
+	for valueString, expected := range testCases {
+		v, err := TranslateStringToType(TypeByte, valueString)
+		log.PanicIf(err)
+
+		if v != expected {
+			t.Fatalf("Translation of [%s] not correct: %v", valueString, v)
+		}
+	}
+}
+
+func TestTranslateStringToType__TypeByte_Invalid(t *testing.T) {
+	for _, valueString := range []string{"-1", "-80", "100"} {
+		_, err := TranslateStringToType(TypeByte, valueString)
+		if err == nil {
+			t.Fatalf("Expected error for [%s].", valueString)
+		}
+	}
+}
+
 func TestTranslateStringToType__TypeAscii(t *testing.T) {
 	v, err := TranslateStringToType(TypeAscii, "abcdefgh")
 	log.PanicIf(err)
@@ -234,6 +358,15 @@ func TestTranslateStringToType__TypeAscii(t *testing.T) {
 	}
 }
 
+func TestTranslateStringToType__TypeUtf8(t *testing.T) {
+	v, err := TranslateStringToType(TypeUtf8, "Grüße")
+	log.PanicIf(err)
+
+	if v != "Grüße" {
+		t.Fatalf("Translation of string to type not correct (UTF-8): %v", v)
+	}
+}
+
 func TestTranslateStringToType__TypeAsciiNoNul(t *testing.T) {
 	v, err := TranslateStringToType(TypeAsciiNoNul, "abcdefgh")
 	log.PanicIf(err)
@@ -281,6 +414,24 @@ func TestTranslateStringToType__TypeSignedLong(t *testing.T) {
 	}
 }
 
+func TestTranslateStringToType__TypeFloat(t *testing.T) {
+	v, err := TranslateStringToType(TypeFloat, "-2.25")
+	log.PanicIf(err)
+
+	if v != float32(-2.25) {
+		t.Fatalf("Translation of string to type not correct (float): %v", v)
+	}
+}
+
+func TestTranslateStringToType__TypeDouble(t *testing.T) {
+	v, err := TranslateStringToType(TypeDouble, "-2.25")
+	log.PanicIf(err)
+
+	if v != -2.25 {
+		t.Fatalf("Translation of string to type not correct (double): %v", v)
+	}
+}
+
 func TestTranslateStringToType__TypeSignedRational(t *testing.T) {
 	v, err := TranslateStringToType(TypeSignedRational, "11/22")
 	log.PanicIf(err)
@@ -342,3 +493,56 @@ func TestTranslateStringToType__InvalidType(t *testing.T) {
 //     log.Panicf("from-string encoding for type not supported; this shouldn't happen: [%s]", tagType.String())
 //     return nil, nil
 // }
+
+func TestTranslateStringToType__TypeByte_High(t *testing.T) {
+	v, err := TranslateStringToType(TypeByte, "ff")
+	log.PanicIf(err)
+
+	if v != byte(0xff) {
+		t.Fatalf("Translation of string to type not correct (bytes): %v", v)
+	}
+}
+
+func TestTranslateStringsToType__TypeShort(t *testing.T) {
+	v, err := TranslateStringsToType(TypeShort, []string{"11", "22"})
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(v, []uint16{11, 22}) != true {
+		t.Fatalf("Translation of strings to type not correct (short): %v", v)
+	}
+}
+
+func TestTranslateStringsToType__TypeRational(t *testing.T) {
+	v, err := TranslateStringsToType(TypeRational, []string{"11/22", "33/44"})
+	log.PanicIf(err)
+
+	expected := []Rational{
+		{Numerator: 11, Denominator: 22},
+		{Numerator: 33, Denominator: 44},
+	}
+
+	if reflect.DeepEqual(v, expected) != true {
+		t.Fatalf("Translation of strings to type not correct (rational): %v", v)
+	}
+}
+
+func TestTranslateStringsToType__TypeAscii(t *testing.T) {
+	v, err := TranslateStringsToType(TypeAscii, []string{"abc"})
+	log.PanicIf(err)
+
+	if v != "abc" {
+		t.Fatalf("Translation of strings to type not correct (ascii): %v", v)
+	}
+
+	_, err = TranslateStringsToType(TypeAscii, []string{"abc", "def"})
+	if err == nil {
+		t.Fatalf("Expected error for multiple ASCII strings.")
+	}
+}
+
+func TestTranslateStringsToType__Empty(t *testing.T) {
+	_, err := TranslateStringsToType(TypeShort, []string{})
+	if err == nil {
+		t.Fatalf("Expected error for no values.")
+	}
+}
@@ -139,7 +139,7 @@ func (vc *ValueContext) effectiveValueType() (tagType TagTypePrimitive) {
 func (vc *ValueContext) readRawEncoded() (rawBytes []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -147,17 +147,58 @@ func (vc *ValueContext) readRawEncoded() (rawBytes []byte, err error) {
 
 	unitSizeRaw := uint32(tagType.Size())
 
+	byteLength := uint64(unitSizeRaw) * uint64(vc.unitCount)
+
 	if vc.isEmbedded() == true {
-		byteLength := unitSizeRaw * vc.unitCount
+		if uint64(len(vc.rawValueOffset)) < byteLength {
+			te := &TruncatedError{
+				IfdPath:   vc.ifdPath,
+				TagId:     vc.tagId,
+				Needed:    int(byteLength),
+				Available: len(vc.rawValueOffset),
+			}
+
+			return nil, te
+		}
+
 		return vc.rawValueOffset[:byteLength], nil
 	}
 
-	return vc.addressableData[vc.valueOffset : vc.valueOffset+vc.unitCount*unitSizeRaw], nil
+	dataLength := uint64(len(vc.addressableData))
+
+	if uint64(vc.valueOffset) >= dataLength {
+		oe := &OffsetError{
+			IfdPath:    vc.ifdPath,
+			TagId:      vc.tagId,
+			Offset:     vc.valueOffset,
+			DataLength: len(vc.addressableData),
+		}
+
+		return nil, oe
+	} else if uint64(vc.valueOffset)+byteLength > dataLength {
+		te := &TruncatedError{
+			IfdPath:   vc.ifdPath,
+			TagId:     vc.tagId,
+			Offset:    vc.valueOffset,
+			Needed:    int(byteLength),
+			Available: int(dataLength - uint64(vc.valueOffset)),
+		}
+
+		return nil, te
+	}
+
+	return vc.addressableData[uint64(vc.valueOffset) : uint64(vc.valueOffset)+byteLength], nil
 }
 
 // GetFarOffset returns the offset if the value is not embedded [within the
 // pointer itself] or an error if an embedded value.
 func (vc *ValueContext) GetFarOffset() (offset uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
 	if vc.isEmbedded() == true {
 		return 0, ErrNotFarValue
 	}
@@ -185,15 +226,19 @@ func (vc *ValueContext) ReadRawEncoded() (rawBytes []byte, err error) {
 func (vc *ValueContext) Format() (value string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawBytes, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	phrase, err := FormatFromBytes(rawBytes, vc.effectiveValueType(), false, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return phrase, nil
 }
@@ -203,15 +248,19 @@ func (vc *ValueContext) Format() (value string, err error) {
 func (vc *ValueContext) FormatFirst() (value string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawBytes, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	phrase, err := FormatFromBytes(rawBytes, vc.tagType, true, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return phrase, nil
 }
@@ -220,15 +269,19 @@ func (vc *ValueContext) FormatFirst() (value string, err error) {
 func (vc *ValueContext) ReadBytes() (value []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseBytes(rawValue, vc.unitCount)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -238,15 +291,19 @@ func (vc *ValueContext) ReadBytes() (value []byte, err error) {
 func (vc *ValueContext) ReadAscii() (value string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseAscii(rawValue, vc.unitCount)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -256,15 +313,41 @@ func (vc *ValueContext) ReadAscii() (value string, err error) {
 func (vc *ValueContext) ReadAsciiNoNul() (value string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseAsciiNoNul(rawValue, vc.unitCount)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// ReadUtf8 parses the encoded NUL-terminated UTF-8 string from the value-
+// context.
+func (vc *ValueContext) ReadUtf8() (value string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	rawValue, err := vc.readRawEncoded()
+	if err != nil {
+		return value, err
+	}
+
+	value, err = parser.ParseUtf8(rawValue, vc.unitCount)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -273,15 +356,19 @@ func (vc *ValueContext) ReadAsciiNoNul() (value string, err error) {
 func (vc *ValueContext) ReadShorts() (value []uint16, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseShorts(rawValue, vc.unitCount, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -290,15 +377,19 @@ func (vc *ValueContext) ReadShorts() (value []uint16, err error) {
 func (vc *ValueContext) ReadLongs() (value []uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseLongs(rawValue, vc.unitCount, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -308,15 +399,19 @@ func (vc *ValueContext) ReadLongs() (value []uint32, err error) {
 func (vc *ValueContext) ReadRationals() (value []Rational, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseRationals(rawValue, vc.unitCount, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -325,15 +420,19 @@ func (vc *ValueContext) ReadRationals() (value []Rational, err error) {
 func (vc *ValueContext) ReadSignedLongs() (value []int32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseSignedLongs(rawValue, vc.unitCount, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -343,15 +442,61 @@ func (vc *ValueContext) ReadSignedLongs() (value []int32, err error) {
 func (vc *ValueContext) ReadSignedRationals() (value []SignedRational, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	rawValue, err := vc.readRawEncoded()
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
 
 	value, err = parser.ParseSignedRationals(rawValue, vc.unitCount, vc.byteOrder)
-	log.PanicIf(err)
+	if err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// ReadFloats parses the list of encoded floats from the value-context.
+func (vc *ValueContext) ReadFloats() (value []float32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	rawValue, err := vc.readRawEncoded()
+	if err != nil {
+		return value, err
+	}
+
+	value, err = parser.ParseFloats(rawValue, vc.unitCount, vc.byteOrder)
+	if err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// ReadDoubles parses the list of encoded doubles from the value-context.
+func (vc *ValueContext) ReadDoubles() (value []float64, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	rawValue, err := vc.readRawEncoded()
+	if err != nil {
+		return value, err
+	}
+
+	value, err = parser.ParseDoubles(rawValue, vc.unitCount, vc.byteOrder)
+	if err != nil {
+		return value, err
+	}
 
 	return value, nil
 }
@@ -365,43 +510,50 @@ func (vc *ValueContext) ReadSignedRationals() (value []SignedRational, err error
 func (vc *ValueContext) Values() (values interface{}, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	if vc.tagType == TypeByte {
 		values, err = vc.ReadBytes()
-		log.PanicIf(err)
 	} else if vc.tagType == TypeAscii {
 		values, err = vc.ReadAscii()
-		log.PanicIf(err)
 	} else if vc.tagType == TypeAsciiNoNul {
 		values, err = vc.ReadAsciiNoNul()
-		log.PanicIf(err)
+	} else if vc.tagType == TypeUtf8 {
+		values, err = vc.ReadUtf8()
 	} else if vc.tagType == TypeShort {
 		values, err = vc.ReadShorts()
-		log.PanicIf(err)
 	} else if vc.tagType == TypeLong {
 		values, err = vc.ReadLongs()
-		log.PanicIf(err)
 	} else if vc.tagType == TypeRational {
 		values, err = vc.ReadRationals()
-		log.PanicIf(err)
 	} else if vc.tagType == TypeSignedLong {
 		values, err = vc.ReadSignedLongs()
-		log.PanicIf(err)
 	} else if vc.tagType == TypeSignedRational {
 		values, err = vc.ReadSignedRationals()
-		log.PanicIf(err)
+	} else if vc.tagType == TypeFloat {
+		values, err = vc.ReadFloats()
+	} else if vc.tagType == TypeDouble {
+		values, err = vc.ReadDoubles()
 	} else if vc.tagType == TypeUndefined {
 		log.Panicf("will not parse undefined-type value")
 
 		// Never called.
 		return nil, nil
 	} else {
-		log.Panicf("value of type [%s] is unparseable", vc.tagType)
-		// Never called.
-		return nil, nil
+		te := &TypeError{
+			IfdPath: vc.ifdPath,
+			TagId:   vc.tagId,
+			TagType: vc.tagType,
+			Err:     ErrTagTypeNotValid,
+		}
+
+		return nil, te
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
 	return values, nil
@@ -719,6 +719,35 @@ func TestValueContext_Values__Ascii(t *testing.T) {
 	}
 }
 
+func TestValueContext_Values__Utf8(t *testing.T) {
+	data := []byte("Grüße")
+	data = append(data, 0)
+
+	unitCount := uint32(len(data))
+
+	rawValueOffset := []byte{0, 0, 0, 4}
+	valueOffset := uint32(4)
+
+	addressableData := []byte{0, 0, 0, 0}
+	addressableData = append(addressableData, data...)
+
+	vc := NewValueContext("aa/bb", 0x1234, unitCount, valueOffset, rawValueOffset, addressableData, TypeUtf8, TestDefaultByteOrder)
+
+	value, err := vc.Values()
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, "Grüße") != true {
+		t.Fatalf("Values not correct (UTF-8): [%s]", value)
+	}
+
+	phrase, err := vc.Format()
+	log.PanicIf(err)
+
+	if phrase != "Grüße" {
+		t.Fatalf("Format not correct (UTF-8): [%s]", phrase)
+	}
+}
+
 func TestValueContext_Values__AsciiNoNul(t *testing.T) {
 	unitCount := uint32(8)
 
@@ -856,3 +885,94 @@ func TestValueContext_Values__SignedRational(t *testing.T) {
 		t.Fatalf("Values not correct (signed rationals): %v", value)
 	}
 }
+
+func TestValueContext_Values__Float(t *testing.T) {
+	unitCount := uint32(2)
+
+	rawValueOffset := []byte{0, 0, 0, 4}
+	valueOffset := uint32(4)
+
+	data := []byte{
+		0x3f, 0xc0, 0x00, 0x00,
+		0xc0, 0x10, 0x00, 0x00,
+	}
+
+	addressableData := []byte{0, 0, 0, 0}
+	addressableData = append(addressableData, data...)
+
+	vc := NewValueContext("aa/bb", 0x1234, unitCount, valueOffset, rawValueOffset, addressableData, TypeFloat, TestDefaultByteOrder)
+
+	value, err := vc.Values()
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, []float32{1.5, -2.25}) != true {
+		t.Fatalf("Values not correct (floats): %v", value)
+	}
+
+	phrase, err := vc.Format()
+	log.PanicIf(err)
+
+	if phrase != "[1.5 -2.25]" {
+		t.Fatalf("Format not correct (floats): [%s]", phrase)
+	}
+}
+
+func TestValueContext_Values__Double(t *testing.T) {
+	unitCount := uint32(2)
+
+	rawValueOffset := []byte{0, 0, 0, 4}
+	valueOffset := uint32(4)
+
+	data := []byte{
+		0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xc0, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	addressableData := []byte{0, 0, 0, 0}
+	addressableData = append(addressableData, data...)
+
+	vc := NewValueContext("aa/bb", 0x1234, unitCount, valueOffset, rawValueOffset, addressableData, TypeDouble, TestDefaultByteOrder)
+
+	value, err := vc.Values()
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, []float64{1.5, -2.25}) != true {
+		t.Fatalf("Values not correct (doubles): %v", value)
+	}
+
+	phrase, err := vc.Format()
+	log.PanicIf(err)
+
+	if phrase != "[1.5 -2.25]" {
+		t.Fatalf("Format not correct (doubles): [%s]", phrase)
+	}
+}
+
+func TestValueContext_ReadLongs_ErrorsNotWrapped(t *testing.T) {
+	addressableData := []byte{0, 0, 0, 0, 0, 0, 0, 0}
+
+	// The value is past the end of the data.
+	vc := NewValueContext("IFD", 0x0100, 2, 100, nil, addressableData, TypeLong, TestDefaultByteOrder)
+
+	_, err := vc.ReadLongs()
+	if oe, ok := err.(*OffsetError); ok == false {
+		t.Fatalf("Expected an unwrapped offset error: %v", err)
+	} else if oe.Offset != 100 || oe.TagId != 0x0100 {
+		t.Fatalf("Offset error not correct: %v", oe)
+	}
+
+	// The value starts in the data but runs off the end.
+	vc = NewValueContext("IFD", 0x0100, 2, 4, nil, addressableData, TypeLong, TestDefaultByteOrder)
+
+	_, err = vc.ReadLongs()
+	if te, ok := err.(*TruncatedError); ok == false {
+		t.Fatalf("Expected an unwrapped truncated error: %v", err)
+	} else if te.Needed != 8 || te.Available != 4 {
+		t.Fatalf("Truncated error not correct: %v", te)
+	}
+
+	_, err = vc.Values()
+	if _, ok := err.(*TruncatedError); ok == false {
+		t.Fatalf("Expected an unwrapped truncated error from Values: %v", err)
+	}
+}
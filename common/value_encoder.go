@@ -2,6 +2,7 @@ package exifcommon
 
 import (
 	"bytes"
+	"math"
 	"reflect"
 	"time"
 
@@ -75,10 +76,24 @@ func (ve *ValueEncoder) encodeAsciiNoNul(value string) (ed EncodedData, err erro
 	return ed, nil
 }
 
+// EncodeUtf8 returns a string encoded as a NUL-terminated UTF-8 string. The
+// bytes are the same as for ASCII, so `Encode()` can only produce the latter;
+// this is used where the tag has the UTF-8 type.
+func (ve *ValueEncoder) EncodeUtf8(value string) (ed EncodedData, err error) {
+	ed.Type = TypeUtf8
+
+	ed.Encoded = []byte(value)
+	ed.Encoded = append(ed.Encoded, 0)
+
+	ed.UnitCount = uint32(len(ed.Encoded))
+
+	return ed, nil
+}
+
 func (ve *ValueEncoder) encodeShorts(value []uint16) (ed EncodedData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -97,7 +112,7 @@ func (ve *ValueEncoder) encodeShorts(value []uint16) (ed EncodedData, err error)
 func (ve *ValueEncoder) encodeLongs(value []uint32) (ed EncodedData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -116,7 +131,7 @@ func (ve *ValueEncoder) encodeLongs(value []uint32) (ed EncodedData, err error)
 func (ve *ValueEncoder) encodeRationals(value []Rational) (ed EncodedData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -136,7 +151,7 @@ func (ve *ValueEncoder) encodeRationals(value []Rational) (ed EncodedData, err e
 func (ve *ValueEncoder) encodeSignedLongs(value []int32) (ed EncodedData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -158,7 +173,7 @@ func (ve *ValueEncoder) encodeSignedLongs(value []int32) (ed EncodedData, err er
 func (ve *ValueEncoder) encodeSignedRationals(value []SignedRational) (ed EncodedData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -180,13 +195,39 @@ func (ve *ValueEncoder) encodeSignedRationals(value []SignedRational) (ed Encode
 	return ed, nil
 }
 
+func (ve *ValueEncoder) encodeFloats(value []float32) (ed EncodedData, err error) {
+	ed.UnitCount = uint32(len(value))
+	ed.Encoded = make([]byte, 4*len(value))
+
+	for i, f := range value {
+		ve.byteOrder.PutUint32(ed.Encoded[i*4:], math.Float32bits(f))
+	}
+
+	ed.Type = TypeFloat
+
+	return ed, nil
+}
+
+func (ve *ValueEncoder) encodeDoubles(value []float64) (ed EncodedData, err error) {
+	ed.UnitCount = uint32(len(value))
+	ed.Encoded = make([]byte, 8*len(value))
+
+	for i, f := range value {
+		ve.byteOrder.PutUint64(ed.Encoded[i*8:], math.Float64bits(f))
+	}
+
+	ed.Type = TypeDouble
+
+	return ed, nil
+}
+
 // Encode returns bytes for the given value, infering type from the actual
 // value. This does not support `TypeAsciiNoNull` (all strings are encoded as
 // `TypeAscii`).
 func (ve *ValueEncoder) Encode(value interface{}) (ed EncodedData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -212,6 +253,12 @@ func (ve *ValueEncoder) Encode(value interface{}) (ed EncodedData, err error) {
 	case []SignedRational:
 		ed, err = ve.encodeSignedRationals(value.([]SignedRational))
 		log.PanicIf(err)
+	case []float32:
+		ed, err = ve.encodeFloats(value.([]float32))
+		log.PanicIf(err)
+	case []float64:
+		ed, err = ve.encodeDoubles(value.([]float64))
+		log.PanicIf(err)
 	case time.Time:
 		// For convenience, if the user doesn't want to deal with translation
 		// semantics with timestamps.
@@ -71,6 +71,36 @@ func TestValueEncoder_encodeAscii__Cycle(t *testing.T) {
 	}
 }
 
+func TestValueEncoder_EncodeUtf8__Cycle(t *testing.T) {
+	byteOrder := TestDefaultByteOrder
+	ve := NewValueEncoder(byteOrder)
+
+	original := "Grüße, 東京"
+
+	ed, err := ve.EncodeUtf8(original)
+	log.PanicIf(err)
+
+	if ed.Type != TypeUtf8 {
+		t.Fatalf("IFD type not expected.")
+	}
+
+	expected := []byte(original)
+	expected = append(expected, 0)
+
+	if reflect.DeepEqual(ed.Encoded, expected) != true {
+		t.Fatalf("Data not encoded correctly.")
+	} else if ed.UnitCount != uint32(len(expected)) {
+		t.Fatalf("Unit-count not correct.")
+	}
+
+	recovered, err := parser.ParseUtf8(ed.Encoded, ed.UnitCount)
+	log.PanicIf(err)
+
+	if recovered != original {
+		t.Fatalf("Value not recovered correctly.")
+	}
+}
+
 func TestValueEncoder_encodeAsciiNoNul__Cycle(t *testing.T) {
 	byteOrder := TestDefaultByteOrder
 	ve := NewValueEncoder(byteOrder)
@@ -590,3 +620,67 @@ func TestValueEncoder_Encode__Timestamp(t *testing.T) {
 		t.Fatalf("Timestamp not encoded correctly: [%s] != [%s]", string(ed.Encoded), string(expected))
 	}
 }
+
+func TestValueEncoder_Encode__Float(t *testing.T) {
+	byteOrder := TestDefaultByteOrder
+	ve := NewValueEncoder(byteOrder)
+
+	original := []float32{1.5, -2.25}
+
+	ed, err := ve.Encode(original)
+	log.PanicIf(err)
+
+	if ed.Type != TypeFloat {
+		t.Fatalf("IFD type not expected.")
+	}
+
+	expected := []byte{
+		0x3f, 0xc0, 0x00, 0x00,
+		0xc0, 0x10, 0x00, 0x00,
+	}
+
+	if reflect.DeepEqual(ed.Encoded, expected) != true {
+		t.Fatalf("Data not encoded correctly.")
+	} else if ed.UnitCount != 2 {
+		t.Fatalf("Unit-count not correct.")
+	}
+
+	recovered, err := parser.ParseFloats(ed.Encoded, ed.UnitCount, byteOrder)
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(recovered, original) != true {
+		t.Fatalf("Value not recovered correctly.")
+	}
+}
+
+func TestValueEncoder_Encode__Double(t *testing.T) {
+	byteOrder := TestDefaultByteOrder
+	ve := NewValueEncoder(byteOrder)
+
+	original := []float64{1.5, -2.25}
+
+	ed, err := ve.Encode(original)
+	log.PanicIf(err)
+
+	if ed.Type != TypeDouble {
+		t.Fatalf("IFD type not expected.")
+	}
+
+	expected := []byte{
+		0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xc0, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	if reflect.DeepEqual(ed.Encoded, expected) != true {
+		t.Fatalf("Data not encoded correctly.")
+	} else if ed.UnitCount != 2 {
+		t.Fatalf("Unit-count not correct.")
+	}
+
+	recovered, err := parser.ParseDoubles(ed.Encoded, ed.UnitCount, byteOrder)
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(recovered, original) != true {
+		t.Fatalf("Value not recovered correctly.")
+	}
+}
@@ -0,0 +1,592 @@
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"encoding/json"
+	"unicode/utf8"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+// conformanceFormat is a textual format that a value must have.
+type conformanceFormat int
+
+const (
+	conformanceFormatNone conformanceFormat = iota
+
+	// conformanceFormatDateTime is "YYYY:MM:DD HH:MM:SS".
+	conformanceFormatDateTime
+
+	// conformanceFormatDate is "YYYY:MM:DD".
+	conformanceFormatDate
+
+	// conformanceFormatOffsetTime is "+HH:MM" or "-HH:MM".
+	conformanceFormatOffsetTime
+
+	// conformanceFormatVersion is four ASCII digits (e.g. "0232").
+	conformanceFormatVersion
+)
+
+// conformanceTagKey identifies a tag in a (non-fully-qualified) IFD.
+type conformanceTagKey struct {
+	ifdPath string
+	tagId   uint16
+}
+
+// conformanceRule is what the standard requires of a tag's value.
+type conformanceRule struct {
+	// count is the required unit-count. Zero allows any count.
+	count uint32
+
+	// values are the allowed units of an integer value (or of the bytes of an
+	// undefined value). Empty allows any value.
+	values []int64
+
+	// strings are the allowed ASCII values. Empty allows any value.
+	strings []string
+
+	// format is the required format of an ASCII value.
+	format conformanceFormat
+}
+
+const (
+	conformanceIfdPath     = "IFD"
+	conformanceExifIfdPath = "IFD/Exif"
+	conformanceGpsIfdPath  = "IFD/GPSInfo"
+	conformanceIopIfdPath  = "IFD/Exif/Iop"
+
+	conformanceDateTimeLayout   = "2006:01:02 15:04:05"
+	conformanceDateLayout       = "2006:01:02"
+	conformanceOffsetTimeLayout = "-07:00"
+)
+
+var (
+	// conformanceRequiredTags are the tags that the standard requires for a
+	// compressed (JPEG) primary image, by IFD.
+	conformanceRequiredTags = map[string][]uint16{
+		conformanceExifIfdPath: {
+			0x9000, // ExifVersion
+			0x9101, // ComponentsConfiguration
+			0xa000, // FlashpixVersion
+			0xa001, // ColorSpace
+			0xa002, // PixelXDimension
+			0xa003, // PixelYDimension
+		},
+	}
+
+	// conformanceRules are the count, range, and format constraints from
+	// CIPA DC-008 (EXIF 2.32 and 3.0). Tags that are not listed are only
+	// checked for their type.
+	conformanceRules = map[conformanceTagKey]conformanceRule{
+		// IFD0 and IFD1.
+
+		{conformanceIfdPath, 0x0100}: {count: 1},                                     // ImageWidth
+		{conformanceIfdPath, 0x0101}: {count: 1},                                     // ImageLength
+		{conformanceIfdPath, 0x0102}: {count: 3},                                     // BitsPerSample
+		{conformanceIfdPath, 0x0103}: {count: 1, values: []int64{1, 6}},              // Compression
+		{conformanceIfdPath, 0x0106}: {count: 1, values: []int64{2, 6}},              // PhotometricInterpretation
+		{conformanceIfdPath, 0x0112}: {count: 1, values: conformanceRange(1, 8)},     // Orientation
+		{conformanceIfdPath, 0x0115}: {count: 1, values: []int64{3}},                 // SamplesPerPixel
+		{conformanceIfdPath, 0x011a}: {count: 1},                                     // XResolution
+		{conformanceIfdPath, 0x011b}: {count: 1},                                     // YResolution
+		{conformanceIfdPath, 0x011c}: {count: 1, values: []int64{1, 2}},              // PlanarConfiguration
+		{conformanceIfdPath, 0x0128}: {count: 1, values: []int64{2, 3}},              // ResolutionUnit
+		{conformanceIfdPath, 0x012d}: {count: 3 * 256},                               // TransferFunction
+		{conformanceIfdPath, 0x0132}: {count: 20, format: conformanceFormatDateTime}, // DateTime
+		{conformanceIfdPath, 0x013e}: {count: 2},                                     // WhitePoint
+		{conformanceIfdPath, 0x013f}: {count: 6},                                     // PrimaryChromaticities
+		{conformanceIfdPath, 0x0201}: {count: 1},                                     // JPEGInterchangeFormat
+		{conformanceIfdPath, 0x0202}: {count: 1},                                     // JPEGInterchangeFormatLength
+		{conformanceIfdPath, 0x0211}: {count: 3},                                     // YCbCrCoefficients
+		{conformanceIfdPath, 0x0212}: {count: 2, values: []int64{1, 2}},              // YCbCrSubSampling
+		{conformanceIfdPath, 0x0213}: {count: 1, values: []int64{1, 2}},              // YCbCrPositioning
+		{conformanceIfdPath, 0x0214}: {count: 6},                                     // ReferenceBlackWhite
+		{conformanceIfdPath, 0x8769}: {count: 1},                                     // ExifTag
+		{conformanceIfdPath, 0x8825}: {count: 1},                                     // GPSTag
+
+		// EXIF IFD.
+
+		{conformanceExifIfdPath, 0x829a}: {count: 1},                                              // ExposureTime
+		{conformanceExifIfdPath, 0x829d}: {count: 1},                                              // FNumber
+		{conformanceExifIfdPath, 0x8822}: {count: 1, values: conformanceRange(0, 8)},              // ExposureProgram
+		{conformanceExifIfdPath, 0x8830}: {count: 1, values: conformanceRange(0, 7)},              // SensitivityType
+		{conformanceExifIfdPath, 0x9000}: {count: 4, format: conformanceFormatVersion},            // ExifVersion
+		{conformanceExifIfdPath, 0x9003}: {count: 20, format: conformanceFormatDateTime},          // DateTimeOriginal
+		{conformanceExifIfdPath, 0x9004}: {count: 20, format: conformanceFormatDateTime},          // DateTimeDigitized
+		{conformanceExifIfdPath, 0x9010}: {count: 7, format: conformanceFormatOffsetTime},         // OffsetTime
+		{conformanceExifIfdPath, 0x9011}: {count: 7, format: conformanceFormatOffsetTime},         // OffsetTimeOriginal
+		{conformanceExifIfdPath, 0x9012}: {count: 7, format: conformanceFormatOffsetTime},         // OffsetTimeDigitized
+		{conformanceExifIfdPath, 0x9101}: {count: 4, values: conformanceRange(0, 6)},              // ComponentsConfiguration
+		{conformanceExifIfdPath, 0x9102}: {count: 1},                                              // CompressedBitsPerPixel
+		{conformanceExifIfdPath, 0x9201}: {count: 1},                                              // ShutterSpeedValue
+		{conformanceExifIfdPath, 0x9202}: {count: 1},                                              // ApertureValue
+		{conformanceExifIfdPath, 0x9203}: {count: 1},                                              // BrightnessValue
+		{conformanceExifIfdPath, 0x9204}: {count: 1},                                              // ExposureBiasValue
+		{conformanceExifIfdPath, 0x9205}: {count: 1},                                              // MaxApertureValue
+		{conformanceExifIfdPath, 0x9206}: {count: 1},                                              // SubjectDistance
+		{conformanceExifIfdPath, 0x9207}: {count: 1, values: append(conformanceRange(0, 6), 255)}, // MeteringMode
+		{conformanceExifIfdPath, 0x9208}: {count: 1, values: conformanceLightSources},             // LightSource
+		{conformanceExifIfdPath, 0x9209}: {count: 1},                                              // Flash
+		{conformanceExifIfdPath, 0x920a}: {count: 1},                                              // FocalLength
+		{conformanceExifIfdPath, 0xa000}: {count: 4, format: conformanceFormatVersion},            // FlashpixVersion
+		{conformanceExifIfdPath, 0xa001}: {count: 1, values: []int64{1, 0xffff}},                  // ColorSpace
+		{conformanceExifIfdPath, 0xa002}: {count: 1},                                              // PixelXDimension
+		{conformanceExifIfdPath, 0xa003}: {count: 1},                                              // PixelYDimension
+		{conformanceExifIfdPath, 0xa004}: {count: 13},                                             // RelatedSoundFile
+		{conformanceExifIfdPath, 0xa005}: {count: 1},                                              // InteroperabilityTag
+		{conformanceExifIfdPath, 0xa20b}: {count: 1},                                              // FlashEnergy
+		{conformanceExifIfdPath, 0xa20e}: {count: 1},                                              // FocalPlaneXResolution
+		{conformanceExifIfdPath, 0xa20f}: {count: 1},                                              // FocalPlaneYResolution
+		{conformanceExifIfdPath, 0xa210}: {count: 1, values: []int64{2, 3}},                       // FocalPlaneResolutionUnit
+		{conformanceExifIfdPath, 0xa214}: {count: 2},                                              // SubjectLocation
+		{conformanceExifIfdPath, 0xa215}: {count: 1},                                              // ExposureIndex
+		{conformanceExifIfdPath, 0xa217}: {count: 1, values: []int64{1, 2, 3, 4, 5, 7, 8}},        // SensingMethod
+		{conformanceExifIfdPath, 0xa300}: {count: 1, values: conformanceRange(0, 3)},              // FileSource
+		{conformanceExifIfdPath, 0xa301}: {count: 1, values: []int64{1}},                          // SceneType
+		{conformanceExifIfdPath, 0xa401}: {count: 1, values: conformanceRange(0, 1)},              // CustomRendered
+		{conformanceExifIfdPath, 0xa402}: {count: 1, values: conformanceRange(0, 2)},              // ExposureMode
+		{conformanceExifIfdPath, 0xa403}: {count: 1, values: conformanceRange(0, 1)},              // WhiteBalance
+		{conformanceExifIfdPath, 0xa404}: {count: 1},                                              // DigitalZoomRatio
+		{conformanceExifIfdPath, 0xa405}: {count: 1},                                              // FocalLengthIn35mmFilm
+		{conformanceExifIfdPath, 0xa406}: {count: 1, values: conformanceRange(0, 3)},              // SceneCaptureType
+		{conformanceExifIfdPath, 0xa407}: {count: 1, values: conformanceRange(0, 4)},              // GainControl
+		{conformanceExifIfdPath, 0xa408}: {count: 1, values: conformanceRange(0, 2)},              // Contrast
+		{conformanceExifIfdPath, 0xa409}: {count: 1, values: conformanceRange(0, 2)},              // Saturation
+		{conformanceExifIfdPath, 0xa40a}: {count: 1, values: conformanceRange(0, 2)},              // Sharpness
+		{conformanceExifIfdPath, 0xa40c}: {count: 1, values: conformanceRange(0, 3)},              // SubjectDistanceRange
+		{conformanceExifIfdPath, 0xa420}: {count: 33},                                             // ImageUniqueID
+		{conformanceExifIfdPath, 0xa432}: {count: 4},                                              // LensSpecification
+		{conformanceExifIfdPath, 0xa460}: {count: 1, values: conformanceRange(0, 3)},              // CompositeImage
+		{conformanceExifIfdPath, 0xa461}: {count: 2},                                              // SourceImageNumberOfCompositeImage
+
+		// GPS IFD.
+
+		{conformanceGpsIfdPath, 0x0000}: {count: 4},                                   // GPSVersionID
+		{conformanceGpsIfdPath, 0x0001}: {count: 2, strings: []string{"N", "S"}},      // GPSLatitudeRef
+		{conformanceGpsIfdPath, 0x0002}: {count: 3},                                   // GPSLatitude
+		{conformanceGpsIfdPath, 0x0003}: {count: 2, strings: []string{"E", "W"}},      // GPSLongitudeRef
+		{conformanceGpsIfdPath, 0x0004}: {count: 3},                                   // GPSLongitude
+		{conformanceGpsIfdPath, 0x0005}: {count: 1, values: conformanceRange(0, 1)},   // GPSAltitudeRef
+		{conformanceGpsIfdPath, 0x0006}: {count: 1},                                   // GPSAltitude
+		{conformanceGpsIfdPath, 0x0007}: {count: 3},                                   // GPSTimeStamp
+		{conformanceGpsIfdPath, 0x0009}: {count: 2, strings: []string{"A", "V"}},      // GPSStatus
+		{conformanceGpsIfdPath, 0x000a}: {count: 2, strings: []string{"2", "3"}},      // GPSMeasureMode
+		{conformanceGpsIfdPath, 0x000b}: {count: 1},                                   // GPSDOP
+		{conformanceGpsIfdPath, 0x000c}: {count: 2, strings: []string{"K", "M", "N"}}, // GPSSpeedRef
+		{conformanceGpsIfdPath, 0x000d}: {count: 1},                                   // GPSSpeed
+		{conformanceGpsIfdPath, 0x000e}: {count: 2, strings: []string{"T", "M"}},      // GPSTrackRef
+		{conformanceGpsIfdPath, 0x000f}: {count: 1},                                   // GPSTrack
+		{conformanceGpsIfdPath, 0x0010}: {count: 2, strings: []string{"T", "M"}},      // GPSImgDirectionRef
+		{conformanceGpsIfdPath, 0x0011}: {count: 1},                                   // GPSImgDirection
+		{conformanceGpsIfdPath, 0x0013}: {count: 2, strings: []string{"N", "S"}},      // GPSDestLatitudeRef
+		{conformanceGpsIfdPath, 0x0014}: {count: 3},                                   // GPSDestLatitude
+		{conformanceGpsIfdPath, 0x0015}: {count: 2, strings: []string{"E", "W"}},      // GPSDestLongitudeRef
+		{conformanceGpsIfdPath, 0x0016}: {count: 3},                                   // GPSDestLongitude
+		{conformanceGpsIfdPath, 0x0017}: {count: 2, strings: []string{"T", "M"}},      // GPSDestBearingRef
+		{conformanceGpsIfdPath, 0x0018}: {count: 1},                                   // GPSDestBearing
+		{conformanceGpsIfdPath, 0x0019}: {count: 2, strings: []string{"K", "M", "N"}}, // GPSDestDistanceRef
+		{conformanceGpsIfdPath, 0x001a}: {count: 1},                                   // GPSDestDistance
+		{conformanceGpsIfdPath, 0x001d}: {count: 11, format: conformanceFormatDate},   // GPSDateStamp
+		{conformanceGpsIfdPath, 0x001e}: {count: 1, values: conformanceRange(0, 1)},   // GPSDifferential
+		{conformanceGpsIfdPath, 0x001f}: {count: 1},                                   // GPSHPositioningError
+
+		// Interoperability IFD.
+
+		{conformanceIopIfdPath, 0x0001}: {count: 4},                                   // InteroperabilityIndex
+		{conformanceIopIfdPath, 0x0002}: {count: 4, format: conformanceFormatVersion}, // InteroperabilityVersion
+	}
+
+	// conformanceLightSources are the defined values of LightSource.
+	conformanceLightSources = []int64{0, 1, 2, 3, 4, 9, 10, 11, 12, 13, 14, 15, 17, 18, 19, 20, 21, 22, 23, 24, 255}
+)
+
+// conformanceRange returns the integers from `from` to `to`, inclusive.
+func conformanceRange(from, to int64) []int64 {
+	values := make([]int64, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		values = append(values, n)
+	}
+
+	return values
+}
+
+// ConformanceReport is the result of `ValidateConformance`.
+type ConformanceReport struct {
+	// Findings are the problems, in IFD order. Problems with the structure are
+	// errors and values that the standard does not define are warnings.
+	// Unknown tags are reported as info.
+	Findings []Diagnostic `json:"findings"`
+}
+
+// IsConformant returns true if there are no warnings or errors.
+func (cr *ConformanceReport) IsConformant() bool {
+	return len(cr.FindingsWithSeverity(DiagnosticWarning)) == 0
+}
+
+// FindingsWithSeverity returns the findings that are at least as severe as
+// `severity`.
+func (cr *ConformanceReport) FindingsWithSeverity(severity DiagnosticSeverity) []Diagnostic {
+	filtered := make([]Diagnostic, 0)
+
+	for _, d := range cr.Findings {
+		if d.Severity >= severity {
+			filtered = append(filtered, d)
+		}
+	}
+
+	return filtered
+}
+
+// String renders the findings as text, one per line.
+func (cr *ConformanceReport) String() string {
+	b := new(bytes.Buffer)
+
+	for _, d := range cr.Findings {
+		fmt.Fprintln(b, d.String())
+	}
+
+	return b.String()
+}
+
+// Json renders the findings as JSON.
+func (cr *ConformanceReport) Json() (data []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	findings := cr.Findings
+	if findings == nil {
+		findings = make([]Diagnostic, 0)
+	}
+
+	data, err = json.MarshalIndent(ConformanceReport{Findings: findings}, "", "  ")
+	log.PanicIf(err)
+
+	return data, nil
+}
+
+// ValidateConformance checks an IFD index against CIPA DC-008 (EXIF 2.32 and
+// 3.0). It checks for the required tags of the EXIF IFD, the types, counts,
+// and ranges of the values, the NUL termination of ASCII values, the format of
+// dates, times, and versions, the order of the tags in each IFD, and the word
+// alignment of IFDs and values.
+func ValidateConformance(index IfdIndex) (report *ConformanceReport, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	report = &ConformanceReport{
+		Findings: make([]Diagnostic, 0),
+	}
+
+	for _, ifd := range index.Ifds {
+		validateIfdConformance(ifd, report)
+	}
+
+	for ifdPath, tagIds := range conformanceRequiredTags {
+		ifd := index.Lookup[ifdPath]
+
+		for _, tagId := range tagIds {
+			if ifd != nil {
+				if _, found := ifd.EntriesByTagId[tagId]; found == true {
+					continue
+				}
+			}
+
+			d := Diagnostic{
+				Severity:  DiagnosticError,
+				Code:      DiagnosticTagRequired,
+				FqIfdPath: ifdPath,
+				TagId:     tagId,
+				Message:   "required tag is missing",
+			}
+
+			if ifd != nil {
+				d.Offset = ifd.Offset
+			}
+
+			report.Findings = append(report.Findings, d)
+		}
+	}
+
+	return report, nil
+}
+
+// addTagFinding adds a finding for a tag.
+func (cr *ConformanceReport) addTagFinding(severity DiagnosticSeverity, code DiagnosticCode, ite *IfdTagEntry, format string, args ...interface{}) {
+	d := Diagnostic{
+		Severity:  severity,
+		Code:      code,
+		FqIfdPath: ite.ifdIdentity.String(),
+		TagId:     ite.tagId,
+		Offset:    ite.entryOffset,
+		Message:   fmt.Sprintf(format, args...),
+	}
+
+	cr.Findings = append(cr.Findings, d)
+}
+
+// validateIfdConformance checks one IFD.
+func validateIfdConformance(ifd *Ifd, report *ConformanceReport) {
+	if ifd.Offset%2 != 0 {
+		d := Diagnostic{
+			Severity:  DiagnosticError,
+			Code:      DiagnosticValueMisaligned,
+			FqIfdPath: ifd.ifdIdentity.String(),
+			Offset:    ifd.Offset,
+			Message:   "IFD is not word-aligned",
+		}
+
+		report.Findings = append(report.Findings, d)
+	}
+
+	for i, ite := range ifd.Entries {
+		if i > 0 && ite.tagId <= ifd.Entries[i-1].tagId {
+			report.addTagFinding(
+				DiagnosticError, DiagnosticTagOrderNotValid, ite,
+				"tag follows tag (0x%04x); tags must be in ascending order",
+				ifd.Entries[i-1].tagId)
+		}
+
+		validateTagConformance(ifd, ite, report)
+	}
+}
+
+// validateTagConformance checks one tag.
+func validateTagConformance(ifd *Ifd, ite *IfdTagEntry, report *ConformanceReport) {
+	// The enumerator rewrites this to describe the thumbnail data (as BYTEs)
+	// rather than the offset.
+	if ite.IsThumbnailOffset() == true {
+		return
+	}
+
+	ifdPath := ifd.ifdIdentity.UnindexedString()
+	tagType := ite.TagType()
+
+	it, err := ifd.tagIndex.Get(ifd.ifdIdentity, ite.tagId)
+	if err == nil {
+		if it.DoesSupportType(tagType) == false {
+			report.addTagFinding(
+				DiagnosticError, DiagnosticTagTypeMismatch, ite,
+				"type [%s] is not valid for the tag; expected one of %v",
+				tagType, it.SupportedTypes)
+
+			// None of the other checks make sense.
+			return
+		}
+	} else if err == ErrTagNotFound {
+		if _, err := ifd.tagIndex.FindFirst(ite.tagId, tagType, nil); err == nil {
+			report.addTagFinding(
+				DiagnosticError, DiagnosticTagNotValidForIfd, ite,
+				"tag is not valid in this IFD")
+		} else {
+			report.addTagFinding(
+				DiagnosticInfo, DiagnosticTagUnknown, ite,
+				"tag is not defined by the standard")
+		}
+	} else {
+		log.Panic(err)
+	}
+
+	byteCount := uint64(ite.UnitCount())
+	if tagType != exifcommon.TypeUndefined {
+		byteCount *= uint64(tagType.Size())
+	}
+
+	if byteCount > 4 && ite.getValueOffset()%2 != 0 {
+		report.addTagFinding(
+			DiagnosticError, DiagnosticValueMisaligned, ite,
+			"value at offset (0x%08x) is not word-aligned",
+			ite.getValueOffset())
+	}
+
+	if tagType == exifcommon.TypeAscii {
+		rawBytes, err := ite.GetRawBytes()
+		log.PanicIf(err)
+
+		if len(rawBytes) == 0 || rawBytes[len(rawBytes)-1] != 0 {
+			report.addTagFinding(
+				DiagnosticError, DiagnosticAsciiNotTerminated, ite,
+				"ASCII value is not NUL-terminated")
+		}
+	} else if tagType == exifcommon.TypeUtf8 {
+		rawBytes, err := ite.GetRawBytes()
+		log.PanicIf(err)
+
+		if len(rawBytes) == 0 || rawBytes[len(rawBytes)-1] != 0 {
+			report.addTagFinding(
+				DiagnosticError, DiagnosticUtf8NotValid, ite,
+				"UTF-8 value is not NUL-terminated")
+		} else if utf8.Valid(rawBytes[:len(rawBytes)-1]) == false {
+			report.addTagFinding(
+				DiagnosticError, DiagnosticUtf8NotValid, ite,
+				"UTF-8 value is not valid UTF-8")
+		}
+	}
+
+	rule, found := conformanceRules[conformanceTagKey{ifdPath: ifdPath, tagId: ite.tagId}]
+	if found == false {
+		return
+	}
+
+	if rule.count != 0 && ite.UnitCount() != rule.count {
+		report.addTagFinding(
+			DiagnosticError, DiagnosticTagCountNotValid, ite,
+			"count (%d) is not valid; expected (%d)",
+			ite.UnitCount(), rule.count)
+	}
+
+	if len(rule.values) > 0 {
+		validateTagValuesConformance(ite, rule, report)
+	}
+
+	if len(rule.strings) > 0 || rule.format != conformanceFormatNone {
+		validateTagStringConformance(ite, rule, report)
+	}
+}
+
+// validateTagValuesConformance checks the units of an integer or undefined
+// value against the allowed values.
+func validateTagValuesConformance(ite *IfdTagEntry, rule conformanceRule, report *ConformanceReport) {
+	var integers []int64
+
+	if ite.TagType() == exifcommon.TypeUndefined {
+		rawBytes, err := ite.getRawUndefinedBytes()
+		log.PanicIf(err)
+
+		integers = make([]int64, len(rawBytes))
+		for i, b := range rawBytes {
+			integers[i] = int64(b)
+		}
+	} else {
+		value, err := ite.Value()
+		log.PanicIf(err)
+
+		var ok bool
+		if integers, ok = integerValueUnits(value); ok == false {
+			return
+		}
+	}
+
+	for _, n := range integers {
+		allowed := false
+		for _, value := range rule.values {
+			if n == value {
+				allowed = true
+				break
+			}
+		}
+
+		if allowed == false {
+			report.addTagFinding(
+				DiagnosticWarning, DiagnosticValueOutOfRange, ite,
+				"value (%d) is not defined by the standard",
+				n)
+
+			// Once is enough.
+			return
+		}
+	}
+}
+
+// validateTagStringConformance checks the allowed values and format of an
+// ASCII or UTF-8 (or, for versions, undefined) value.
+func validateTagStringConformance(ite *IfdTagEntry, rule conformanceRule, report *ConformanceReport) {
+	var s string
+
+	if ite.TagType() == exifcommon.TypeUndefined {
+		rawBytes, err := ite.getRawUndefinedBytes()
+		log.PanicIf(err)
+
+		s = string(rawBytes)
+	} else if ite.TagType() == exifcommon.TypeAscii || ite.TagType() == exifcommon.TypeUtf8 {
+		value, err := ite.Value()
+		log.PanicIf(err)
+
+		s = value.(string)
+	} else {
+		return
+	}
+
+	if len(rule.strings) > 0 {
+		allowed := false
+		for _, value := range rule.strings {
+			if s == value {
+				allowed = true
+				break
+			}
+		}
+
+		if allowed == false {
+			report.addTagFinding(
+				DiagnosticWarning, DiagnosticValueOutOfRange, ite,
+				"value [%s] is not defined by the standard; expected one of %v",
+				s, rule.strings)
+		}
+	}
+
+	if rule.format != conformanceFormatNone && isConformantFormat(s, rule.format) == false {
+		report.addTagFinding(
+			DiagnosticError, DiagnosticValueFormatNotValid, ite,
+			"value [%s] is not in the format that the standard requires",
+			s)
+	}
+}
+
+// isConformantFormat returns true if the string has the given format. The
+// standard allows dates and times that are not known to be given as blanks
+// (with or without the separators).
+func isConformantFormat(s string, format conformanceFormat) bool {
+	var layout string
+
+	switch format {
+	case conformanceFormatDateTime:
+		layout = conformanceDateTimeLayout
+	case conformanceFormatDate:
+		layout = conformanceDateLayout
+	case conformanceFormatOffsetTime:
+		layout = conformanceOffsetTimeLayout
+	case conformanceFormatVersion:
+		if len(s) != 4 {
+			return false
+		}
+
+		for _, c := range s {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+
+		return true
+	default:
+		log.Panicf("format (%d) not valid", format)
+	}
+
+	if len(s) != len(layout) {
+		return false
+	}
+
+	isBlank := true
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' && (s[i] != ':' || layout[i] != ':') {
+			isBlank = false
+			break
+		}
+	}
+
+	if isBlank == true {
+		return true
+	}
+
+	_, err := time.Parse(layout, s)
+	return err == nil
+}
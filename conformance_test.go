@@ -0,0 +1,178 @@
+package exif
+
+import (
+	"reflect"
+	"testing"
+
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func TestValidateConformance_RealData(t *testing.T) {
+	index := getRepairTestIndex(getTestExifData())
+
+	report, err := ValidateConformance(index)
+	log.PanicIf(err)
+
+	if len(report.Findings) != 0 {
+		t.Fatalf("Real data should conform:\n%s", report)
+	} else if report.IsConformant() != true {
+		t.Fatalf("Expected conformant.")
+	}
+
+	data, err := report.Json()
+	log.PanicIf(err)
+
+	if string(data) != "{\n  \"findings\": []\n}" {
+		t.Fatalf("JSON not correct: [%s]", string(data))
+	}
+}
+
+func TestValidateConformance_Findings(t *testing.T) {
+	// The extra data starts at (8 + 2 + 12 * 5 + 4) = (74). Put DateTime at an
+	// odd offset.
+	extra := append([]byte{0}, []byte("2020:13:01 00:00:00\000")...)
+
+	entries := []optionsTestEntry{
+		// Make: "Cano" without a NUL.
+		{0x010f, exifcommon.TypeAscii, 4, 0x6f6e6143},
+
+		// Orientation only goes up to (8).
+		{0x0112, exifcommon.TypeShort, 1, 9},
+
+		// XResolution is a RATIONAL.
+		{0x011a, exifcommon.TypeShort, 1, 72},
+
+		// ImageWidth is out of order and has one unit.
+		{0x0100, exifcommon.TypeShort, 2, 0x00100010},
+
+		// DateTime has no thirteenth month.
+		{0x0132, exifcommon.TypeAscii, 20, 75},
+	}
+
+	exifData := getOptionsTestExifData(entries, extra)
+
+	index := getRepairTestIndex(exifData)
+
+	report, err := ValidateConformance(index)
+	log.PanicIf(err)
+
+	type finding struct {
+		severity DiagnosticSeverity
+		code     DiagnosticCode
+		tagId    uint16
+	}
+
+	actual := make([]finding, len(report.Findings))
+	for i, d := range report.Findings {
+		actual[i] = finding{d.Severity, d.Code, d.TagId}
+	}
+
+	expected := []finding{
+		{DiagnosticError, DiagnosticAsciiNotTerminated, 0x010f},
+		{DiagnosticWarning, DiagnosticValueOutOfRange, 0x0112},
+		{DiagnosticError, DiagnosticTagTypeMismatch, 0x011a},
+		{DiagnosticError, DiagnosticTagOrderNotValid, 0x0100},
+		{DiagnosticError, DiagnosticTagCountNotValid, 0x0100},
+		{DiagnosticError, DiagnosticValueMisaligned, 0x0132},
+		{DiagnosticError, DiagnosticValueFormatNotValid, 0x0132},
+		{DiagnosticError, DiagnosticTagRequired, 0x9000},
+		{DiagnosticError, DiagnosticTagRequired, 0x9101},
+		{DiagnosticError, DiagnosticTagRequired, 0xa000},
+		{DiagnosticError, DiagnosticTagRequired, 0xa001},
+		{DiagnosticError, DiagnosticTagRequired, 0xa002},
+		{DiagnosticError, DiagnosticTagRequired, 0xa003},
+	}
+
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Fatalf("Findings not correct:\n%s", report)
+	} else if report.IsConformant() != false {
+		t.Fatalf("Expected not conformant.")
+	}
+
+	d := report.Findings[3]
+	if d.FqIfdPath != "IFD" || d.Offset != 8+2+12*3 {
+		t.Fatalf("Location not correct: %s", d)
+	} else if report.Findings[7].FqIfdPath != "IFD/Exif" {
+		t.Fatalf("Required-tag location not correct: %s", report.Findings[7])
+	}
+
+	// The JSON is machine-readable.
+
+	data, err := report.Json()
+	log.PanicIf(err)
+
+	decoded := ConformanceReport{}
+
+	err = json.Unmarshal(data, &decoded)
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(decoded.Findings, report.Findings) == false {
+		t.Fatalf("JSON did not round-trip:\n%s", string(data))
+	}
+}
+
+func TestIsConformantFormat(t *testing.T) {
+	cases := []struct {
+		s        string
+		format   conformanceFormat
+		expected bool
+	}{
+		{"2020:02:29 23:59:59", conformanceFormatDateTime, true},
+		{"2019:02:29 23:59:59", conformanceFormatDateTime, false},
+		{"2020-02-29 23:59:59", conformanceFormatDateTime, false},
+		{"2020:02:29 23:59", conformanceFormatDateTime, false},
+		{"    :  :     :  :  ", conformanceFormatDateTime, true},
+		{"                   ", conformanceFormatDateTime, true},
+		{"2020:02:29", conformanceFormatDate, true},
+		{"2020:00:29", conformanceFormatDate, false},
+		{"+09:00", conformanceFormatOffsetTime, true},
+		{"-05:30", conformanceFormatOffsetTime, true},
+		{"Z", conformanceFormatOffsetTime, false},
+		{"0232", conformanceFormatVersion, true},
+		{"02a2", conformanceFormatVersion, false},
+		{"023", conformanceFormatVersion, false},
+	}
+
+	for _, c := range cases {
+		if isConformantFormat(c.s, c.format) != c.expected {
+			t.Fatalf("Format check of [%s] not correct: expected (%v)", c.s, c.expected)
+		}
+	}
+}
+
+func TestValidateConformance_Utf8(t *testing.T) {
+	entries := []optionsTestEntry{
+		// Make: "abcd" without a NUL.
+		{0x010f, exifcommon.TypeUtf8, 4, 0x64636261},
+
+		// Artist: an invalid sequence.
+		{0x013b, exifcommon.TypeUtf8, 4, 0x006261ff},
+
+		// Copyright: "é".
+		{0x8298, exifcommon.TypeUtf8, 3, 0x00a9c3},
+	}
+
+	exifData := getOptionsTestExifData(entries, nil)
+
+	index := getRepairTestIndex(exifData)
+
+	report, err := ValidateConformance(index)
+	log.PanicIf(err)
+
+	tagIds := make([]uint16, 0)
+	for _, d := range report.Findings {
+		if d.Code == DiagnosticUtf8NotValid {
+			tagIds = append(tagIds, d.TagId)
+		} else if d.Code != DiagnosticTagRequired {
+			t.Fatalf("Finding not expected: %s", d)
+		}
+	}
+
+	if reflect.DeepEqual(tagIds, []uint16{0x010f, 0x013b}) == false {
+		t.Fatalf("Findings not correct:\n%s", report)
+	}
+}
@@ -0,0 +1,381 @@
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+// DiffChangeType describes the kind of difference that a `DiffEntry`
+// represents.
+type DiffChangeType string
+
+const (
+	// DiffTagAdded indicates a tag that is only present in the second index.
+	DiffTagAdded DiffChangeType = "tag-added"
+
+	// DiffTagRemoved indicates a tag that is only present in the first index.
+	DiffTagRemoved DiffChangeType = "tag-removed"
+
+	// DiffValueChanged indicates a tag whose decoded value differs.
+	DiffValueChanged DiffChangeType = "value-changed"
+
+	// DiffTypeChanged indicates a tag whose type differs.
+	DiffTypeChanged DiffChangeType = "type-changed"
+
+	// DiffUnitCountChanged indicates a tag whose unit-count differs.
+	DiffUnitCountChanged DiffChangeType = "unit-count-changed"
+
+	// DiffIfdAdded indicates an IFD that is only present in the second index.
+	DiffIfdAdded DiffChangeType = "ifd-added"
+
+	// DiffIfdRemoved indicates an IFD that is only present in the first index.
+	DiffIfdRemoved DiffChangeType = "ifd-removed"
+)
+
+// DiffEntry describes one difference between two IFD indices. Tag-level
+// entries are keyed by the fully-qualified IFD-path, the tag-ID and, for the
+// rare case of a tag that is repeated within the same IFD, the occurrence.
+type DiffEntry struct {
+	Change    DiffChangeType `json:"change"`
+	FqIfdPath string         `json:"ifd_path"`
+
+	TagId      uint16 `json:"tag_id,omitempty"`
+	TagName    string `json:"tag_name,omitempty"`
+	Occurrence int    `json:"occurrence,omitempty"`
+
+	OldType string `json:"old_type,omitempty"`
+	NewType string `json:"new_type,omitempty"`
+
+	OldUnitCount uint32 `json:"old_unit_count,omitempty"`
+	NewUnitCount uint32 `json:"new_unit_count,omitempty"`
+
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+// String returns a one-line description of the difference.
+func (de DiffEntry) String() string {
+	switch de.Change {
+	case DiffIfdAdded, DiffIfdRemoved:
+		return fmt.Sprintf("%s [%s]", de.Change, de.FqIfdPath)
+	case DiffTagAdded:
+		return fmt.Sprintf("%s [%s] (0x%04x) [%s] %s(%d): [%s]", de.Change, de.FqIfdPath, de.TagId, de.TagName, de.NewType, de.NewUnitCount, de.NewValue)
+	case DiffTagRemoved:
+		return fmt.Sprintf("%s [%s] (0x%04x) [%s] %s(%d): [%s]", de.Change, de.FqIfdPath, de.TagId, de.TagName, de.OldType, de.OldUnitCount, de.OldValue)
+	case DiffTypeChanged:
+		return fmt.Sprintf("%s [%s] (0x%04x) [%s]: [%s] -> [%s]", de.Change, de.FqIfdPath, de.TagId, de.TagName, de.OldType, de.NewType)
+	case DiffUnitCountChanged:
+		return fmt.Sprintf("%s [%s] (0x%04x) [%s]: (%d) -> (%d)", de.Change, de.FqIfdPath, de.TagId, de.TagName, de.OldUnitCount, de.NewUnitCount)
+	default:
+		return fmt.Sprintf("%s [%s] (0x%04x) [%s]: [%s] -> [%s]", de.Change, de.FqIfdPath, de.TagId, de.TagName, de.OldValue, de.NewValue)
+	}
+}
+
+// IfdIndexDiff is the structured set of differences between two IFD indices.
+type IfdIndexDiff struct {
+	Entries []DiffEntry `json:"entries"`
+}
+
+// HasDifferences returns true if any differences were found.
+func (diff *IfdIndexDiff) HasDifferences() bool {
+	return len(diff.Entries) > 0
+}
+
+// String renders the differences as text, one per line.
+func (diff *IfdIndexDiff) String() string {
+	b := new(bytes.Buffer)
+
+	for _, de := range diff.Entries {
+		fmt.Fprintln(b, de.String())
+	}
+
+	return b.String()
+}
+
+// Json renders the differences as JSON.
+func (diff *IfdIndexDiff) Json() (data []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	entries := diff.Entries
+	if entries == nil {
+		entries = make([]DiffEntry, 0)
+	}
+
+	data, err = json.MarshalIndent(IfdIndexDiff{Entries: entries}, "", "  ")
+	log.PanicIf(err)
+
+	return data, nil
+}
+
+// diffTagKey identifies a tag in a particular IFD.
+type diffTagKey struct {
+	tagId      uint16
+	occurrence int
+}
+
+// DiffIfdIndexes compares two IFD indices and returns the differences between
+// them. Values are compared in their decoded form, so the same tags written at
+// different offsets (or by a different encoder) will compare as equal. Undefined
+// tags that we can not decode are compared by their raw bytes. The tags that
+// point to child IFDs are not compared by value since their values are
+// offsets; changes in the child IFDs themselves are reported instead.
+func DiffIfdIndexes(a, b IfdIndex) (diff *IfdIndexDiff, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	diff = new(IfdIndexDiff)
+
+	for _, ifdA := range a.Ifds {
+		fqIfdPath := ifdA.ifdIdentity.String()
+
+		ifdB, found := b.Lookup[fqIfdPath]
+		if found == false {
+			diff.Entries = append(diff.Entries, DiffEntry{
+				Change:    DiffIfdRemoved,
+				FqIfdPath: fqIfdPath,
+			})
+
+			continue
+		}
+
+		entries, err := diffIfds(ifdA, ifdB)
+		log.PanicIf(err)
+
+		diff.Entries = append(diff.Entries, entries...)
+	}
+
+	for _, ifdB := range b.Ifds {
+		fqIfdPath := ifdB.ifdIdentity.String()
+
+		if _, found := a.Lookup[fqIfdPath]; found == true {
+			continue
+		}
+
+		diff.Entries = append(diff.Entries, DiffEntry{
+			Change:    DiffIfdAdded,
+			FqIfdPath: fqIfdPath,
+		})
+	}
+
+	sort.SliceStable(diff.Entries, func(i, j int) bool {
+		x := diff.Entries[i]
+		y := diff.Entries[j]
+
+		if x.FqIfdPath != y.FqIfdPath {
+			return x.FqIfdPath < y.FqIfdPath
+		} else if x.TagId != y.TagId {
+			return x.TagId < y.TagId
+		}
+
+		return x.Occurrence < y.Occurrence
+	})
+
+	return diff, nil
+}
+
+// diffIfds compares the tags of two IFDs with the same fully-qualified path.
+func diffIfds(ifdA, ifdB *Ifd) (entries []DiffEntry, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	fqIfdPath := ifdA.ifdIdentity.String()
+
+	tagsA := diffTagsByKey(ifdA)
+	tagsB := diffTagsByKey(ifdB)
+
+	entries = make([]DiffEntry, 0)
+
+	for _, iteA := range ifdA.Entries {
+		key := diffTagKeyOf(tagsA, iteA)
+
+		iteB, found := tagsB[key]
+		if found == false {
+			de := DiffEntry{
+				Change:       DiffTagRemoved,
+				FqIfdPath:    fqIfdPath,
+				TagId:        iteA.TagId(),
+				TagName:      iteA.TagName(),
+				Occurrence:   key.occurrence,
+				OldType:      iteA.TagType().String(),
+				OldUnitCount: iteA.UnitCount(),
+			}
+
+			if iteA.ChildIfdPath() == "" {
+				de.OldValue = diffFormatValue(iteA)
+			}
+
+			entries = append(entries, de)
+			continue
+		}
+
+		de := DiffEntry{
+			FqIfdPath:    fqIfdPath,
+			TagId:        iteA.TagId(),
+			TagName:      iteA.TagName(),
+			Occurrence:   key.occurrence,
+			OldType:      iteA.TagType().String(),
+			NewType:      iteB.TagType().String(),
+			OldUnitCount: iteA.UnitCount(),
+			NewUnitCount: iteB.UnitCount(),
+		}
+
+		if de.TagName == "" {
+			de.TagName = iteB.TagName()
+		}
+
+		if iteA.TagType() != iteB.TagType() {
+			de.Change = DiffTypeChanged
+			entries = append(entries, de)
+
+			continue
+		}
+
+		// Child-IFD pointers are offsets. Their contents are compared as
+		// IFDs.
+		if iteA.ChildIfdPath() != "" || iteB.ChildIfdPath() != "" {
+			continue
+		}
+
+		// The unit-count of a string is just a consequence of its value.
+		isString := iteA.TagType() == exifcommon.TypeAscii || iteA.TagType() == exifcommon.TypeAsciiNoNul || iteA.TagType() == exifcommon.TypeUtf8
+
+		if isString == false && iteA.UnitCount() != iteB.UnitCount() {
+			de.Change = DiffUnitCountChanged
+			de.OldValue = diffFormatValue(iteA)
+			de.NewValue = diffFormatValue(iteB)
+
+			entries = append(entries, de)
+			continue
+		}
+
+		valueA, err := diffComparableValue(iteA)
+		log.PanicIf(err)
+
+		valueB, err := diffComparableValue(iteB)
+		log.PanicIf(err)
+
+		if reflect.DeepEqual(valueA, valueB) == false {
+			de.Change = DiffValueChanged
+			de.OldValue = diffFormatValue(iteA)
+			de.NewValue = diffFormatValue(iteB)
+
+			entries = append(entries, de)
+		}
+	}
+
+	for _, iteB := range ifdB.Entries {
+		key := diffTagKeyOf(tagsB, iteB)
+
+		if _, found := tagsA[key]; found == true {
+			continue
+		}
+
+		de := DiffEntry{
+			Change:       DiffTagAdded,
+			FqIfdPath:    fqIfdPath,
+			TagId:        iteB.TagId(),
+			TagName:      iteB.TagName(),
+			Occurrence:   key.occurrence,
+			NewType:      iteB.TagType().String(),
+			NewUnitCount: iteB.UnitCount(),
+		}
+
+		if iteB.ChildIfdPath() == "" {
+			de.NewValue = diffFormatValue(iteB)
+		}
+
+		entries = append(entries, de)
+	}
+
+	return entries, nil
+}
+
+// diffTagsByKey indexes the tags of the IFD by ID and occurrence.
+func diffTagsByKey(ifd *Ifd) map[diffTagKey]*IfdTagEntry {
+	tags := make(map[diffTagKey]*IfdTagEntry)
+	counts := make(map[uint16]int)
+
+	for _, ite := range ifd.Entries {
+		tagId := ite.TagId()
+
+		key := diffTagKey{
+			tagId:      tagId,
+			occurrence: counts[tagId],
+		}
+
+		tags[key] = ite
+		counts[tagId]++
+	}
+
+	return tags
+}
+
+// diffTagKeyOf returns the key that the given tag was indexed under.
+func diffTagKeyOf(tags map[diffTagKey]*IfdTagEntry, ite *IfdTagEntry) diffTagKey {
+	for occurrence := 0; ; occurrence++ {
+		key := diffTagKey{
+			tagId:      ite.TagId(),
+			occurrence: occurrence,
+		}
+
+		if tags[key] == ite {
+			return key
+		}
+	}
+}
+
+// diffComparableValue returns the decoded value of the tag or, if it is an
+// undefined-type tag that we can not decode, its raw bytes.
+func diffComparableValue(ite *IfdTagEntry) (value interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err = ite.Value()
+	if err == nil {
+		return value, nil
+	} else if err != exifcommon.ErrUnhandledUndefinedTypedTag && err != exifundefined.ErrUnparseableValue {
+		log.Panic(err)
+	}
+
+	rawBytes, err := ite.getRawUndefinedBytes()
+	log.PanicIf(err)
+
+	return rawBytes, nil
+}
+
+// diffFormatValue returns the value formatted for the report.
+func diffFormatValue(ite *IfdTagEntry) string {
+	value, err := diffComparableValue(ite)
+	if err != nil {
+		return fmt.Sprintf("!ERROR: %s", err.Error())
+	}
+
+	phrase, err := exifcommon.FormatFromType(value, false)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return phrase
+}
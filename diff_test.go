@@ -0,0 +1,148 @@
+package exif
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func TestDiffIfdIndexes_Identical(t *testing.T) {
+	exifData := getTestExifData()
+
+	indexA := getTestIndex(exifData)
+	indexB := getTestIndex(exifData)
+
+	diff, err := DiffIfdIndexes(indexA, indexB)
+	log.PanicIf(err)
+
+	if diff.HasDifferences() == true {
+		t.Fatalf("Expected no differences:\n%s", diff)
+	}
+}
+
+func TestDiffIfdIndexes_RoundTrip(t *testing.T) {
+	exifData := getTestExifData()
+
+	indexA := getTestIndex(exifData)
+
+	rootIb := NewIfdBuilderFromExistingChain(indexA.RootIfd)
+
+	err := rootIb.SetStandardWithName("Model", "other model")
+	log.PanicIf(err)
+
+	_, err = rootIb.DeleteAll(0x013b)
+	log.PanicIf(err)
+
+	err = rootIb.AddStandardWithName("DocumentName", "document")
+	log.PanicIf(err)
+
+	// Drop the GPS IFD entirely.
+	_, err = rootIb.DeleteAll(exifcommon.IfdGpsInfoStandardIfdIdentity.TagId())
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	updatedExifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	indexB := getTestIndex(updatedExifData)
+
+	diff, err := DiffIfdIndexes(indexA, indexB)
+	log.PanicIf(err)
+
+	actual := make([]string, len(diff.Entries))
+	for i, de := range diff.Entries {
+		actual[i] = fmt.Sprintf("%s %s 0x%04x", de.Change, de.FqIfdPath, de.TagId)
+	}
+
+	expected := []string{
+		"tag-added IFD 0x010d",
+		"value-changed IFD 0x0110",
+		"tag-removed IFD 0x013b",
+		"tag-removed IFD 0x8825",
+		"ifd-removed IFD/GPSInfo 0x0000",
+	}
+
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Diff not correct:\nACTUAL:\n%s\nEXPECTED:\n%s", strings.Join(actual, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if diff.Entries[1].OldValue != "Canon EOS 5D Mark III" || diff.Entries[1].NewValue != "other model" {
+		t.Fatalf("New value not correct: [%s]", diff.Entries[1].NewValue)
+	}
+}
+
+func TestDiffIfdIndexes_TypeAndUnitCount(t *testing.T) {
+	im := NewIfdMapping()
+
+	err := LoadStandardIfds(im)
+	log.PanicIf(err)
+
+	ti := NewTagIndex()
+
+	ibA := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	err = ibA.AddStandard(0x0100, []uint32{100})
+	log.PanicIf(err)
+
+	err = ibA.AddStandard(0x0102, []uint16{8, 8, 8})
+	log.PanicIf(err)
+
+	ibB := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	bt := NewBuilderTag(
+		exifcommon.IfdStandardIfdIdentity.UnindexedString(),
+		0x0100,
+		exifcommon.TypeShort,
+		NewIfdBuilderTagValueFromBytes([]byte{0x00, 0x64}),
+		exifcommon.TestDefaultByteOrder)
+
+	err = ibB.Add(bt)
+	log.PanicIf(err)
+
+	err = ibB.AddStandard(0x0102, []uint16{8})
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifDataA, err := ibe.EncodeToExif(ibA)
+	log.PanicIf(err)
+
+	exifDataB, err := ibe.EncodeToExif(ibB)
+	log.PanicIf(err)
+
+	diff, err := DiffIfdIndexes(getTestIndex(exifDataA), getTestIndex(exifDataB))
+	log.PanicIf(err)
+
+	if len(diff.Entries) != 2 {
+		t.Fatalf("Expected two differences:\n%s", diff)
+	}
+
+	de := diff.Entries[0]
+	if de.Change != DiffTypeChanged || de.OldType != "LONG" || de.NewType != "SHORT" {
+		t.Fatalf("Type change not correct: %s", de)
+	}
+
+	de = diff.Entries[1]
+	if de.Change != DiffUnitCountChanged || de.OldUnitCount != 3 || de.NewUnitCount != 1 {
+		t.Fatalf("Unit-count change not correct: %s", de)
+	}
+
+	data, err := diff.Json()
+	log.PanicIf(err)
+
+	recovered := new(IfdIndexDiff)
+
+	err = json.Unmarshal(data, recovered)
+	log.PanicIf(err)
+
+	if len(recovered.Entries) != 2 || recovered.Entries[1].TagName != "BitsPerSample" {
+		t.Fatalf("JSON not correct: %s", string(data))
+	}
+}
@@ -0,0 +1,609 @@
+package exif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+// The DNG tags are stored in the first IFD. The colour tags are stored as flat
+// lists whose shape depends on the number of colour planes of the camera. We
+// have accessors that return them as matrices and vectors.
+
+var (
+	// ErrDngValueNotValid means that a DNG tag does not have the count or
+	// type that the DNG specification requires.
+	ErrDngValueNotValid = errors.New("DNG value not valid")
+
+	// ErrDngMakerNoteNotFound means that the DNGPrivateData does not have an
+	// Adobe maker-note block.
+	ErrDngMakerNoteNotFound = errors.New("DNG maker-note not found")
+)
+
+// DngMatrix is a matrix from one of the DNG colour tags. The values are
+// stored by row.
+type DngMatrix struct {
+	Rows    int
+	Columns int
+	Values  []float64
+}
+
+// newDngMatrix returns a matrix with the given shape. The count of the values
+// must match.
+func newDngMatrix(rows, columns int, values []float64) (m DngMatrix, err error) {
+	if len(values) != rows*columns {
+		return m, ErrDngValueNotValid
+	}
+
+	m = DngMatrix{
+		Rows:    rows,
+		Columns: columns,
+		Values:  values,
+	}
+
+	return m, nil
+}
+
+// At returns the value at the given row and column.
+func (m DngMatrix) At(row, column int) float64 {
+	return m.Values[row*m.Columns+column]
+}
+
+// Row returns the values of the given row.
+func (m DngMatrix) Row(row int) []float64 {
+	return m.Values[row*m.Columns : (row+1)*m.Columns]
+}
+
+// String returns a descriptive string.
+func (m DngMatrix) String() string {
+	rows := make([]string, m.Rows)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("%v", m.Row(i))
+	}
+
+	return fmt.Sprintf("DngMatrix<ROWS=(%d) COLUMNS=(%d) VALUES=[%s]>", m.Rows, m.Columns, strings.Join(rows, " "))
+}
+
+// LightSource is the kind of light from the EXIF LightSource tag. The DNG
+// CalibrationIlluminant tags use the same values.
+type LightSource uint16
+
+const (
+	LightSourceUnknown              LightSource = 0
+	LightSourceDaylight             LightSource = 1
+	LightSourceFluorescent          LightSource = 2
+	LightSourceTungsten             LightSource = 3
+	LightSourceFlash                LightSource = 4
+	LightSourceFineWeather          LightSource = 9
+	LightSourceCloudyWeather        LightSource = 10
+	LightSourceShade                LightSource = 11
+	LightSourceDaylightFluorescent  LightSource = 12
+	LightSourceDayWhiteFluorescent  LightSource = 13
+	LightSourceCoolWhiteFluorescent LightSource = 14
+	LightSourceWhiteFluorescent     LightSource = 15
+	LightSourceWarmWhiteFluorescent LightSource = 16
+	LightSourceStandardLightA       LightSource = 17
+	LightSourceStandardLightB       LightSource = 18
+	LightSourceStandardLightC       LightSource = 19
+	LightSourceD55                  LightSource = 20
+	LightSourceD65                  LightSource = 21
+	LightSourceD75                  LightSource = 22
+	LightSourceD50                  LightSource = 23
+	LightSourceIsoStudioTungsten    LightSource = 24
+	LightSourceOther                LightSource = 255
+)
+
+var (
+	lightSourceNames = map[LightSource]string{
+		LightSourceUnknown:              "Unknown",
+		LightSourceDaylight:             "Daylight",
+		LightSourceFluorescent:          "Fluorescent",
+		LightSourceTungsten:             "Tungsten",
+		LightSourceFlash:                "Flash",
+		LightSourceFineWeather:          "Fine weather",
+		LightSourceCloudyWeather:        "Cloudy weather",
+		LightSourceShade:                "Shade",
+		LightSourceDaylightFluorescent:  "Daylight fluorescent",
+		LightSourceDayWhiteFluorescent:  "Day white fluorescent",
+		LightSourceCoolWhiteFluorescent: "Cool white fluorescent",
+		LightSourceWhiteFluorescent:     "White fluorescent",
+		LightSourceWarmWhiteFluorescent: "Warm white fluorescent",
+		LightSourceStandardLightA:       "Standard light A",
+		LightSourceStandardLightB:       "Standard light B",
+		LightSourceStandardLightC:       "Standard light C",
+		LightSourceD55:                  "D55",
+		LightSourceD65:                  "D65",
+		LightSourceD75:                  "D75",
+		LightSourceD50:                  "D50",
+		LightSourceIsoStudioTungsten:    "ISO studio tungsten",
+		LightSourceOther:                "Other",
+	}
+)
+
+// String returns the name of the light source. Values that are not defined
+// are returned as a number.
+func (ls LightSource) String() string {
+	if name, found := lightSourceNames[ls]; found == true {
+		return name
+	}
+
+	return fmt.Sprintf("LightSource(%d)", uint16(ls))
+}
+
+// DngPrivateData is the value of the DNGPrivateData tag: a NUL-terminated
+// name of the application that wrote it followed by data in a format that
+// only that application knows.
+type DngPrivateData struct {
+	Creator string
+	Data    []byte
+}
+
+// NewDngPrivateData splits the value of the DNGPrivateData tag.
+func NewDngPrivateData(raw []byte) DngPrivateData {
+	i := bytes.IndexByte(raw, 0)
+	if i == -1 {
+		return DngPrivateData{
+			Data: raw,
+		}
+	}
+
+	return DngPrivateData{
+		Creator: string(raw[:i]),
+		Data:    raw[i+1:],
+	}
+}
+
+// DngMakerNote is the original maker-note that Adobe software keeps when it
+// converts a raw file to DNG. Any offsets in the maker-note are relative to
+// `OriginalOffset`.
+type DngMakerNote struct {
+	ByteOrder      binary.ByteOrder
+	OriginalOffset uint32
+	Data           []byte
+}
+
+// MakerNote returns the maker-note block of data written by Adobe software.
+// Returns `ErrDngMakerNoteNotFound` if there is none.
+func (dpd DngPrivateData) MakerNote() (mn DngMakerNote, err error) {
+	if dpd.Creator != "Adobe" {
+		return mn, ErrDngMakerNoteNotFound
+	}
+
+	// The data is a list of blocks, each with a four-character name and a
+	// big-endian size.
+
+	data := dpd.Data
+	for len(data) >= 8 {
+		name := string(data[:4])
+		size := binary.BigEndian.Uint32(data[4:8])
+		data = data[8:]
+
+		if uint64(size) > uint64(len(data)) {
+			return mn, ErrDngValueNotValid
+		}
+
+		block := data[:size]
+		data = data[size:]
+
+		if name != "MakN" {
+			continue
+		}
+
+		if len(block) < 6 {
+			return mn, ErrDngValueNotValid
+		}
+
+		byteOrderSignature := string(block[:2])
+		if byteOrderSignature == "II" {
+			mn.ByteOrder = binary.LittleEndian
+		} else if byteOrderSignature == "MM" {
+			mn.ByteOrder = binary.BigEndian
+		} else {
+			return mn, ErrDngValueNotValid
+		}
+
+		mn.OriginalOffset = binary.BigEndian.Uint32(block[2:6])
+		mn.Data = block[6:]
+
+		return mn, nil
+	}
+
+	return mn, ErrDngMakerNoteNotFound
+}
+
+// findDngTagValue returns the value of the first tag with the given ID. The
+// IFD must be the root IFD.
+func (ifd *Ifd) findDngTagValue(tagId uint16) (value interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if ifd.ifdIdentity.UnindexedString() != exifcommon.IfdStandardIfdIdentity.UnindexedString() {
+		log.Panicf("tag (0x%04x) can only be read on the root IFD: [%s] != [%s]", tagId, ifd.ifdIdentity.UnindexedString(), exifcommon.IfdStandardIfdIdentity.UnindexedString())
+	}
+
+	tags, found := ifd.EntriesByTagId[tagId]
+	if found == false {
+		return nil, ErrTagNotFound
+	}
+
+	value, err = tags[0].Value()
+	log.PanicIf(err)
+
+	return value, nil
+}
+
+// dngFloats converts the integer or rational values of a DNG tag to floats.
+func dngFloats(value interface{}) (floats []float64, err error) {
+	switch t := value.(type) {
+	case []uint16:
+		floats = make([]float64, len(t))
+		for i, v := range t {
+			floats[i] = float64(v)
+		}
+	case []uint32:
+		floats = make([]float64, len(t))
+		for i, v := range t {
+			floats[i] = float64(v)
+		}
+	case []exifcommon.Rational:
+		floats = make([]float64, len(t))
+		for i, r := range t {
+			if r.Denominator == 0 {
+				return nil, ErrDngValueNotValid
+			}
+
+			floats[i] = float64(r.Numerator) / float64(r.Denominator)
+		}
+	case []exifcommon.SignedRational:
+		floats = make([]float64, len(t))
+		for i, r := range t {
+			if r.Denominator == 0 {
+				return nil, ErrDngValueNotValid
+			}
+
+			floats[i] = float64(r.Numerator) / float64(r.Denominator)
+		}
+	default:
+		return nil, ErrDngValueNotValid
+	}
+
+	return floats, nil
+}
+
+// dngTagIdForIndex returns the ID of the first or second tag of a pair (e.g.
+// ColorMatrix1 and ColorMatrix2).
+func dngTagIdForIndex(index int, first, second uint16) uint16 {
+	if index == 1 {
+		return first
+	} else if index == 2 {
+		return second
+	}
+
+	log.Panicf("DNG tag index must be 1 or 2: (%d)", index)
+	return 0
+}
+
+// DngVersion returns the DNG version (e.g. 1.4.0.0). The IFD must be the root
+// IFD. Returns `ErrTagNotFound` if there is none (it's not a DNG).
+func (ifd *Ifd) DngVersion() (version [4]byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findDngTagValue(TagDNGVersion)
+	if err == ErrTagNotFound {
+		return version, err
+	}
+
+	log.PanicIf(err)
+
+	raw, ok := value.([]byte)
+	if ok == false || len(raw) != 4 {
+		log.Panic(ErrDngValueNotValid)
+	}
+
+	copy(version[:], raw)
+
+	return version, nil
+}
+
+// ColorPlanes returns the number of colour planes of the camera. This is the
+// count of the CFAPlaneColor tag, or the count of the ColorMatrix1 tag
+// divided by three if there is no CFAPlaneColor. It's three if there is
+// neither. The IFD must be the root IFD.
+func (ifd *Ifd) ColorPlanes() (planes int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findDngTagValue(TagCFAPlaneColor)
+	if err == nil {
+		raw, ok := value.([]byte)
+		if ok == false || len(raw) == 0 {
+			log.Panic(ErrDngValueNotValid)
+		}
+
+		return len(raw), nil
+	} else if err != ErrTagNotFound {
+		log.Panic(err)
+	}
+
+	tags, found := ifd.EntriesByTagId[TagColorMatrix1]
+	if found == true {
+		count := int(tags[0].UnitCount())
+		if count == 0 || count%3 != 0 {
+			log.Panic(ErrDngValueNotValid)
+		}
+
+		return count / 3, nil
+	}
+
+	return 3, nil
+}
+
+// dngMatrix returns the value of the given tag as a matrix of the given shape.
+// A dimension of zero is the number of colour planes.
+func (ifd *Ifd) dngMatrix(tagId uint16, rows, columns int) (m DngMatrix, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findDngTagValue(tagId)
+	if err == ErrTagNotFound {
+		return m, err
+	}
+
+	log.PanicIf(err)
+
+	planes, err := ifd.ColorPlanes()
+	log.PanicIf(err)
+
+	if rows == 0 {
+		rows = planes
+	}
+
+	if columns == 0 {
+		columns = planes
+	}
+
+	values, err := dngFloats(value)
+	log.PanicIf(err)
+
+	m, err = newDngMatrix(rows, columns, values)
+	log.PanicIf(err)
+
+	return m, nil
+}
+
+// dngVector returns the value of the given tag as a vector with one value for
+// each colour plane.
+func (ifd *Ifd) dngVector(tagId uint16) (vector []float64, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	m, err := ifd.dngMatrix(tagId, 0, 1)
+	if err == ErrTagNotFound {
+		return nil, err
+	}
+
+	log.PanicIf(err)
+
+	return m.Values, nil
+}
+
+// ColorMatrix returns ColorMatrix1 or ColorMatrix2 (`index` is 1 or 2). This
+// converts XYZ values to reference camera-native values. It has one row for
+// each colour plane and three columns. The IFD must be the root IFD. Returns
+// `ErrTagNotFound` if there is none.
+func (ifd *Ifd) ColorMatrix(index int) (m DngMatrix, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagId := dngTagIdForIndex(index, TagColorMatrix1, TagColorMatrix2)
+
+	m, err = ifd.dngMatrix(tagId, 0, 3)
+	if err == ErrTagNotFound {
+		return m, err
+	}
+
+	log.PanicIf(err)
+
+	return m, nil
+}
+
+// CameraCalibration returns CameraCalibration1 or CameraCalibration2 (`index`
+// is 1 or 2). This converts reference camera-native values to individual
+// camera-native values. It's square, with one row and one column for each
+// colour plane. The IFD must be the root IFD. Returns `ErrTagNotFound` if there
+// is none.
+func (ifd *Ifd) CameraCalibration(index int) (m DngMatrix, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagId := dngTagIdForIndex(index, TagCameraCalibration1, TagCameraCalibration2)
+
+	m, err = ifd.dngMatrix(tagId, 0, 0)
+	if err == ErrTagNotFound {
+		return m, err
+	}
+
+	log.PanicIf(err)
+
+	return m, nil
+}
+
+// ReductionMatrix returns ReductionMatrix1 or ReductionMatrix2 (`index` is 1
+// or 2). This converts camera-native values to XYZ values for cameras with
+// more than three colour planes. It has three rows and one column for each
+// colour plane. The IFD must be the root IFD. Returns `ErrTagNotFound` if there
+// is none.
+func (ifd *Ifd) ReductionMatrix(index int) (m DngMatrix, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagId := dngTagIdForIndex(index, TagReductionMatrix1, TagReductionMatrix2)
+
+	m, err = ifd.dngMatrix(tagId, 3, 0)
+	if err == ErrTagNotFound {
+		return m, err
+	}
+
+	log.PanicIf(err)
+
+	return m, nil
+}
+
+// ForwardMatrix returns ForwardMatrix1 or ForwardMatrix2 (`index` is 1 or 2).
+// This converts white-balanced camera-native values to XYZ D50 values. It has
+// three rows and one column for each colour plane. The IFD must be the root
+// IFD. Returns `ErrTagNotFound` if there is none.
+func (ifd *Ifd) ForwardMatrix(index int) (m DngMatrix, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagId := dngTagIdForIndex(index, TagForwardMatrix1, TagForwardMatrix2)
+
+	m, err = ifd.dngMatrix(tagId, 3, 0)
+	if err == ErrTagNotFound {
+		return m, err
+	}
+
+	log.PanicIf(err)
+
+	return m, nil
+}
+
+// AnalogBalance returns the gain that was applied to each colour plane before
+// digitization. The IFD must be the root IFD. Returns `ErrTagNotFound` if there
+// is none.
+func (ifd *Ifd) AnalogBalance() (vector []float64, err error) {
+	return ifd.dngVector(TagAnalogBalance)
+}
+
+// AsShotNeutral returns the white balance at the time of capture as the
+// camera-native value of a neutral colour. The IFD must be the root IFD.
+// Returns `ErrTagNotFound` if there is none.
+func (ifd *Ifd) AsShotNeutral() (vector []float64, err error) {
+	return ifd.dngVector(TagAsShotNeutral)
+}
+
+// CalibrationIlluminant returns CalibrationIlluminant1 or
+// CalibrationIlluminant2 (`index` is 1 or 2): the light source of the
+// matrices with the same index. The IFD must be the root IFD. Returns
+// `ErrTagNotFound` if there is none.
+func (ifd *Ifd) CalibrationIlluminant(index int) (ls LightSource, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagId := dngTagIdForIndex(index, TagCalibrationIlluminant1, TagCalibrationIlluminant2)
+
+	value, err := ifd.findDngTagValue(tagId)
+	if err == ErrTagNotFound {
+		return ls, err
+	}
+
+	log.PanicIf(err)
+
+	values, ok := value.([]uint16)
+	if ok == false || len(values) != 1 {
+		log.Panic(ErrDngValueNotValid)
+	}
+
+	return LightSource(values[0]), nil
+}
+
+// DngPrivateData returns the DNGPrivateData. The IFD must be the root IFD.
+// Returns `ErrTagNotFound` if there is none.
+func (ifd *Ifd) DngPrivateData() (dpd DngPrivateData, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findDngTagValue(TagDNGPrivateData)
+	if err == ErrTagNotFound {
+		return dpd, err
+	}
+
+	log.PanicIf(err)
+
+	raw, ok := value.([]byte)
+	if ok == false {
+		log.Panic(ErrDngValueNotValid)
+	}
+
+	return NewDngPrivateData(raw), nil
+}
+
+// OpcodeList returns OpcodeList1, OpcodeList2, or OpcodeList3 (`index` is 1,
+// 2, or 3). The IFD must be the root IFD. Returns `ErrTagNotFound` if there is
+// none.
+func (ifd *Ifd) OpcodeList(index int) (ol exifundefined.TagIfdOpcodeList, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	var tagId uint16
+	switch index {
+	case 1:
+		tagId = TagOpcodeList1
+	case 2:
+		tagId = TagOpcodeList2
+	case 3:
+		tagId = TagOpcodeList3
+	default:
+		log.Panicf("opcode-list index must be 1, 2, or 3: (%d)", index)
+	}
+
+	value, err := ifd.findDngTagValue(tagId)
+	if err == ErrTagNotFound {
+		return ol, err
+	}
+
+	log.PanicIf(err)
+
+	ol, ok := value.(exifundefined.TagIfdOpcodeList)
+	if ok == false {
+		log.Panic(ErrDngValueNotValid)
+	}
+
+	return ol, nil
+}
@@ -0,0 +1,266 @@
+package exif
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+func getDngTestSignedRationals(values ...int32) []exifcommon.SignedRational {
+	srs := make([]exifcommon.SignedRational, len(values))
+	for i, v := range values {
+		srs[i] = exifcommon.SignedRational{Numerator: v, Denominator: 10000}
+	}
+
+	return srs
+}
+
+func TestDngMatrix(t *testing.T) {
+	m, err := newDngMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	log.PanicIf(err)
+
+	if m.At(1, 2) != 6 {
+		t.Fatalf("Value not correct: (%f)", m.At(1, 2))
+	} else if reflect.DeepEqual(m.Row(1), []float64{4, 5, 6}) != true {
+		t.Fatalf("Row not correct: %v", m.Row(1))
+	} else if m.String() != "DngMatrix<ROWS=(2) COLUMNS=(3) VALUES=[[1 2 3] [4 5 6]]>" {
+		t.Fatalf("String not correct: [%s]", m.String())
+	}
+
+	_, err = newDngMatrix(2, 2, []float64{1, 2, 3})
+	if err != ErrDngValueNotValid {
+		t.Fatalf("Expected error for wrong count: %v", err)
+	}
+}
+
+func TestLightSource_String(t *testing.T) {
+	if LightSourceD65.String() != "D65" {
+		t.Fatalf("Name not correct: [%s]", LightSourceD65.String())
+	} else if LightSource(100).String() != "LightSource(100)" {
+		t.Fatalf("Name not correct for undefined value: [%s]", LightSource(100).String())
+	}
+}
+
+func TestIfd_Dng_ColorTags(t *testing.T) {
+	ifd := GetTestRootIfd(map[string]interface{}{
+		"DNGVersion":             []byte{1, 4, 0, 0},
+		"ColorMatrix1":           getDngTestSignedRationals(6722, -635, -963, -4287, 12460, 2028, -908, 2162, 5668),
+		"ForwardMatrix1":         getDngTestSignedRationals(7978, 1352, 312, 2880, 9254, -134, 0, -1000, 9000),
+		"CameraCalibration1":     getDngTestSignedRationals(10000, 0, 0, 0, 10000, 0, 0, 0, 10000),
+		"AsShotNeutral":          []exifcommon.Rational{{Numerator: 1, Denominator: 2}, {Numerator: 1, Denominator: 1}, {Numerator: 3, Denominator: 4}},
+		"CalibrationIlluminant1": []uint16{uint16(LightSourceD65)},
+	})
+
+	version, err := ifd.DngVersion()
+	log.PanicIf(err)
+
+	if version != [4]byte{1, 4, 0, 0} {
+		t.Fatalf("Version not correct: %v", version)
+	}
+
+	planes, err := ifd.ColorPlanes()
+	log.PanicIf(err)
+
+	if planes != 3 {
+		t.Fatalf("Color planes not correct: (%d)", planes)
+	}
+
+	cm, err := ifd.ColorMatrix(1)
+	log.PanicIf(err)
+
+	if cm.Rows != 3 || cm.Columns != 3 {
+		t.Fatalf("Color matrix shape not correct: %s", cm)
+	} else if cm.At(1, 1) != 1.246 {
+		t.Fatalf("Color matrix value not correct: (%f)", cm.At(1, 1))
+	}
+
+	fm, err := ifd.ForwardMatrix(1)
+	log.PanicIf(err)
+
+	if fm.At(2, 1) != -0.1 {
+		t.Fatalf("Forward matrix value not correct: (%f)", fm.At(2, 1))
+	}
+
+	cc, err := ifd.CameraCalibration(1)
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(cc.Values, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}) != true {
+		t.Fatalf("Camera calibration not correct: %s", cc)
+	}
+
+	neutral, err := ifd.AsShotNeutral()
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(neutral, []float64{0.5, 1, 0.75}) != true {
+		t.Fatalf("As-shot neutral not correct: %v", neutral)
+	}
+
+	illuminant, err := ifd.CalibrationIlluminant(1)
+	log.PanicIf(err)
+
+	if illuminant != LightSourceD65 {
+		t.Fatalf("Illuminant not correct: [%s]", illuminant)
+	}
+
+	_, err = ifd.ColorMatrix(2)
+	if err != ErrTagNotFound {
+		t.Fatalf("Expected ErrTagNotFound for missing matrix: %v", err)
+	}
+
+	_, err = ifd.AnalogBalance()
+	if err != ErrTagNotFound {
+		t.Fatalf("Expected ErrTagNotFound for missing vector: %v", err)
+	}
+}
+
+func TestIfd_Dng_FourColorPlanes(t *testing.T) {
+	ifd := GetTestRootIfd(map[string]interface{}{
+		"CFAPlaneColor":  []byte{0, 1, 2, 1},
+		"ColorMatrix1":   getDngTestSignedRationals(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+		"ForwardMatrix1": getDngTestSignedRationals(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+		"AsShotNeutral":  []uint16{1, 2, 3, 4},
+	})
+
+	planes, err := ifd.ColorPlanes()
+	log.PanicIf(err)
+
+	if planes != 4 {
+		t.Fatalf("Color planes not correct: (%d)", planes)
+	}
+
+	cm, err := ifd.ColorMatrix(1)
+	log.PanicIf(err)
+
+	if cm.Rows != 4 || cm.Columns != 3 {
+		t.Fatalf("Color matrix shape not correct: %s", cm)
+	}
+
+	fm, err := ifd.ForwardMatrix(1)
+	log.PanicIf(err)
+
+	if fm.Rows != 3 || fm.Columns != 4 {
+		t.Fatalf("Forward matrix shape not correct: %s", fm)
+	}
+
+	neutral, err := ifd.AsShotNeutral()
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(neutral, []float64{1, 2, 3, 4}) != true {
+		t.Fatalf("As-shot neutral not correct: %v", neutral)
+	}
+}
+
+func TestIfd_Dng_WrongShape(t *testing.T) {
+	ifd := GetTestRootIfd(map[string]interface{}{
+		"CFAPlaneColor": []byte{0, 1, 2},
+		"ColorMatrix1":  getDngTestSignedRationals(1, 2, 3, 4, 5, 6),
+	})
+
+	_, err := ifd.ColorMatrix(1)
+	if err == nil {
+		t.Fatalf("Expected error for matrix with wrong count.")
+	} else if log.Is(err, ErrDngValueNotValid) == false {
+		t.Fatalf("Error not correct: %v", err)
+	}
+}
+
+func TestDngPrivateData_MakerNote(t *testing.T) {
+	b := new(bytes.Buffer)
+
+	b.WriteString("Adobe\000")
+
+	b.WriteString("Othr")
+	binary.Write(b, binary.BigEndian, uint32(2))
+	b.Write([]byte{0x11, 0x22})
+
+	b.WriteString("MakN")
+	binary.Write(b, binary.BigEndian, uint32(9))
+	b.WriteString("MM")
+	binary.Write(b, binary.BigEndian, uint32(0x1234))
+	b.Write([]byte{0xaa, 0xbb, 0xcc})
+
+	ifd := GetTestRootIfd(map[string]interface{}{
+		"DNGPrivateData": b.Bytes(),
+	})
+
+	dpd, err := ifd.DngPrivateData()
+	log.PanicIf(err)
+
+	if dpd.Creator != "Adobe" {
+		t.Fatalf("Creator not correct: [%s]", dpd.Creator)
+	}
+
+	mn, err := dpd.MakerNote()
+	log.PanicIf(err)
+
+	if mn.ByteOrder != binary.BigEndian {
+		t.Fatalf("Byte-order not correct.")
+	} else if mn.OriginalOffset != 0x1234 {
+		t.Fatalf("Original offset not correct: (0x%x)", mn.OriginalOffset)
+	} else if bytes.Equal(mn.Data, []byte{0xaa, 0xbb, 0xcc}) != true {
+		t.Fatalf("Maker-note data not correct: %v", mn.Data)
+	}
+
+	_, err = NewDngPrivateData([]byte("Other\000data")).MakerNote()
+	if err != ErrDngMakerNoteNotFound {
+		t.Fatalf("Expected ErrDngMakerNoteNotFound: %v", err)
+	}
+}
+
+func TestIfd_OpcodeList(t *testing.T) {
+	parameters := new(bytes.Buffer)
+
+	err := binary.Write(parameters, binary.BigEndian, []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.5, 0.5})
+	log.PanicIf(err)
+
+	ol := exifundefined.TagIfdOpcodeList{
+		Opcodes: []exifundefined.Opcode{
+			{
+				Id:         exifundefined.OpcodeFixVignetteRadial,
+				DngVersion: [4]byte{1, 3, 0, 0},
+				Flags:      exifundefined.OpcodeFlagOptional,
+				Parameters: parameters.Bytes(),
+			},
+		},
+	}
+
+	ifd := GetTestRootIfd(map[string]interface{}{
+		"OpcodeList3": ol,
+	})
+
+	recovered, err := ifd.OpcodeList(3)
+	log.PanicIf(err)
+
+	if len(recovered.Opcodes) != 1 {
+		t.Fatalf("Opcode count not correct: %s", recovered)
+	}
+
+	fvr, ok := recovered.Opcodes[0].Record.(exifundefined.FixVignetteRadial)
+	if ok == false {
+		t.Fatalf("Opcode record not correct: %v", recovered.Opcodes[0].Record)
+	} else if fvr.K != [5]float64{0.1, 0.2, 0.3, 0.4, 0.5} || fvr.CenterX != 0.5 {
+		t.Fatalf("Opcode record values not correct: %v", fvr)
+	}
+
+	_, err = ifd.OpcodeList(1)
+	if err != ErrTagNotFound {
+		t.Fatalf("Expected ErrTagNotFound for missing list: %v", err)
+	}
+}
+
+func TestIfd_Dng_NotRootIfd(t *testing.T) {
+	exifData := getTestExifData()
+	index := getTestIndex(exifData)
+
+	_, err := index.Lookup["IFD/Exif"].ColorMatrix(1)
+	if err == nil {
+		t.Fatalf("Expected error for EXIF IFD.")
+	}
+}
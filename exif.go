@@ -50,7 +50,7 @@ var (
 func SearchAndExtractExif(data []byte) (rawExif []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -72,7 +72,7 @@ func SearchAndExtractExif(data []byte) (rawExif []byte, err error) {
 func SearchAndExtractExifWithReadSeeker(r io.ReadSeeker, size int64) (rawExif []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -97,7 +97,7 @@ func SearchAndExtractExifWithReadSeeker(r io.ReadSeeker, size int64) (rawExif []
 func SearchFileAndExtractExif(filepath string) (rawExif []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -133,7 +133,7 @@ func (eh ExifHeader) String() string {
 func ParseExifHeader(data []byte) (eh ExifHeader, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -164,7 +164,7 @@ func ParseExifHeader(data []byte) (eh ExifHeader, err error) {
 func Visit(s *Scanner, rootIfdIdentity *exifcommon.IfdIdentity, ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, visitor TagVisitorFn) (eh ExifHeader, furthestOffset uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -188,7 +188,7 @@ func Visit(s *Scanner, rootIfdIdentity *exifcommon.IfdIdentity, ifdMapping *exif
 func Collect(s *Scanner, ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex) (eh ExifHeader, index IfdIndex, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -206,11 +206,34 @@ func Collect(s *Scanner, ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex)
 	return eh, index, nil
 }
 
+// CollectWithOptions is `Collect` with control over how strict the parse is
+// and what is collected.
+func CollectWithOptions(s *Scanner, ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, options ScanOptions) (eh ExifHeader, index IfdIndex, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	window, err := s.Peek(ExifSignatureLength)
+	log.PanicIf(err)
+
+	eh, err = ParseExifHeader(window)
+	log.PanicIf(err)
+
+	ie := NewIfdEnumerate(s, ifdMapping, tagIndex, eh.ByteOrder)
+
+	index, err = ie.CollectWithOptions(eh.FirstIfdOffset, options)
+	log.PanicIf(err)
+
+	return eh, index, nil
+}
+
 // BuildExifHeader constructs the bytes that go at the front of the stream.
 func BuildExifHeader(byteOrder binary.ByteOrder, firstIfdOffset uint32) (headerBytes []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
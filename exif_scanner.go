@@ -57,7 +57,7 @@ func NewScannerNoLimit(r io.ReadSeeker, size int64) (s *Scanner, err error) {
 func NewScannerLimit(r io.ReadSeeker, size, startLimit, scanLimit int64) (s *Scanner, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -202,7 +202,22 @@ func (s *Scanner) Discard(n int64) (int, error) {
 func (s *Scanner) GetFlatExifData() (exifTags []ExifTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
+		}
+	}()
+
+	exifTags, _, err = s.GetFlatExifDataWithOptions(ScanOptions{})
+	log.PanicIf(err)
+
+	return exifTags, nil
+}
+
+// GetFlatExifDataWithOptions returns a simple, flat representation of all
+// tags along with the additional data collected during the parse.
+func (s *Scanner) GetFlatExifDataWithOptions(options ScanOptions) (exifTags []ExifTag, med *MiscellaneousExifData, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -213,7 +228,7 @@ func (s *Scanner) GetFlatExifData() (exifTags []ExifTag, err error) {
 		tempDir := os.TempDir()
 		tempFile, err := ioutil.TempFile(tempDir, "file.txt")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		defer os.Remove(tempFile.Name())
 
@@ -242,33 +257,48 @@ func (s *Scanner) GetFlatExifData() (exifTags []ExifTag, err error) {
 	log.PanicIf(err)
 
 	im := NewIfdMappingWithStandard()
-	ti := NewTagIndex()
+	ti := StandardTagIndex()
 
 	ie := NewIfdEnumerate(s, im, ti, eh.ByteOrder)
 
 	exifTags = make([]ExifTag, 0)
 
-	visitor := func(fqIfdPath string, ifdIndex int, ite *IfdTagEntry) (err error) {
-		// This encodes down to base64. Since this an example tool and we do not
-		// expect to ever decode the output, we are not worried about
-		// specifically base64-encoding it in order to have a measure of
-		// control.
-		valueBytes, err := ite.GetRawBytes()
-		if err != nil {
-			if err == exifundefined.ErrUnparseableValue {
-				return nil
-			}
+	// Values that we skip are reported along with the rest of the
+	// diagnostics once the scan is done.
+	skipped := make([]*IfdTagEntry, 0)
 
-			log.Panic(err)
-		}
+	visitor := func(fqIfdPath string, ifdIndex int, ite *IfdTagEntry) (err error) {
+		var valueBytes []byte
+		var value interface{}
+
+		if ite.TagType() == exifcommon.TypeUndefined && options.SkipUndefinedDecode == true {
+			valueBytes, err = ite.getRawUndefinedBytes()
+			log.PanicIf(err)
+
+			value = valueBytes
+		} else {
+			// This encodes down to base64. Since this an example tool and we do not
+			// expect to ever decode the output, we are not worried about
+			// specifically base64-encoding it in order to have a measure of
+			// control.
+			valueBytes, err = ite.GetRawBytes()
+			if err != nil {
+				if err == exifundefined.ErrUnparseableValue {
+					skipped = append(skipped, ite)
+					return nil
+				}
 
-		value, err := ite.Value()
-		if err != nil {
-			if err == exifcommon.ErrUnhandledUndefinedTypedTag {
-				value = exifundefined.UnparseableUnknownTagValuePlaceholder
-			} else {
 				log.Panic(err)
 			}
+
+			value, err = ite.Value()
+			if err != nil {
+				if err == exifcommon.ErrUnhandledUndefinedTypedTag {
+					value = exifundefined.UnparseableUnknownTagValuePlaceholder
+				} else {
+					log.Panic(err)
+				}
+			}
 		}
 
 		et := ExifTag{
@@ -283,19 +313,36 @@ func (s *Scanner) GetFlatExifData() (exifTags []ExifTag, err error) {
 			ChildIfdPath: ite.ChildIfdPath(),
 		}
 
-		et.Formatted, err = ite.Format()
-		log.PanicIf(err)
+		if options.SkipFormatting == false {
+			if ite.TagType() == exifcommon.TypeUndefined && options.SkipUndefinedDecode == true {
+				et.Formatted, err = exifcommon.FormatFromType(valueBytes, false)
+				log.PanicIf(err)
 
-		et.FormattedFirst, err = ite.FormatFirst()
-		log.PanicIf(err)
+				et.FormattedFirst, err = exifcommon.FormatFromType(valueBytes, true)
+				log.PanicIf(err)
+			} else {
+				et.Formatted, err = ite.Format()
+				log.PanicIf(err)
+
+				et.FormattedFirst, err = ite.FormatFirst()
+				log.PanicIf(err)
+			}
+		}
 
 		exifTags = append(exifTags, et)
 
 		return nil
 	}
 
-	_, err = ie.Scan(exifcommon.IfdStandardIfdIdentity, eh.FirstIfdOffset, visitor)
+	med, err = ie.ScanWithOptions(exifcommon.IfdStandardIfdIdentity, eh.FirstIfdOffset, visitor, options)
 	log.PanicIf(err)
 
-	return exifTags, nil
+	for _, ite := range skipped {
+		med.addTagDiagnostic(
+			DiagnosticError, DiagnosticValueUnparseable, ite,
+			"undefined-type value of tag [%s] could not be parsed and was skipped",
+			ite.TagName())
+	}
+
+	return exifTags, med, nil
 }
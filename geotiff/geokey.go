@@ -0,0 +1,225 @@
+package exifgeotiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyId identifies a GeoKey (OGC GeoTIFF 1.1, section 7).
+type KeyId uint16
+
+const (
+	// GeoTIFF configuration keys.
+
+	GTModelTypeGeoKey  KeyId = 1024
+	GTRasterTypeGeoKey KeyId = 1025
+	GTCitationGeoKey   KeyId = 1026
+
+	// Geographic CRS parameter keys.
+
+	GeographicTypeGeoKey        KeyId = 2048
+	GeogCitationGeoKey          KeyId = 2049
+	GeogGeodeticDatumGeoKey     KeyId = 2050
+	GeogPrimeMeridianGeoKey     KeyId = 2051
+	GeogLinearUnitsGeoKey       KeyId = 2052
+	GeogLinearUnitSizeGeoKey    KeyId = 2053
+	GeogAngularUnitsGeoKey      KeyId = 2054
+	GeogAngularUnitSizeGeoKey   KeyId = 2055
+	GeogEllipsoidGeoKey         KeyId = 2056
+	GeogSemiMajorAxisGeoKey     KeyId = 2057
+	GeogSemiMinorAxisGeoKey     KeyId = 2058
+	GeogInvFlatteningGeoKey     KeyId = 2059
+	GeogAzimuthUnitsGeoKey      KeyId = 2060
+	GeogPrimeMeridianLongGeoKey KeyId = 2061
+
+	// Projected CRS parameter keys.
+
+	ProjectedCSTypeGeoKey          KeyId = 3072
+	PCSCitationGeoKey              KeyId = 3073
+	ProjectionGeoKey               KeyId = 3074
+	ProjCoordTransGeoKey           KeyId = 3075
+	ProjLinearUnitsGeoKey          KeyId = 3076
+	ProjLinearUnitSizeGeoKey       KeyId = 3077
+	ProjStdParallel1GeoKey         KeyId = 3078
+	ProjStdParallel2GeoKey         KeyId = 3079
+	ProjNatOriginLongGeoKey        KeyId = 3080
+	ProjNatOriginLatGeoKey         KeyId = 3081
+	ProjFalseEastingGeoKey         KeyId = 3082
+	ProjFalseNorthingGeoKey        KeyId = 3083
+	ProjFalseOriginLongGeoKey      KeyId = 3084
+	ProjFalseOriginLatGeoKey       KeyId = 3085
+	ProjFalseOriginEastingGeoKey   KeyId = 3086
+	ProjFalseOriginNorthingGeoKey  KeyId = 3087
+	ProjCenterLongGeoKey           KeyId = 3088
+	ProjCenterLatGeoKey            KeyId = 3089
+	ProjCenterEastingGeoKey        KeyId = 3090
+	ProjCenterNorthingGeoKey       KeyId = 3091
+	ProjScaleAtNatOriginGeoKey     KeyId = 3092
+	ProjScaleAtCenterGeoKey        KeyId = 3093
+	ProjAzimuthAngleGeoKey         KeyId = 3094
+	ProjStraightVertPoleLongGeoKey KeyId = 3095
+
+	// Vertical CRS parameter keys.
+
+	VerticalCSTypeGeoKey   KeyId = 4096
+	VerticalCitationGeoKey KeyId = 4097
+	VerticalDatumGeoKey    KeyId = 4098
+	VerticalUnitsGeoKey    KeyId = 4099
+)
+
+var (
+	keyNames = map[KeyId]string{
+		GTModelTypeGeoKey:              "GTModelType",
+		GTRasterTypeGeoKey:             "GTRasterType",
+		GTCitationGeoKey:               "GTCitation",
+		GeographicTypeGeoKey:           "GeographicType",
+		GeogCitationGeoKey:             "GeogCitation",
+		GeogGeodeticDatumGeoKey:        "GeogGeodeticDatum",
+		GeogPrimeMeridianGeoKey:        "GeogPrimeMeridian",
+		GeogLinearUnitsGeoKey:          "GeogLinearUnits",
+		GeogLinearUnitSizeGeoKey:       "GeogLinearUnitSize",
+		GeogAngularUnitsGeoKey:         "GeogAngularUnits",
+		GeogAngularUnitSizeGeoKey:      "GeogAngularUnitSize",
+		GeogEllipsoidGeoKey:            "GeogEllipsoid",
+		GeogSemiMajorAxisGeoKey:        "GeogSemiMajorAxis",
+		GeogSemiMinorAxisGeoKey:        "GeogSemiMinorAxis",
+		GeogInvFlatteningGeoKey:        "GeogInvFlattening",
+		GeogAzimuthUnitsGeoKey:         "GeogAzimuthUnits",
+		GeogPrimeMeridianLongGeoKey:    "GeogPrimeMeridianLong",
+		ProjectedCSTypeGeoKey:          "ProjectedCSType",
+		PCSCitationGeoKey:              "PCSCitation",
+		ProjectionGeoKey:               "Projection",
+		ProjCoordTransGeoKey:           "ProjCoordTrans",
+		ProjLinearUnitsGeoKey:          "ProjLinearUnits",
+		ProjLinearUnitSizeGeoKey:       "ProjLinearUnitSize",
+		ProjStdParallel1GeoKey:         "ProjStdParallel1",
+		ProjStdParallel2GeoKey:         "ProjStdParallel2",
+		ProjNatOriginLongGeoKey:        "ProjNatOriginLong",
+		ProjNatOriginLatGeoKey:         "ProjNatOriginLat",
+		ProjFalseEastingGeoKey:         "ProjFalseEasting",
+		ProjFalseNorthingGeoKey:        "ProjFalseNorthing",
+		ProjFalseOriginLongGeoKey:      "ProjFalseOriginLong",
+		ProjFalseOriginLatGeoKey:       "ProjFalseOriginLat",
+		ProjFalseOriginEastingGeoKey:   "ProjFalseOriginEasting",
+		ProjFalseOriginNorthingGeoKey:  "ProjFalseOriginNorthing",
+		ProjCenterLongGeoKey:           "ProjCenterLong",
+		ProjCenterLatGeoKey:            "ProjCenterLat",
+		ProjCenterEastingGeoKey:        "ProjCenterEasting",
+		ProjCenterNorthingGeoKey:       "ProjCenterNorthing",
+		ProjScaleAtNatOriginGeoKey:     "ProjScaleAtNatOrigin",
+		ProjScaleAtCenterGeoKey:        "ProjScaleAtCenter",
+		ProjAzimuthAngleGeoKey:         "ProjAzimuthAngle",
+		ProjStraightVertPoleLongGeoKey: "ProjStraightVertPoleLong",
+		VerticalCSTypeGeoKey:           "VerticalCSType",
+		VerticalCitationGeoKey:         "VerticalCitation",
+		VerticalDatumGeoKey:            "VerticalDatum",
+		VerticalUnitsGeoKey:            "VerticalUnits",
+	}
+)
+
+// String returns the name of the key (e.g. "GTModelType"). Keys that are not
+// defined are returned as a number.
+func (ki KeyId) String() string {
+	if name, found := keyNames[ki]; found == true {
+		return name
+	}
+
+	return fmt.Sprintf("GeoKey(%d)", uint16(ki))
+}
+
+// UserDefined is the value of a code-type key whose parameters are given by
+// other keys rather than by an EPSG code.
+const UserDefined = 32767
+
+// ModelType is the value of the GTModelType key.
+type ModelType uint16
+
+const (
+	ModelTypeProjected  ModelType = 1
+	ModelTypeGeographic ModelType = 2
+	ModelTypeGeocentric ModelType = 3
+)
+
+// String returns a descriptive string.
+func (mt ModelType) String() string {
+	switch mt {
+	case ModelTypeProjected:
+		return "Projected"
+	case ModelTypeGeographic:
+		return "Geographic"
+	case ModelTypeGeocentric:
+		return "Geocentric"
+	}
+
+	return fmt.Sprintf("ModelType(%d)", uint16(mt))
+}
+
+// RasterType is the value of the GTRasterType key.
+type RasterType uint16
+
+const (
+	// RasterPixelIsArea means that a raster coordinate refers to the top-left
+	// corner of a pixel.
+	RasterPixelIsArea RasterType = 1
+
+	// RasterPixelIsPoint means that a raster coordinate refers to the center
+	// of a pixel.
+	RasterPixelIsPoint RasterType = 2
+)
+
+// String returns a descriptive string.
+func (rt RasterType) String() string {
+	switch rt {
+	case RasterPixelIsArea:
+		return "PixelIsArea"
+	case RasterPixelIsPoint:
+		return "PixelIsPoint"
+	}
+
+	return fmt.Sprintf("RasterType(%d)", uint16(rt))
+}
+
+// GeoKey is one key from the key directory. Depending on where the value is
+// stored, exactly one of `Shorts`, `Doubles`, or `Ascii` is set.
+type GeoKey struct {
+	Id KeyId
+
+	// Location is the tag that the value is stored in (0 if it is stored in
+	// the key itself).
+	Location uint16
+
+	Shorts  []uint16
+	Doubles []float64
+	Ascii   string
+}
+
+// Name returns the name of the key.
+func (gk GeoKey) Name() string {
+	return gk.Id.String()
+}
+
+// Value returns the value of the key.
+func (gk GeoKey) Value() interface{} {
+	if gk.Shorts != nil {
+		return gk.Shorts
+	} else if gk.Doubles != nil {
+		return gk.Doubles
+	}
+
+	return gk.Ascii
+}
+
+// String returns a descriptive string.
+func (gk GeoKey) String() string {
+	var valuePhrase string
+
+	if gk.Shorts != nil {
+		valuePhrase = strings.Trim(fmt.Sprintf("%v", gk.Shorts), "[]")
+	} else if gk.Doubles != nil {
+		valuePhrase = strings.Trim(fmt.Sprintf("%v", gk.Doubles), "[]")
+	} else {
+		valuePhrase = fmt.Sprintf("%q", gk.Ascii)
+	}
+
+	return fmt.Sprintf("GeoKey<NAME=[%s] VALUE=[%s]>", gk.Name(), valuePhrase)
+}
@@ -0,0 +1,48 @@
+package exifgeotiff
+
+import (
+	"testing"
+)
+
+func TestKeyId_String(t *testing.T) {
+	if GTModelTypeGeoKey.String() != "GTModelType" {
+		t.Fatalf("Name not correct: [%s]", GTModelTypeGeoKey.String())
+	} else if ProjectedCSTypeGeoKey.String() != "ProjectedCSType" {
+		t.Fatalf("Name not correct: [%s]", ProjectedCSTypeGeoKey.String())
+	} else if KeyId(5000).String() != "GeoKey(5000)" {
+		t.Fatalf("Name not correct: [%s]", KeyId(5000).String())
+	}
+}
+
+func TestModelType_String(t *testing.T) {
+	if ModelTypeGeographic.String() != "Geographic" {
+		t.Fatalf("String not correct: [%s]", ModelTypeGeographic.String())
+	} else if ModelType(9).String() != "ModelType(9)" {
+		t.Fatalf("String not correct: [%s]", ModelType(9).String())
+	}
+}
+
+func TestRasterType_String(t *testing.T) {
+	if RasterPixelIsPoint.String() != "PixelIsPoint" {
+		t.Fatalf("String not correct: [%s]", RasterPixelIsPoint.String())
+	}
+}
+
+func TestGeoKey_String(t *testing.T) {
+	gk := GeoKey{Id: GTModelTypeGeoKey, Shorts: []uint16{1}}
+	if gk.String() != "GeoKey<NAME=[GTModelType] VALUE=[1]>" {
+		t.Fatalf("String not correct: [%s]", gk.String())
+	}
+
+	gk = GeoKey{Id: GeogInvFlatteningGeoKey, Location: 0x87b0, Doubles: []float64{298.25}}
+	if gk.String() != "GeoKey<NAME=[GeogInvFlattening] VALUE=[298.25]>" {
+		t.Fatalf("String not correct: [%s]", gk.String())
+	}
+
+	gk = GeoKey{Id: GTCitationGeoKey, Location: 0x87b1, Ascii: "WGS 84"}
+	if gk.String() != "GeoKey<NAME=[GTCitation] VALUE=[\"WGS 84\"]>" {
+		t.Fatalf("String not correct: [%s]", gk.String())
+	} else if gk.Value() != "WGS 84" {
+		t.Fatalf("Value not correct: [%v]", gk.Value())
+	}
+}
@@ -0,0 +1,197 @@
+// Package exifgeotiff reads the GeoTIFF tags of an IFD: the GeoKey directory
+// and the relationship between raster and model space.
+package exifgeotiff
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dsoprea/go-logging"
+
+	exif "github.com/imclaren/go-exif"
+)
+
+var (
+	// ErrNoGeoTiff means that the IFD has no GeoTIFF tags.
+	ErrNoGeoTiff = errors.New("no GeoTIFF tags")
+
+	// ErrNoTransform means that there is neither a ModelTransformation nor a
+	// ModelPixelScale and ModelTiepoint.
+	ErrNoTransform = errors.New("no GeoTIFF transform")
+
+	// ErrNoImageSize means that the IFD has no ImageWidth or ImageLength.
+	ErrNoImageSize = errors.New("no image size")
+)
+
+// Tiepoint ties the raster point (I, J, K) to the model point (X, Y, Z).
+type Tiepoint struct {
+	I, J, K float64
+	X, Y, Z float64
+}
+
+// GeoTiff has the GeoTIFF information of an IFD.
+type GeoTiff struct {
+	// Keys is nil if there is no GeoKeyDirectory.
+	Keys *KeyDirectory
+
+	PixelScale          []float64
+	Tiepoints           []Tiepoint
+	ModelTransformation []float64
+
+	// Width and Height are the size of the image in pixels, or (0) if the
+	// IFD does not have them.
+	Width  uint32
+	Height uint32
+}
+
+// NewGeoTiffFromIfd reads the GeoTIFF tags of the IFD (usually IFD0). Returns
+// `ErrNoGeoTiff` if there are none.
+func NewGeoTiffFromIfd(ifd *exif.Ifd) (gt *GeoTiff, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	gt = new(GeoTiff)
+
+	found := false
+
+	if value, tagFound := getIfdTagValue(ifd, exif.TagGeoKeyDirectory); tagFound == true {
+		directory, ok := value.([]uint16)
+		if ok == false {
+			log.Panic(ErrKeyDirectoryNotValid)
+		}
+
+		var doubleParams []float64
+		if value, tagFound := getIfdTagValue(ifd, exif.TagGeoDoubleParams); tagFound == true {
+			doubleParams, _ = value.([]float64)
+		}
+
+		var asciiParams string
+		if value, tagFound := getIfdTagValue(ifd, exif.TagGeoAsciiParams); tagFound == true {
+			asciiParams, _ = value.(string)
+		}
+
+		gt.Keys, err = ParseKeyDirectory(directory, doubleParams, asciiParams)
+		log.PanicIf(err)
+
+		found = true
+	}
+
+	if value, tagFound := getIfdTagValue(ifd, exif.TagModelPixelScale); tagFound == true {
+		gt.PixelScale, _ = value.([]float64)
+		found = true
+	}
+
+	if value, tagFound := getIfdTagValue(ifd, exif.TagModelTiepoint); tagFound == true {
+		values, _ := value.([]float64)
+
+		gt.Tiepoints = make([]Tiepoint, len(values)/6)
+		for i := range gt.Tiepoints {
+			v := values[i*6 : i*6+6]
+			gt.Tiepoints[i] = Tiepoint{I: v[0], J: v[1], K: v[2], X: v[3], Y: v[4], Z: v[5]}
+		}
+
+		found = true
+	}
+
+	if value, tagFound := getIfdTagValue(ifd, exif.TagModelTransformation); tagFound == true {
+		gt.ModelTransformation, _ = value.([]float64)
+		found = true
+	}
+
+	if found == false {
+		return nil, ErrNoGeoTiff
+	}
+
+	gt.Width = getIfdTagSize(ifd, exif.TagImageWidth)
+	gt.Height = getIfdTagSize(ifd, exif.TagImageLength)
+
+	return gt, nil
+}
+
+// getIfdTagValue returns the value of the first tag with the given ID.
+func getIfdTagValue(ifd *exif.Ifd, tagId uint16) (value interface{}, found bool) {
+	tags, found := ifd.EntriesByTagId[tagId]
+	if found == false {
+		return nil, false
+	}
+
+	value, err := tags[0].Value()
+	log.PanicIf(err)
+
+	return value, true
+}
+
+// getIfdTagSize returns the value of a SHORT or LONG size tag, or (0).
+func getIfdTagSize(ifd *exif.Ifd, tagId uint16) uint32 {
+	value, found := getIfdTagValue(ifd, tagId)
+	if found == false {
+		return 0
+	}
+
+	switch t := value.(type) {
+	case []uint16:
+		if len(t) > 0 {
+			return uint32(t[0])
+		}
+	case []uint32:
+		if len(t) > 0 {
+			return t[0]
+		}
+	}
+
+	return 0
+}
+
+// Transform returns the transform from raster to model space. The
+// ModelTransformation is preferred. Otherwise, the first tie-point and the
+// pixel scale are used.
+func (gt *GeoTiff) Transform() (t Transform, err error) {
+	if len(gt.ModelTransformation) == 16 {
+		return newTransformFromMatrix(gt.ModelTransformation), nil
+	} else if len(gt.PixelScale) >= 2 && len(gt.Tiepoints) > 0 {
+		return newTransformFromTiepoint(gt.PixelScale[0], gt.PixelScale[1], gt.Tiepoints[0]), nil
+	}
+
+	return t, ErrNoTransform
+}
+
+// Corners returns the model coordinates of the corners and center of the
+// image. If raster coordinates refer to the centers of pixels (PixelIsPoint),
+// the corners are half a pixel further out.
+func (gt *GeoTiff) Corners() (c Corners, err error) {
+	t, err := gt.Transform()
+	if err != nil {
+		return c, err
+	}
+
+	if gt.Width == 0 || gt.Height == 0 {
+		return c, ErrNoImageSize
+	}
+
+	left := 0.0
+	if gt.Keys != nil && gt.Keys.RasterType() == RasterPixelIsPoint {
+		left = -0.5
+	}
+
+	top := left
+	right := left + float64(gt.Width)
+	bottom := top + float64(gt.Height)
+
+	c = Corners{
+		UpperLeft:  t.Apply(left, top),
+		UpperRight: t.Apply(right, top),
+		LowerRight: t.Apply(right, bottom),
+		LowerLeft:  t.Apply(left, bottom),
+		Center:     t.Apply((left+right)/2, (top+bottom)/2),
+	}
+
+	return c, nil
+}
+
+// String returns a descriptive string.
+func (gt *GeoTiff) String() string {
+	return fmt.Sprintf("GeoTiff<KEYS=%v WIDTH=(%d) HEIGHT=(%d)>", gt.Keys, gt.Width, gt.Height)
+}
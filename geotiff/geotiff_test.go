@@ -0,0 +1,123 @@
+package exifgeotiff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dsoprea/go-logging"
+
+	exif "github.com/imclaren/go-exif"
+)
+
+func TestNewGeoTiffFromIfd_Projected(t *testing.T) {
+	ifd := exif.GetTestRootIfd(map[string]interface{}{
+		"ImageWidth":      []uint32{100},
+		"ImageLength":     []uint32{200},
+		"ModelPixelScale": []float64{0.5, 0.5, 0},
+		"ModelTiepoint":   []float64{0, 0, 0, 500000, 4000000, 0},
+		"GeoKeyDirectory": testKeyDirectory,
+		"GeoDoubleParams": testDoubleParams,
+		"GeoAsciiParams":  testAsciiParams,
+	})
+
+	gt, err := NewGeoTiffFromIfd(ifd)
+	log.PanicIf(err)
+
+	if gt.Width != 100 || gt.Height != 200 {
+		t.Fatalf("Size not correct: %s", gt)
+	} else if len(gt.Keys.Keys) != 5 {
+		t.Fatalf("Keys not correct: %s", gt.Keys)
+	}
+
+	gk, _ := gt.Keys.Get(GTCitationGeoKey)
+	if gk.Ascii != "WGS 84 / UTM zone 33N" {
+		t.Fatalf("Citation not correct: %s", gk)
+	}
+
+	c, err := gt.Corners()
+	log.PanicIf(err)
+
+	expected := Corners{
+		UpperLeft:  Point{X: 500000, Y: 4000000},
+		UpperRight: Point{X: 500050, Y: 4000000},
+		LowerRight: Point{X: 500050, Y: 3999900},
+		LowerLeft:  Point{X: 500000, Y: 3999900},
+		Center:     Point{X: 500025, Y: 3999950},
+	}
+
+	if c != expected {
+		t.Fatalf("Corners not correct: %s", c)
+	}
+}
+
+func TestNewGeoTiffFromIfd_GeographicPixelIsPoint(t *testing.T) {
+	ifd := exif.GetTestRootIfd(map[string]interface{}{
+		"ImageWidth":  []uint32{10},
+		"ImageLength": []uint32{10},
+		"ModelTransformation": []float64{
+			0.001, 0, 0, 10,
+			0, -0.001, 0, 50,
+			0, 0, 0, 0,
+			0, 0, 0, 1,
+		},
+		"GeoKeyDirectory": []uint16{
+			1, 1, 0, 3,
+			1024, 0, 1, 2,
+			1025, 0, 1, 2,
+			2048, 0, 1, 4326,
+		},
+	})
+
+	gt, err := NewGeoTiffFromIfd(ifd)
+	log.PanicIf(err)
+
+	mt, _ := gt.Keys.ModelType()
+	if mt != ModelTypeGeographic {
+		t.Fatalf("Model type not correct: %s", mt)
+	}
+
+	c, err := gt.Corners()
+	log.PanicIf(err)
+
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) < 1e-9
+	}
+
+	if near(c.UpperLeft.X, 9.9995) == false || near(c.UpperLeft.Y, 50.0005) == false {
+		t.Fatalf("Upper-left not correct: %s", c.UpperLeft)
+	} else if near(c.LowerRight.X, 10.0095) == false || near(c.LowerRight.Y, 49.9905) == false {
+		t.Fatalf("Lower-right not correct: %s", c.LowerRight)
+	}
+
+	ll := c.Center.LatLng()
+	if near(ll.Lat.Degrees(), 49.9955) == false || near(ll.Lng.Degrees(), 10.0045) == false {
+		t.Fatalf("Center not correct: %s", ll)
+	}
+}
+
+func TestNewGeoTiffFromIfd_NoGeoTiff(t *testing.T) {
+	ifd := exif.GetTestRootIfd(map[string]interface{}{
+		"ImageWidth": []uint32{100},
+	})
+
+	_, err := NewGeoTiffFromIfd(ifd)
+	if err != ErrNoGeoTiff {
+		t.Fatalf("Expected ErrNoGeoTiff: %v", err)
+	}
+}
+
+func TestGeoTiff_Corners_NotAvailable(t *testing.T) {
+	gt := &GeoTiff{PixelScale: []float64{1, 1, 0}}
+
+	_, err := gt.Corners()
+	if err != ErrNoTransform {
+		t.Fatalf("Expected ErrNoTransform: %v", err)
+	}
+
+	gt.Tiepoints = []Tiepoint{{}}
+
+	_, err = gt.Corners()
+	if err != ErrNoImageSize {
+		t.Fatalf("Expected ErrNoImageSize: %v", err)
+	}
+}
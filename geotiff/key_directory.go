@@ -0,0 +1,150 @@
+package exifgeotiff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dsoprea/go-logging"
+
+	exif "github.com/imclaren/go-exif"
+)
+
+var (
+	// ErrKeyDirectoryNotValid means that the key directory is truncated or
+	// refers to values that are not there.
+	ErrKeyDirectoryNotValid = errors.New("GeoKey directory not valid")
+)
+
+// KeyDirectory is the decoded GeoKeyDirectory tag.
+type KeyDirectory struct {
+	Version       uint16
+	Revision      uint16
+	MinorRevision uint16
+
+	Keys []GeoKey
+}
+
+// ParseKeyDirectory decodes the values of the GeoKeyDirectory tag. The values
+// of the GeoDoubleParams and GeoAsciiParams tags are required if any keys are
+// stored in them.
+func ParseKeyDirectory(directory []uint16, doubleParams []float64, asciiParams string) (kd *KeyDirectory, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if len(directory) < 4 {
+		return nil, ErrKeyDirectoryNotValid
+	}
+
+	keyCount := int(directory[3])
+	if len(directory) < 4+keyCount*4 {
+		return nil, ErrKeyDirectoryNotValid
+	}
+
+	kd = &KeyDirectory{
+		Version:       directory[0],
+		Revision:      directory[1],
+		MinorRevision: directory[2],
+		Keys:          make([]GeoKey, keyCount),
+	}
+
+	for i := 0; i < keyCount; i++ {
+		entry := directory[4+i*4 : 8+i*4]
+
+		gk := GeoKey{
+			Id:       KeyId(entry[0]),
+			Location: entry[1],
+		}
+
+		count := int(entry[2])
+		offset := int(entry[3])
+
+		switch gk.Location {
+		case 0:
+			gk.Shorts = []uint16{entry[3]}
+		case exif.TagGeoKeyDirectory:
+			if offset+count > len(directory) {
+				return nil, ErrKeyDirectoryNotValid
+			}
+
+			gk.Shorts = directory[offset : offset+count]
+		case exif.TagGeoDoubleParams:
+			if offset+count > len(doubleParams) {
+				return nil, ErrKeyDirectoryNotValid
+			}
+
+			gk.Doubles = doubleParams[offset : offset+count]
+		case exif.TagGeoAsciiParams:
+			if offset+count > len(asciiParams) {
+				return nil, ErrKeyDirectoryNotValid
+			}
+
+			// Each value is terminated with a pipe rather than a NUL.
+			gk.Ascii = strings.TrimRight(asciiParams[offset:offset+count], "|\x00")
+		default:
+			// The standard allows other tags but nobody uses them. We keep
+			// the key without a value.
+		}
+
+		kd.Keys[i] = gk
+	}
+
+	return kd, nil
+}
+
+// Get returns the key with the given ID.
+func (kd *KeyDirectory) Get(id KeyId) (gk GeoKey, found bool) {
+	for _, gk := range kd.Keys {
+		if gk.Id == id {
+			return gk, true
+		}
+	}
+
+	return gk, false
+}
+
+// Short returns the first SHORT value of the key.
+func (kd *KeyDirectory) Short(id KeyId) (value uint16, found bool) {
+	gk, found := kd.Get(id)
+	if found == false || len(gk.Shorts) == 0 {
+		return 0, false
+	}
+
+	return gk.Shorts[0], true
+}
+
+// ModelType returns the GTModelType.
+func (kd *KeyDirectory) ModelType() (mt ModelType, found bool) {
+	value, found := kd.Short(GTModelTypeGeoKey)
+	return ModelType(value), found
+}
+
+// RasterType returns the GTRasterType. PixelIsArea is the default.
+func (kd *KeyDirectory) RasterType() RasterType {
+	if value, found := kd.Short(GTRasterTypeGeoKey); found == true {
+		return RasterType(value)
+	}
+
+	return RasterPixelIsArea
+}
+
+// EpsgCode returns the EPSG code of the coordinate reference system: the
+// ProjectedCSType or, if there is none, the GeographicType. A user-defined
+// system has no code.
+func (kd *KeyDirectory) EpsgCode() (code uint16, found bool) {
+	for _, id := range []KeyId{ProjectedCSTypeGeoKey, GeographicTypeGeoKey} {
+		if value, found := kd.Short(id); found == true && value != UserDefined {
+			return value, true
+		}
+	}
+
+	return 0, false
+}
+
+// String returns a descriptive string.
+func (kd *KeyDirectory) String() string {
+	return fmt.Sprintf("KeyDirectory<VERSION=(%d) REVISION=(%d.%d) KEYS=(%d)>", kd.Version, kd.Revision, kd.MinorRevision, len(kd.Keys))
+}
@@ -0,0 +1,119 @@
+package exifgeotiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dsoprea/go-logging"
+)
+
+var (
+	testKeyDirectory = []uint16{
+		1, 1, 0, 5,
+		1024, 0, 1, 1,
+		1025, 0, 1, 1,
+		1026, 0x87b1, 22, 0,
+		2059, 0x87b0, 1, 0,
+		3072, 0, 1, 32633,
+	}
+
+	testDoubleParams = []float64{298.257223563}
+	testAsciiParams  = "WGS 84 / UTM zone 33N|"
+)
+
+func TestParseKeyDirectory(t *testing.T) {
+	kd, err := ParseKeyDirectory(testKeyDirectory, testDoubleParams, testAsciiParams)
+	log.PanicIf(err)
+
+	if kd.Version != 1 || kd.Revision != 1 || kd.MinorRevision != 0 {
+		t.Fatalf("Version not correct: %s", kd)
+	} else if len(kd.Keys) != 5 {
+		t.Fatalf("Key count not correct: (%d)", len(kd.Keys))
+	}
+
+	names := make([]string, len(kd.Keys))
+	for i, gk := range kd.Keys {
+		names[i] = gk.Name()
+	}
+
+	expectedNames := []string{"GTModelType", "GTRasterType", "GTCitation", "GeogInvFlattening", "ProjectedCSType"}
+	if reflect.DeepEqual(names, expectedNames) != true {
+		t.Fatalf("Key names not correct: %v", names)
+	}
+
+	gk, found := kd.Get(GTCitationGeoKey)
+	if found != true || gk.Ascii != "WGS 84 / UTM zone 33N" {
+		t.Fatalf("Citation not correct: %s", gk)
+	}
+
+	gk, found = kd.Get(GeogInvFlatteningGeoKey)
+	if found != true || reflect.DeepEqual(gk.Doubles, testDoubleParams) != true {
+		t.Fatalf("Inverse flattening not correct: %s", gk)
+	}
+
+	mt, found := kd.ModelType()
+	if found != true || mt != ModelTypeProjected {
+		t.Fatalf("Model type not correct: %s", mt)
+	} else if kd.RasterType() != RasterPixelIsArea {
+		t.Fatalf("Raster type not correct: %s", kd.RasterType())
+	}
+
+	code, found := kd.EpsgCode()
+	if found != true || code != 32633 {
+		t.Fatalf("EPSG code not correct: (%d)", code)
+	}
+
+	_, found = kd.Get(VerticalUnitsGeoKey)
+	if found != false {
+		t.Fatalf("Key not expected.")
+	}
+}
+
+func TestParseKeyDirectory_ShortParams(t *testing.T) {
+	directory := []uint16{
+		1, 1, 1, 1,
+		3078, 0x87af, 2, 8,
+		7, 8,
+	}
+
+	kd, err := ParseKeyDirectory(directory, nil, "")
+	log.PanicIf(err)
+
+	gk, _ := kd.Get(ProjStdParallel1GeoKey)
+	if reflect.DeepEqual(gk.Shorts, []uint16{7, 8}) != true {
+		t.Fatalf("Values not correct: %v", gk.Shorts)
+	}
+}
+
+func TestParseKeyDirectory_NotValid(t *testing.T) {
+	_, err := ParseKeyDirectory([]uint16{1, 1, 0}, nil, "")
+	if err != ErrKeyDirectoryNotValid {
+		t.Fatalf("Expected ErrKeyDirectoryNotValid for short header: %v", err)
+	}
+
+	_, err = ParseKeyDirectory(testKeyDirectory[:12], testDoubleParams, testAsciiParams)
+	if err != ErrKeyDirectoryNotValid {
+		t.Fatalf("Expected ErrKeyDirectoryNotValid for truncated keys: %v", err)
+	}
+
+	_, err = ParseKeyDirectory(testKeyDirectory, nil, testAsciiParams)
+	if err != ErrKeyDirectoryNotValid {
+		t.Fatalf("Expected ErrKeyDirectoryNotValid for missing doubles: %v", err)
+	}
+}
+
+func TestKeyDirectory_EpsgCode_UserDefined(t *testing.T) {
+	directory := []uint16{
+		1, 1, 0, 2,
+		2048, 0, 1, 4326,
+		3072, 0, 1, UserDefined,
+	}
+
+	kd, err := ParseKeyDirectory(directory, nil, "")
+	log.PanicIf(err)
+
+	code, found := kd.EpsgCode()
+	if found != true || code != 4326 {
+		t.Fatalf("EPSG code not correct: (%d)", code)
+	}
+}
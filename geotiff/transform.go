@@ -0,0 +1,106 @@
+package exifgeotiff
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/s2"
+)
+
+// Transform is the affine transform from raster space (column, row) to model
+// space (x, y):
+//
+//	x = XPerColumn*column + XPerRow*row + XOffset
+//	y = YPerColumn*column + YPerRow*row + YOffset
+type Transform struct {
+	XPerColumn float64
+	XPerRow    float64
+	XOffset    float64
+
+	YPerColumn float64
+	YPerRow    float64
+	YOffset    float64
+}
+
+// newTransformFromTiepoint returns the transform for a scale and a single tie-
+// point. Model Y increases as rows go down the image, so it is flipped.
+func newTransformFromTiepoint(scaleX, scaleY float64, tp Tiepoint) Transform {
+	return Transform{
+		XPerColumn: scaleX,
+		XOffset:    tp.X - tp.I*scaleX,
+
+		YPerRow: -scaleY,
+		YOffset: tp.Y + tp.J*scaleY,
+	}
+}
+
+// newTransformFromMatrix returns the transform for the 4x4
+// ModelTransformation matrix (row-major). Z is ignored.
+func newTransformFromMatrix(matrix []float64) Transform {
+	return Transform{
+		XPerColumn: matrix[0],
+		XPerRow:    matrix[1],
+		XOffset:    matrix[3],
+
+		YPerColumn: matrix[4],
+		YPerRow:    matrix[5],
+		YOffset:    matrix[7],
+	}
+}
+
+// Apply returns the model coordinates of the raster coordinates.
+func (t Transform) Apply(column, row float64) Point {
+	return Point{
+		X: t.XPerColumn*column + t.XPerRow*row + t.XOffset,
+		Y: t.YPerColumn*column + t.YPerRow*row + t.YOffset,
+	}
+}
+
+// String returns a descriptive string.
+func (t Transform) String() string {
+	return fmt.Sprintf("Transform<X=[%v %v %v] Y=[%v %v %v]>", t.XPerColumn, t.XPerRow, t.XOffset, t.YPerColumn, t.YPerRow, t.YOffset)
+}
+
+// Point is a location in model space. For a geographic model, X is the
+// longitude and Y is the latitude, in degrees.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// LatLng returns the point as a latitude and longitude. This only makes sense
+// for a geographic model.
+func (p Point) LatLng() s2.LatLng {
+	return s2.LatLngFromDegrees(p.Y, p.X)
+}
+
+// String returns a descriptive string.
+func (p Point) String() string {
+	return fmt.Sprintf("(%v, %v)", p.X, p.Y)
+}
+
+// Corners are the model coordinates of the outer edges and center of the
+// image.
+type Corners struct {
+	UpperLeft  Point
+	UpperRight Point
+	LowerRight Point
+	LowerLeft  Point
+	Center     Point
+}
+
+// Rect returns the smallest latitude/longitude rectangle that contains the
+// corners. This only makes sense for a geographic model.
+func (c Corners) Rect() s2.Rect {
+	rect := s2.RectFromLatLng(c.UpperLeft.LatLng())
+
+	for _, p := range []Point{c.UpperRight, c.LowerRight, c.LowerLeft} {
+		rect = rect.AddPoint(p.LatLng())
+	}
+
+	return rect
+}
+
+// String returns a descriptive string.
+func (c Corners) String() string {
+	return fmt.Sprintf("Corners<UL=%s UR=%s LR=%s LL=%s CENTER=%s>", c.UpperLeft, c.UpperRight, c.LowerRight, c.LowerLeft, c.Center)
+}
@@ -0,0 +1,56 @@
+package exifgeotiff
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransform_Apply(t *testing.T) {
+	tr := newTransformFromTiepoint(0.5, 2, Tiepoint{I: 10, J: 20, X: 1000, Y: 5000})
+
+	p := tr.Apply(10, 20)
+	if p.X != 1000 || p.Y != 5000 {
+		t.Fatalf("Tie-point not mapped correctly: %s", p)
+	}
+
+	p = tr.Apply(12, 21)
+	if p.X != 1001 || p.Y != 4998 {
+		t.Fatalf("Point not mapped correctly: %s", p)
+	}
+}
+
+func TestTransform_FromMatrix(t *testing.T) {
+	matrix := []float64{
+		2, 1, 0, 100,
+		0, -3, 0, 200,
+		0, 0, 0, 0,
+		0, 0, 0, 1,
+	}
+
+	tr := newTransformFromMatrix(matrix)
+
+	p := tr.Apply(1, 1)
+	if p.X != 103 || p.Y != 197 {
+		t.Fatalf("Point not mapped correctly: %s", p)
+	}
+}
+
+func TestCorners_Rect(t *testing.T) {
+	c := Corners{
+		UpperLeft:  Point{X: 10, Y: 50},
+		UpperRight: Point{X: 11, Y: 50},
+		LowerRight: Point{X: 11, Y: 49},
+		LowerLeft:  Point{X: 10, Y: 49},
+	}
+
+	rect := c.Rect()
+
+	lo := rect.Lo()
+	hi := rect.Hi()
+
+	if math.Abs(lo.Lat.Degrees()-49) > 1e-9 || math.Abs(lo.Lng.Degrees()-10) > 1e-9 {
+		t.Fatalf("Lower bound not correct: %s", lo)
+	} else if math.Abs(hi.Lat.Degrees()-50) > 1e-9 || math.Abs(hi.Lng.Degrees()-11) > 1e-9 {
+		t.Fatalf("Upper bound not correct: %s", hi)
+	}
+}
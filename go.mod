@@ -1,9 +1,17 @@
 module github.com/imclaren/go-exif
 
-go 1.16
+go 1.17
 
 require (
 	github.com/dsoprea/go-logging v0.0.0-20200710184922-b02d349568dd
 	github.com/golang/geo v0.0.0-20210211234256-740aa86cb551
+	golang.org/x/text v0.13.0
 	gopkg.in/yaml.v2 v2.4.0
 )
+
+require golang.org/x/net v0.0.0-20200501053045-e0ff5e5a1de5 // indirect
+
+// go-logging wraps errors with go-errors. Since v1.1.0 these have an
+// `Unwrap`, which `errors.Is` and `errors.As` need in order to find
+// `ParseLimitError` and the typed parse errors under `log.Wrap`.
+require github.com/go-errors/errors v1.4.2 // indirect
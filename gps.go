@@ -3,6 +3,7 @@ package exif
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/dsoprea/go-logging"
@@ -42,7 +43,7 @@ type GpsDegrees struct {
 func NewGpsDegreesFromRationals(refValue string, rawCoordinate []exifcommon.Rational) (gd GpsDegrees, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -60,6 +61,34 @@ func NewGpsDegreesFromRationals(refValue string, rawCoordinate []exifcommon.Rati
 	return gd, nil
 }
 
+// NewGpsDegreesFromDecimal returns a GpsDegrees struct for the given decimal
+// coordinate. Negative values are south (for latitudes) or west (for
+// longitudes).
+func NewGpsDegreesFromDecimal(decimal float64, isLatitude bool) (gd GpsDegrees) {
+	if isLatitude == true {
+		gd.Orientation = 'N'
+		if decimal < 0 {
+			gd.Orientation = 'S'
+		}
+	} else {
+		gd.Orientation = 'E'
+		if decimal < 0 {
+			gd.Orientation = 'W'
+		}
+	}
+
+	decimal = math.Abs(decimal)
+
+	gd.Degrees = math.Floor(decimal)
+
+	minutes := (decimal - gd.Degrees) * 60.0
+	gd.Minutes = math.Floor(minutes)
+
+	gd.Seconds = (minutes - gd.Minutes) * 60.0
+
+	return gd
+}
+
 // String provides returns a descriptive string.
 func (d GpsDegrees) String() string {
 	return fmt.Sprintf("Degrees<O=[%s] D=(%g) M=(%g) S=(%g)>", string([]byte{d.Orientation}), d.Degrees, d.Minutes, d.Seconds)
@@ -59,3 +59,26 @@ func TestGpsDegrees_Raw(t *testing.T) {
 		t.Fatalf("GpsInfo not correctly encoded down to raw: %v\n", actual)
 	}
 }
+
+func TestNewGpsDegreesFromDecimal(t *testing.T) {
+	gd := NewGpsDegreesFromDecimal(-33.8675, true)
+
+	if gd.Orientation != 'S' {
+		t.Fatalf("Orientation was not set correctly: [%s]", string([]byte{gd.Orientation}))
+	} else if gd.Degrees != 33.0 {
+		t.Fatalf("Degrees is not correct: (%.2f)", gd.Degrees)
+	} else if gd.Minutes != 52.0 {
+		t.Fatalf("Minutes is not correct: (%.2f)", gd.Minutes)
+	} else if math.Abs(gd.Seconds-3.0) > 0.0001 {
+		t.Fatalf("Seconds is not correct: (%.4f)", gd.Seconds)
+	}
+
+	if math.Abs(gd.Decimal()-(-33.8675)) > 0.000001 {
+		t.Fatalf("Decimal not correct: (%.6f)", gd.Decimal())
+	}
+
+	gd = NewGpsDegreesFromDecimal(151.2, false)
+	if gd.Orientation != 'E' {
+		t.Fatalf("Orientation was not set correctly: [%s]", string([]byte{gd.Orientation}))
+	}
+}
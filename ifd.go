@@ -29,7 +29,7 @@ func NewIfdMappingWithStandard() (ifdMapping *exifcommon.IfdMapping) {
 func LoadStandardIfds(im *exifcommon.IfdMapping) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -30,6 +30,11 @@ var (
 type IfdBuilderTagValue struct {
 	valueBytes []byte
 	ib         *IfdBuilder
+
+	// undefinedValue is the structured value that an undefined-type value was
+	// encoded from, if any. It lets the value be re-encoded with a different
+	// codec registry.
+	undefinedValue exifundefined.EncodeableValue
 }
 
 func (ibtv IfdBuilderTagValue) String() string {
@@ -150,7 +155,7 @@ func (bt *BuilderTag) String() string {
 func (bt *BuilderTag) SetValue(byteOrder binary.ByteOrder, value interface{}) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -160,7 +165,7 @@ func (bt *BuilderTag) SetValue(byteOrder binary.ByteOrder, value interface{}) (e
 	if bt.typeId == exifcommon.TypeUndefined {
 		encodeable := value.(exifundefined.EncodeableValue)
 
-		encoded, unitCount, err := exifundefined.Encode(encodeable, byteOrder)
+		encoded, unitCount, err := exifundefined.DefaultRegistry().EncodeTag(bt.ifdPath, bt.tagId, encodeable, byteOrder)
 		log.PanicIf(err)
 
 		ed = exifcommon.EncodedData{
@@ -179,35 +184,61 @@ func (bt *BuilderTag) SetValue(byteOrder binary.ByteOrder, value interface{}) (e
 
 	bt.value = NewIfdBuilderTagValueFromBytes(ed.Encoded)
 
+	if bt.typeId == exifcommon.TypeUndefined {
+		bt.value.undefinedValue = value.(exifundefined.EncodeableValue)
+	}
+
 	return nil
 }
 
 // NewStandardBuilderTag constructs a `BuilderTag` instance. The type is looked
 // up. `ii` is the type of IFD that owns this tag.
 func NewStandardBuilderTag(ifdPath string, it *IndexedTag, byteOrder binary.ByteOrder, value interface{}) *BuilderTag {
+	return newStandardBuilderTagWithCodecs(ifdPath, it, byteOrder, value, exifundefined.DefaultRegistry())
+}
+
+// newStandardBuilderTagWithCodecs is `NewStandardBuilderTag` but encodes
+// undefined-type values with the given registry.
+func newStandardBuilderTagWithCodecs(ifdPath string, it *IndexedTag, byteOrder binary.ByteOrder, value interface{}, codecs *exifundefined.Registry) *BuilderTag {
+	if sv, ok := value.(structuredValue); ok == true {
+		value = sv.primitiveValue()
+	}
+
 	// If there is more than one supported type, we'll go with the larger to
 	// encode with. It'll use the same amount of fixed-space, and we'll
 	// eliminate unnecessary overflows/issues.
 	tagType := it.GetEncodingType(value)
 
 	var rawBytes []byte
+	var encodeable exifundefined.EncodeableValue
 	if it.DoesSupportType(exifcommon.TypeUndefined) == true {
-		encodeable := value.(exifundefined.EncodeableValue)
+		encodeable = value.(exifundefined.EncodeableValue)
 
 		var err error
 
-		rawBytes, _, err = exifundefined.Encode(encodeable, byteOrder)
+		rawBytes, _, err = codecs.EncodeTag(ifdPath, it.Id, encodeable, byteOrder)
 		log.PanicIf(err)
 	} else {
 		ve := exifcommon.NewValueEncoder(byteOrder)
 
-		ed, err := ve.Encode(value)
+		var ed exifcommon.EncodedData
+		var err error
+
+		if tagType == exifcommon.TypeUtf8 {
+			ed, err = ve.EncodeUtf8(value.(string))
+		} else if s, ok := value.(string); ok == true && isXpTag(ifdPath, it.Id) == true {
+			ed.Encoded = encodeXpString(s)
+		} else {
+			ed, err = ve.Encode(value)
+		}
+
 		log.PanicIf(err)
 
 		rawBytes = ed.Encoded
 	}
 
 	tagValue := NewIfdBuilderTagValueFromBytes(rawBytes)
+	tagValue.undefinedValue = encodeable
 
 	return NewBuilderTag(
 		ifdPath,
@@ -240,6 +271,10 @@ type IfdBuilder struct {
 
 	ifdMapping *exifcommon.IfdMapping
 	tagIndex   *TagIndex
+
+	// codecs encodes undefined-type values. If nil, the default registry is
+	// used.
+	codecs *exifundefined.Registry
 }
 
 func NewIfdBuilder(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exifcommon.IfdIdentity, byteOrder binary.ByteOrder) (ib *IfdBuilder) {
@@ -266,14 +301,31 @@ func NewIfdBuilderWithExistingIfd(ifd *Ifd) (ib *IfdBuilder) {
 		existingOffset: ifd.Offset,
 		ifdMapping:     ifd.ifdMapping,
 		tagIndex:       ifd.tagIndex,
+		codecs:         ifd.codecs,
 	}
 
 	return ib
 }
 
 // NewIfdBuilderFromExistingChain creates a chain of IB instances from an
-// IFD chain generated from real data.
+// IFD chain generated from real data. It panics if a value can not be read.
+// See `NewIfdBuilderFromExistingChainWithError`.
 func NewIfdBuilderFromExistingChain(rootIfd *Ifd) (firstIb *IfdBuilder) {
+	firstIb, err := NewIfdBuilderFromExistingChainWithError(rootIfd)
+	log.PanicIf(err)
+
+	return firstIb
+}
+
+// NewIfdBuilderFromExistingChainWithError is `NewIfdBuilderFromExistingChain`
+// but returns an error if a value can not be read (e.g. it is truncated).
+func NewIfdBuilderFromExistingChainWithError(rootIfd *Ifd) (firstIb *IfdBuilder, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
 	var lastIb *IfdBuilder
 	i := 0
 	for thisExistingIfd := rootIfd; thisExistingIfd != nil; thisExistingIfd = thisExistingIfd.NextIfd {
@@ -283,6 +335,8 @@ func NewIfdBuilderFromExistingChain(rootIfd *Ifd) (firstIb *IfdBuilder) {
 			rootIfd.ifdIdentity,
 			thisExistingIfd.ByteOrder)
 
+		newIb.codecs = thisExistingIfd.codecs
+
 		if firstIb == nil {
 			firstIb = newIb
 		} else {
@@ -296,7 +350,7 @@ func NewIfdBuilderFromExistingChain(rootIfd *Ifd) (firstIb *IfdBuilder) {
 		i++
 	}
 
-	return firstIb
+	return firstIb, nil
 }
 
 func (ib *IfdBuilder) IfdIdentity() *exifcommon.IfdIdentity {
@@ -310,7 +364,7 @@ func (ib *IfdBuilder) NextIb() (nextIb *IfdBuilder, err error) {
 func (ib *IfdBuilder) ChildWithTagId(childIfdTagId uint16) (childIb *IfdBuilder, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -335,7 +389,7 @@ func (ib *IfdBuilder) ChildWithTagId(childIfdTagId uint16) (childIb *IfdBuilder,
 func getOrCreateIbFromRootIbInner(rootIb *IfdBuilder, parentIb *IfdBuilder, currentLineage []exifcommon.IfdTagIdAndIndex) (ib *IfdBuilder, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -381,6 +435,7 @@ func getOrCreateIbFromRootIbInner(rootIb *IfdBuilder, parentIb *IfdBuilder, curr
 
 			iiSibling := thisIb.IfdIdentity().NewSibling(i + 1)
 			thisIb.nextIb = NewIfdBuilder(thisIb.ifdMapping, thisIb.tagIndex, iiSibling, thisIb.byteOrder)
+			thisIb.nextIb.codecs = thisIb.codecs
 		}
 
 		thisIb = thisIb.nextIb
@@ -429,6 +484,8 @@ func getOrCreateIbFromRootIbInner(rootIb *IfdBuilder, parentIb *IfdBuilder, curr
 				iiChild,
 				thisIb.byteOrder)
 
+		foundChild.codecs = thisIb.codecs
+
 		err = thisIb.AddChildIb(foundChild)
 		log.PanicIf(err)
 	}
@@ -445,7 +502,7 @@ func getOrCreateIbFromRootIbInner(rootIb *IfdBuilder, parentIb *IfdBuilder, curr
 func GetOrCreateIbFromRootIb(rootIb *IfdBuilder, fqIfdPath string) (ib *IfdBuilder, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -460,6 +517,45 @@ func GetOrCreateIbFromRootIb(rootIb *IfdBuilder, fqIfdPath string) (ib *IfdBuild
 	return ib, nil
 }
 
+// FindIbFromRootIb returns the IB representing the requested IFD. Unlike
+// `GetOrCreateIbFromRootIb`, it will return `ErrChildIbNotFound` rather than
+// creating any IBs that do not already exist.
+func FindIbFromRootIb(rootIb *IfdBuilder, fqIfdPath string) (ib *IfdBuilder, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	lineage, err := rootIb.ifdMapping.ResolvePath(fqIfdPath)
+	log.PanicIf(err)
+
+	if lineage[0].TagId != rootIb.IfdIdentity().TagId() {
+		log.Panicf("the FQ IFD-path [%s] does not start with the root IB [%s]", fqIfdPath, rootIb.IfdIdentity().UnindexedString())
+	}
+
+	ib = rootIb
+
+	for i, itii := range lineage {
+		if i > 0 {
+			ib, err = ib.ChildWithTagId(itii.TagId)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for j := 0; j < itii.Index; j++ {
+			if ib.nextIb == nil {
+				return nil, ErrChildIbNotFound
+			}
+
+			ib = ib.nextIb
+		}
+	}
+
+	return ib, nil
+}
+
 func (ib *IfdBuilder) String() string {
 	nextIfdPhrase := ""
 	if ib.nextIb != nil {
@@ -488,7 +584,7 @@ func (ib *IfdBuilder) Tags() (tags []*BuilderTag) {
 func (ib *IfdBuilder) SetThumbnail(data []byte) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -531,6 +627,32 @@ func (ib *IfdBuilder) Thumbnail() []byte {
 	return ib.thumbnailData
 }
 
+// SetCodecRegistry replaces the registry (`exifundefined.DefaultRegistry()`,
+// by default) that undefined-type values are encoded with. This applies to
+// the siblings and the children of this IB, too, as well as to any that are
+// created from it later.
+func (ib *IfdBuilder) SetCodecRegistry(codecs *exifundefined.Registry) {
+	for thisIb := ib; thisIb != nil; thisIb = thisIb.nextIb {
+		thisIb.codecs = codecs
+
+		for _, bt := range thisIb.tags {
+			if bt.value.IsIb() == true {
+				bt.value.Ib().SetCodecRegistry(codecs)
+			}
+		}
+	}
+}
+
+// undefinedCodecs returns the registry that undefined-type values are encoded
+// with.
+func (ib *IfdBuilder) undefinedCodecs() *exifundefined.Registry {
+	if ib.codecs == nil {
+		return exifundefined.DefaultRegistry()
+	}
+
+	return ib.codecs
+}
+
 func (ib *IfdBuilder) printTagTree(levels int) {
 	indent := strings.Repeat(" ", levels*2)
 
@@ -697,7 +819,7 @@ func (ib *IfdBuilder) DumpToStrings() (lines []string) {
 func (ib *IfdBuilder) SetNextIb(nextIb *IfdBuilder) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -709,7 +831,7 @@ func (ib *IfdBuilder) SetNextIb(nextIb *IfdBuilder) (err error) {
 func (ib *IfdBuilder) DeleteN(tagId uint16, n int) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -740,7 +862,7 @@ func (ib *IfdBuilder) DeleteN(tagId uint16, n int) (err error) {
 func (ib *IfdBuilder) DeleteFirst(tagId uint16) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -753,7 +875,7 @@ func (ib *IfdBuilder) DeleteFirst(tagId uint16) (err error) {
 func (ib *IfdBuilder) DeleteAll(tagId uint16) (n int, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -774,7 +896,7 @@ func (ib *IfdBuilder) DeleteAll(tagId uint16) (n int, err error) {
 func (ib *IfdBuilder) ReplaceAt(position int, bt *BuilderTag) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -792,7 +914,7 @@ func (ib *IfdBuilder) ReplaceAt(position int, bt *BuilderTag) (err error) {
 func (ib *IfdBuilder) Replace(tagId uint16, bt *BuilderTag) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -808,7 +930,7 @@ func (ib *IfdBuilder) Replace(tagId uint16, bt *BuilderTag) (err error) {
 func (ib *IfdBuilder) Set(bt *BuilderTag) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -828,7 +950,7 @@ func (ib *IfdBuilder) Set(bt *BuilderTag) (err error) {
 func (ib *IfdBuilder) FindN(tagId uint16, maxFound int) (found []int, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -849,7 +971,7 @@ func (ib *IfdBuilder) FindN(tagId uint16, maxFound int) (found []int, err error)
 func (ib *IfdBuilder) Find(tagId uint16) (position int, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -866,7 +988,7 @@ func (ib *IfdBuilder) Find(tagId uint16) (position int, err error) {
 func (ib *IfdBuilder) FindTag(tagId uint16) (bt *BuilderTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -885,7 +1007,7 @@ func (ib *IfdBuilder) FindTag(tagId uint16) (bt *BuilderTag, err error) {
 func (ib *IfdBuilder) FindTagWithName(tagName string) (bt *BuilderTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -907,7 +1029,7 @@ func (ib *IfdBuilder) FindTagWithName(tagName string) (bt *BuilderTag, err error
 func (ib *IfdBuilder) add(bt *BuilderTag) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -926,7 +1048,7 @@ func (ib *IfdBuilder) add(bt *BuilderTag) (err error) {
 func (ib *IfdBuilder) Add(bt *BuilderTag) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -944,7 +1066,7 @@ func (ib *IfdBuilder) Add(bt *BuilderTag) (err error) {
 func (ib *IfdBuilder) AddChildIb(childIb *IfdBuilder) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -972,7 +1094,7 @@ func (ib *IfdBuilder) AddChildIb(childIb *IfdBuilder) (err error) {
 func (ib *IfdBuilder) NewBuilderTagFromBuilder(childIb *IfdBuilder) (bt *BuilderTag) {
 	defer func() {
 		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
+			err := log.Wrap(state)
 			log.Panic(err)
 		}
 	}()
@@ -993,7 +1115,7 @@ func (ib *IfdBuilder) NewBuilderTagFromBuilder(childIb *IfdBuilder) (bt *Builder
 func (ib *IfdBuilder) AddTagsFromExisting(ifd *Ifd, includeTagIds []uint16, excludeTagIds []uint16) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1071,7 +1193,9 @@ func (ib *IfdBuilder) AddTagsFromExisting(ifd *Ifd, includeTagIds []uint16, excl
 				log.Panicf("could not find child IFD for child ITE: IFD-PATH=[%s] TAG-ID=(0x%04x) CURRENT-TAG-POSITION=(%d) CHILDREN=%v", ite.IfdPath(), ite.TagId(), i, childTagIds)
 			}
 
-			childIb := NewIfdBuilderFromExistingChain(childIfd)
+			childIb, err := NewIfdBuilderFromExistingChainWithError(childIfd)
+			log.PanicIf(err)
+
 			bt = ib.NewBuilderTagFromBuilder(childIb)
 		} else {
 			// Non-IFD tag.
@@ -1101,14 +1225,14 @@ func (ib *IfdBuilder) AddTagsFromExisting(ifd *Ifd, includeTagIds []uint16, excl
 func (ib *IfdBuilder) AddStandard(tagId uint16, value interface{}) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	it, err := ib.tagIndex.Get(ib.IfdIdentity(), tagId)
 	log.PanicIf(err)
 
-	bt := NewStandardBuilderTag(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value)
+	bt := newStandardBuilderTagWithCodecs(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value, ib.undefinedCodecs())
 
 	err = ib.add(bt)
 	log.PanicIf(err)
@@ -1122,14 +1246,14 @@ func (ib *IfdBuilder) AddStandard(tagId uint16, value interface{}) (err error) {
 func (ib *IfdBuilder) AddStandardWithName(tagName string, value interface{}) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	it, err := ib.tagIndex.GetWithName(ib.IfdIdentity(), tagName)
 	log.PanicIf(err)
 
-	bt := NewStandardBuilderTag(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value)
+	bt := newStandardBuilderTagWithCodecs(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value, ib.undefinedCodecs())
 
 	err = ib.add(bt)
 	log.PanicIf(err)
@@ -1142,7 +1266,7 @@ func (ib *IfdBuilder) AddStandardWithName(tagName string, value interface{}) (er
 func (ib *IfdBuilder) SetStandard(tagId uint16, value interface{}) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1151,7 +1275,7 @@ func (ib *IfdBuilder) SetStandard(tagId uint16, value interface{}) (err error) {
 	it, err := ib.tagIndex.Get(ib.IfdIdentity(), tagId)
 	log.PanicIf(err)
 
-	bt := NewStandardBuilderTag(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value)
+	bt := newStandardBuilderTagWithCodecs(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value, ib.undefinedCodecs())
 
 	i, err := ib.Find(tagId)
 	if err != nil {
@@ -1173,7 +1297,7 @@ func (ib *IfdBuilder) SetStandard(tagId uint16, value interface{}) (err error) {
 func (ib *IfdBuilder) SetStandardWithName(tagName string, value interface{}) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1182,7 +1306,7 @@ func (ib *IfdBuilder) SetStandardWithName(tagName string, value interface{}) (er
 	it, err := ib.tagIndex.GetWithName(ib.IfdIdentity(), tagName)
 	log.PanicIf(err)
 
-	bt := NewStandardBuilderTag(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value)
+	bt := newStandardBuilderTagWithCodecs(ib.IfdIdentity().UnindexedString(), it, ib.byteOrder, value, ib.undefinedCodecs())
 
 	i, err := ib.Find(bt.tagId)
 	if err != nil {
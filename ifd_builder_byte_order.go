@@ -0,0 +1,135 @@
+package exif
+
+import (
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+// ByteOrder returns the byte-order that the IB will be encoded with.
+func (ib *IfdBuilder) ByteOrder() binary.ByteOrder {
+	return ib.byteOrder
+}
+
+// SetByteOrder converts this IB, its children, and its siblings to the given
+// byte-order. Every value is decoded with the current byte-order and
+// re-encoded with the new one. Undefined-type values go through their
+// registered codecs; ones that have no codec (or that can't be parsed) are
+// opaque and are kept as-is.
+func (ib *IfdBuilder) SetByteOrder(byteOrder binary.ByteOrder) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	for thisIb := ib; thisIb != nil; thisIb = thisIb.nextIb {
+		if thisIb.byteOrder == byteOrder {
+			continue
+		}
+
+		for _, bt := range thisIb.tags {
+			if bt.value.IsIb() == true {
+				err := bt.value.Ib().SetByteOrder(byteOrder)
+				log.PanicIf(err)
+
+				continue
+			}
+
+			err := bt.convertByteOrder(thisIb.byteOrder, byteOrder, thisIb.undefinedCodecs())
+			log.PanicIf(err)
+		}
+
+		thisIb.byteOrder = byteOrder
+	}
+
+	return nil
+}
+
+// convertByteOrder re-encodes the value of the tag with a different
+// byte-order.
+func (bt *BuilderTag) convertByteOrder(from, to binary.ByteOrder, codecs *exifundefined.Registry) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	bt.byteOrder = to
+
+	// Single-byte types don't change and the thumbnail "value" is the raw
+	// image data.
+	if _, found := tagsWithoutAlignment[bt.tagId]; found == true {
+		return nil
+	} else if bt.typeId == exifcommon.TypeByte || bt.typeId == exifcommon.TypeAscii || bt.typeId == exifcommon.TypeAsciiNoNul || bt.typeId == exifcommon.TypeUtf8 {
+		return nil
+	}
+
+	valueBytes := bt.value.Bytes()
+
+	if bt.typeId == exifcommon.TypeUndefined {
+		encoded, err := convertUndefinedByteOrder(codecs, bt.ifdPath, bt.tagId, valueBytes, from, to)
+		log.PanicIf(err)
+
+		undefinedValue := bt.value.undefinedValue
+
+		bt.value = NewIfdBuilderTagValueFromBytes(encoded)
+		bt.value.undefinedValue = undefinedValue
+
+		return nil
+	}
+
+	value, err := decodeExifTagValueBytes(bt.ifdPath, bt.tagId, bt.typeId, valueBytes, from)
+	log.PanicIf(err)
+
+	ve := exifcommon.NewValueEncoder(to)
+
+	ed, err := ve.Encode(value)
+	log.PanicIf(err)
+
+	bt.value = NewIfdBuilderTagValueFromBytes(ed.Encoded)
+
+	return nil
+}
+
+// convertUndefinedByteOrder re-encodes an undefined-type value with a
+// different byte-order. The bytes are returned unchanged if the tag has no
+// codec or its value can't be parsed.
+func convertUndefinedByteOrder(codecs *exifundefined.Registry, ifdPath string, tagId uint16, valueBytes []byte, from, to binary.ByteOrder) (encoded []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	unitCount := uint32(len(valueBytes))
+
+	var vc *exifcommon.ValueContext
+
+	// Small values are embedded in the entry itself rather than addressed.
+	if len(valueBytes) <= 4 {
+		rawValueOffset := make([]byte, 4)
+		copy(rawValueOffset, valueBytes)
+
+		vc = exifcommon.NewValueContext(ifdPath, tagId, unitCount, 0, rawValueOffset, nil, exifcommon.TypeUndefined, from)
+	} else {
+		vc = exifcommon.NewValueContext(ifdPath, tagId, unitCount, 0, nil, valueBytes, exifcommon.TypeUndefined, from)
+	}
+
+	value, err := codecs.Decode(vc)
+	if err != nil {
+		if err == exifcommon.ErrUnhandledUndefinedTypedTag || err == exifundefined.ErrUnparseableValue {
+			return valueBytes, nil
+		}
+
+		log.Panic(err)
+	}
+
+	encoded, _, err = codecs.EncodeTag(ifdPath, tagId, value, to)
+	log.PanicIf(err)
+
+	return encoded, nil
+}
@@ -0,0 +1,117 @@
+package exif
+
+import (
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+func TestIfdBuilder_SetByteOrder(t *testing.T) {
+	exifData := getTestExifData()
+
+	index := getTestIndex(exifData)
+
+	rootIb := NewIfdBuilderFromExistingChain(index.RootIfd)
+
+	err := rootIb.SetByteOrder(binary.BigEndian)
+	log.PanicIf(err)
+
+	if rootIb.ByteOrder() != binary.BigEndian {
+		t.Fatalf("Byte-order not set.")
+	}
+
+	ibe := NewIfdByteEncoder()
+
+	convertedExifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	eh, err := ParseExifHeader(convertedExifData)
+	log.PanicIf(err)
+
+	if eh.ByteOrder != binary.BigEndian {
+		t.Fatalf("Encoded byte-order not correct: [%v]", eh.ByteOrder)
+	}
+
+	convertedIndex := getTestIndex(convertedExifData)
+
+	diff, err := DiffIfdIndexes(index, convertedIndex)
+	log.PanicIf(err)
+
+	if diff.HasDifferences() == true {
+		t.Fatalf("Converted values not correct:\n%s", diff)
+	}
+
+	// Convert it back. We should get the same values again.
+
+	err = rootIb.SetByteOrder(binary.LittleEndian)
+	log.PanicIf(err)
+
+	restoredExifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	diff, err = DiffIfdIndexes(index, getTestIndex(restoredExifData))
+	log.PanicIf(err)
+
+	if diff.HasDifferences() == true {
+		t.Fatalf("Restored values not correct:\n%s", diff)
+	}
+}
+
+func TestIfdByteEncoder_EncodeToExif_Conformant(t *testing.T) {
+	exifData := getTestExifData()
+
+	index := getTestIndex(exifData)
+
+	rootIb := NewIfdBuilderFromExistingChain(index.RootIfd)
+
+	err := rootIb.SetStandardWithName("Model", "odd")
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	updatedExifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	report, err := ValidateConformance(getTestIndex(updatedExifData))
+	log.PanicIf(err)
+
+	for _, d := range report.Findings {
+		if d.Code == DiagnosticValueMisaligned || d.Code == DiagnosticTagOrderNotValid {
+			t.Fatalf("Encoded data not sorted and aligned:\n%s", report)
+		}
+	}
+}
+
+func TestConvertUndefinedByteOrder_UnicodeUserComment(t *testing.T) {
+	codecs := exifundefined.DefaultRegistry()
+	ifdPath := exifcommon.IfdExifStandardIfdIdentity.UnindexedString()
+
+	uc := exifundefined.NewTag9286UserComment("東京タワー")
+
+	littleEndianBytes, _, err := codecs.Encode(uc, binary.LittleEndian)
+	log.PanicIf(err)
+
+	bigEndianBytes, err := convertUndefinedByteOrder(codecs, ifdPath, 0x9286, littleEndianBytes, binary.LittleEndian, binary.BigEndian)
+	log.PanicIf(err)
+
+	vc := exifcommon.NewValueContext(ifdPath, 0x9286, uint32(len(bigEndianBytes)), 0, nil, bigEndianBytes, exifcommon.TypeUndefined, binary.BigEndian)
+
+	value, err := codecs.Decode(vc)
+	log.PanicIf(err)
+
+	if converted := value.(exifundefined.Tag9286UserComment); converted.Text != uc.Text {
+		t.Fatalf("Text not correct after converting byte-order: [%s]", converted.Text)
+	}
+
+	restoredBytes, err := convertUndefinedByteOrder(codecs, ifdPath, 0x9286, bigEndianBytes, binary.BigEndian, binary.LittleEndian)
+	log.PanicIf(err)
+
+	if string(restoredBytes) != string(littleEndianBytes) {
+		t.Fatalf("Bytes not correct after converting back: %v", restoredBytes)
+	}
+}
@@ -0,0 +1,314 @@
+package exif
+
+import (
+	"io"
+	"sort"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+// ifdLayout is the precomputed position of one IFD in a chain, the values it
+// allocates, and the child IFDs that follow it.
+type ifdLayout struct {
+	ib *IfdBuilder
+
+	// offset is where the IFD table starts (relative to the start of the EXIF
+	// header).
+	offset uint32
+
+	// tags are the tags in the order that they will be written.
+	tags []*BuilderTag
+
+	// values are the encoded values of each tag, or nil if the tag points to a
+	// child IFD.
+	values [][]byte
+
+	// unitCounts are the unit-counts of each tag.
+	unitCounts []uint32
+
+	// valueOffsets are the offsets of the allocated value of each tag, or (0)
+	// if the value is embedded in the entry.
+	valueOffsets []uint32
+
+	// children are the layouts of the child-IFD chain of each tag, or nil if
+	// the tag doesn't point to a child IFD.
+	children []*ifdLayout
+
+	next *ifdLayout
+}
+
+// layoutIfdChain computes the offset of every IFD and value in the chain
+// starting with the given IB, which will be written at the given offset. Each
+// IFD table is followed by its allocated values, then by its child IFDs (in
+// tag order), and then by the next IFD in the chain. The returned offset is the
+// one following the whole chain. If `codecs` is not nil, it re-encodes the
+// structured undefined-type values.
+func layoutIfdChain(ib *IfdBuilder, offset uint32, codecs *exifundefined.Registry) (head *ifdLayout, end uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	var previous *ifdLayout
+
+	for thisIb := ib; thisIb != nil; thisIb = thisIb.nextIb {
+		tags := make([]*BuilderTag, len(thisIb.tags))
+		copy(tags, thisIb.tags)
+
+		sort.SliceStable(tags, func(i, j int) bool {
+			return tags[i].tagId < tags[j].tagId
+		})
+
+		il := &ifdLayout{
+			ib:           thisIb,
+			offset:       offset,
+			tags:         tags,
+			values:       make([][]byte, len(tags)),
+			unitCounts:   make([]uint32, len(tags)),
+			valueOffsets: make([]uint32, len(tags)),
+			children:     make([]*ifdLayout, len(tags)),
+		}
+
+		tableSize := uint32(2) + IfdTagEntrySize*uint32(len(tags)) + uint32(4)
+		offset += tableSize
+
+		for i, bt := range tags {
+			if bt.value.IsBytes() == false {
+				if bt.value.IsIb() == false {
+					log.Panicf("tag value is not a byte-slice but also not a child IB: %v", bt)
+				}
+
+				il.unitCounts[i] = 1
+
+				continue
+			}
+
+			valueBytes, err := builderTagValueBytes(bt, codecs)
+			log.PanicIf(err)
+
+			il.values[i] = valueBytes
+
+			unitCount, err := builderTagUnitCount(bt, valueBytes)
+			log.PanicIf(err)
+
+			il.unitCounts[i] = unitCount
+
+			len_ := uint32(len(valueBytes))
+			if len_ > 4 {
+				il.valueOffsets[i] = offset
+
+				// Keep every value on a word boundary.
+				offset += len_ + len_%2
+			}
+		}
+
+		for i, bt := range tags {
+			if bt.value.IsIb() == false {
+				continue
+			}
+
+			il.children[i], offset, err = layoutIfdChain(bt.value.Ib(), offset, codecs)
+			log.PanicIf(err)
+		}
+
+		if previous == nil {
+			head = il
+		} else {
+			previous.next = il
+		}
+
+		previous = il
+	}
+
+	return head, offset, nil
+}
+
+// builderTagValueBytes returns the encoded value of the tag. Undefined-type
+// values that were given as structured values are re-encoded if a registry is
+// given.
+func builderTagValueBytes(bt *BuilderTag, codecs *exifundefined.Registry) (valueBytes []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if codecs == nil || bt.typeId != exifcommon.TypeUndefined || bt.value.undefinedValue == nil {
+		return bt.value.Bytes(), nil
+	}
+
+	valueBytes, _, err = codecs.EncodeTag(bt.ifdPath, bt.tagId, bt.value.undefinedValue, bt.byteOrder)
+	log.PanicIf(err)
+
+	return valueBytes, nil
+}
+
+// builderTagUnitCount returns the count of units of the given type in the
+// encoded value of the tag.
+func builderTagUnitCount(bt *BuilderTag, valueBytes []byte) (unitCount uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	effectiveType := bt.typeId
+	if bt.typeId == exifcommon.TypeUndefined {
+		effectiveType = exifcommon.TypeByte
+	}
+
+	typeSize := uint32(effectiveType.Size())
+	len_ := uint32(len(valueBytes))
+
+	if _, found := tagsWithoutAlignment[bt.tagId]; found == false {
+		if remainder := len_ % typeSize; remainder > 0 {
+			log.Panicf("tag (0x%04x) value of (%d) bytes not evenly divisible by type-size (%d)", bt.tagId, len_, typeSize)
+		}
+	}
+
+	return len_ / typeSize, nil
+}
+
+// EncodedSize returns the size of the EXIF block (including the header) that
+// the IB would be encoded to. Nothing is encoded, so this is cheap enough to
+// check against the (64K) limit of a JPEG APP1 segment before encoding. Values
+// are sized as the IB encoded them, so this doesn't account for the registry of
+// an `IfdByteEncoder` (see `SetCodecRegistry`).
+func EncodedSize(ib *IfdBuilder) (size uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	_, size, err = layoutIfdChain(ib, ExifDefaultFirstIfdOffset, nil)
+	log.PanicIf(err)
+
+	return size, nil
+}
+
+// exifStreamWriter writes to an `io.Writer` while tracking the offset that has
+// been reached.
+type exifStreamWriter struct {
+	w         io.Writer
+	byteOrder binary.ByteOrder
+	offset    uint32
+	entry     [IfdTagEntrySize]byte
+}
+
+func (esw *exifStreamWriter) write(data []byte) {
+	n, err := esw.w.Write(data)
+	log.PanicIf(err)
+
+	esw.offset += uint32(n)
+}
+
+func (esw *exifStreamWriter) writeUint16(value uint16) {
+	esw.byteOrder.PutUint16(esw.entry[:2], value)
+	esw.write(esw.entry[:2])
+}
+
+func (esw *exifStreamWriter) writeUint32(value uint32) {
+	esw.byteOrder.PutUint32(esw.entry[:4], value)
+	esw.write(esw.entry[:4])
+}
+
+// writeIfdChain writes the chain described by the layout.
+func (ibe *IfdByteEncoder) writeIfdChain(esw *exifStreamWriter, head *ifdLayout) {
+	for il := head; il != nil; il = il.next {
+		ibe.pushToJournal("writeIfdChain", ">", "%s", il.ib)
+
+		if esw.offset != il.offset {
+			log.Panicf("IFD written at the wrong offset: (0x%08x) != (0x%08x) %s", esw.offset, il.offset, il.ib)
+		}
+
+		esw.writeUint16(uint16(len(il.tags)))
+
+		for i, bt := range il.tags {
+			entry := esw.entry[:]
+
+			esw.byteOrder.PutUint16(entry[0:2], bt.tagId)
+			esw.byteOrder.PutUint16(entry[2:4], uint16(bt.typeId))
+			esw.byteOrder.PutUint32(entry[4:8], il.unitCounts[i])
+
+			if child := il.children[i]; child != nil {
+				esw.byteOrder.PutUint32(entry[8:12], child.offset)
+			} else if valueOffset := il.valueOffsets[i]; valueOffset != 0 {
+				esw.byteOrder.PutUint32(entry[8:12], valueOffset)
+			} else {
+				n := copy(entry[8:12], il.values[i])
+				for ; n < 4; n++ {
+					entry[8+n] = 0
+				}
+			}
+
+			esw.write(entry)
+		}
+
+		if il.next != nil {
+			esw.writeUint32(il.next.offset)
+		} else {
+			esw.writeUint32(0)
+		}
+
+		for i := range il.tags {
+			valueOffset := il.valueOffsets[i]
+			if valueOffset == 0 {
+				continue
+			}
+
+			valueBytes := il.values[i]
+			esw.write(valueBytes)
+
+			if len(valueBytes)%2 != 0 {
+				esw.write([]byte{0})
+			}
+		}
+
+		for _, child := range il.children {
+			if child != nil {
+				ibe.writeIfdChain(esw, child)
+			}
+		}
+
+		ibe.pushToJournal("writeIfdChain", "<", "%s", il.ib)
+	}
+}
+
+// EncodeToWriter writes the complete EXIF block for the IB to the writer. The
+// layout is computed up front so the data is written in a single pass without
+// being buffered. Returns the number of bytes written.
+func (ibe *IfdByteEncoder) EncodeToWriter(ib *IfdBuilder, w io.Writer) (size uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	head, size, err := layoutIfdChain(ib, ExifDefaultFirstIfdOffset, ibe.codecs)
+	log.PanicIf(err)
+
+	headerBytes, err := BuildExifHeader(ib.byteOrder, ExifDefaultFirstIfdOffset)
+	log.PanicIf(err)
+
+	esw := &exifStreamWriter{
+		w:         w,
+		byteOrder: ib.byteOrder,
+	}
+
+	esw.write(headerBytes)
+
+	ibe.writeIfdChain(esw, head)
+
+	if esw.offset != size {
+		log.Panicf("written size does not match the pre-calculated size: (%d) != (%d)", esw.offset, size)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,162 @@
+package exif
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+var (
+	errStreamTestWriter = errors.New("stream test writer failed")
+)
+
+// streamTestFailingWriter fails once more than `limit` bytes are written.
+type streamTestFailingWriter struct {
+	limit   int
+	written int
+}
+
+func (w *streamTestFailingWriter) Write(data []byte) (n int, err error) {
+	if w.written+len(data) > w.limit {
+		return 0, errStreamTestWriter
+	}
+
+	w.written += len(data)
+
+	return len(data), nil
+}
+
+func TestIfdByteEncoder_EncodeToWriter_Simple(t *testing.T) {
+	ib := getExifSimpleTestIb()
+
+	size, err := EncodedSize(ib)
+	log.PanicIf(err)
+
+	b := new(bytes.Buffer)
+
+	ibe := NewIfdByteEncoder()
+
+	written, err := ibe.EncodeToWriter(ib, b)
+	log.PanicIf(err)
+
+	if written != size || uint32(b.Len()) != size {
+		t.Fatalf("Size not correct: (%d) (%d) != (%d)", written, b.Len(), size)
+	} else if bytes.Equal(b.Bytes()[ExifDefaultFirstIfdOffset:], exifSimpleTestIbPayload) == false {
+		t.Fatalf("Streamed data not correct: %v", exifcommon.DumpBytesClauseToString(b.Bytes()))
+	}
+
+	validateExifSimpleTestIb(b.Bytes(), t)
+}
+
+func TestIfdByteEncoder_EncodeToWriter_RealData(t *testing.T) {
+	exifData := getTestExifData()
+
+	index := getTestIndex(exifData)
+
+	rootIb := NewIfdBuilderFromExistingChain(index.RootIfd)
+
+	size, err := EncodedSize(rootIb)
+	log.PanicIf(err)
+
+	b := new(bytes.Buffer)
+
+	ibe := NewIfdByteEncoder()
+
+	_, err = ibe.EncodeToWriter(rootIb, b)
+	log.PanicIf(err)
+
+	if uint32(b.Len()) != size {
+		t.Fatalf("Size not correct: (%d) != (%d)", b.Len(), size)
+	}
+
+	diff, err := DiffIfdIndexes(index, getTestIndex(b.Bytes()))
+	log.PanicIf(err)
+
+	if diff.HasDifferences() == true {
+		t.Fatalf("Streamed data not correct:\n%s", diff)
+	}
+}
+
+func TestIfdByteEncoder_EncodeToWriter_WriterError(t *testing.T) {
+	ib := getExifSimpleTestIb()
+
+	w := &streamTestFailingWriter{limit: 20}
+
+	ibe := NewIfdByteEncoder()
+
+	_, err := ibe.EncodeToWriter(ib, w)
+	if err == nil {
+		t.Fatalf("Expected error.")
+	} else if log.Is(err, errStreamTestWriter) == false {
+		t.Fatalf("Error not correct: %v", err)
+	}
+}
+
+// streamTestVersionEncoder writes an ExifVersion with its digits reversed.
+type streamTestVersionEncoder struct {
+}
+
+func (streamTestVersionEncoder) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	s := value.(exifundefined.Tag9000ExifVersion).ExifVersion
+
+	encoded = make([]byte, len(s))
+	for i := range s {
+		encoded[len(s)-1-i] = s[i]
+	}
+
+	return encoded, uint32(len(encoded)), nil
+}
+
+func TestIfdByteEncoder_SetCodecRegistry(t *testing.T) {
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	childIb := NewIfdBuilder(im, ti, exifcommon.IfdExifStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	err := childIb.AddStandardWithName("ExifVersion", exifundefined.Tag9000ExifVersion{ExifVersion: "0230"})
+	log.PanicIf(err)
+
+	err = ib.AddChildIb(childIb)
+	log.PanicIf(err)
+
+	codecs := exifundefined.DefaultRegistry().Clone()
+
+	err = codecs.RegisterTagEncoder(exifcommon.IfdExifStandardIfdIdentity.UnindexedString(), 0x9000, streamTestVersionEncoder{})
+	log.PanicIf(err)
+
+	getExifVersion := func(ibe *IfdByteEncoder) interface{} {
+		exifData, err := ibe.EncodeToExif(ib)
+		log.PanicIf(err)
+
+		index := getTestIndex(exifData)
+
+		return getTestTagValue(t, index, exifcommon.IfdExifStandardIfdIdentity.String(), 0x9000)
+	}
+
+	// Without a registry, the value is written as the IB encoded it.
+
+	value := getExifVersion(NewIfdByteEncoder())
+
+	if reflect.DeepEqual(value, exifundefined.Tag9000ExifVersion{ExifVersion: "0230"}) == false {
+		t.Fatalf("Value not correct without registry: %v", value)
+	}
+
+	ibe := NewIfdByteEncoder()
+	ibe.SetCodecRegistry(codecs)
+
+	value = getExifVersion(ibe)
+
+	if reflect.DeepEqual(value, exifundefined.Tag9000ExifVersion{ExifVersion: "0320"}) == false {
+		t.Fatalf("Value not correct with registry: %v", value)
+	}
+}
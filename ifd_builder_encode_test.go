@@ -95,80 +95,6 @@ func Test_ByteWriter_WriteFourBytes_TooMany(t *testing.T) {
 	}
 }
 
-func Test_IfdDataAllocator_Allocate_InitialOffset1(t *testing.T) {
-	addressableOffset := uint32(0)
-	ida := newIfdDataAllocator(addressableOffset)
-
-	if ida.NextOffset() != addressableOffset {
-		t.Fatalf("initial offset not correct: (%d) != (%d)", ida.NextOffset(), addressableOffset)
-	} else if len(ida.Bytes()) != 0 {
-		t.Fatalf("initial buffer not empty")
-	}
-
-	data := []byte{0x1, 0x2, 0x3}
-	offset, err := ida.Allocate(data)
-	log.PanicIf(err)
-
-	expected := uint32(addressableOffset + 0)
-	if offset != expected {
-		t.Fatalf("offset not bumped correctly (2): (%d) != (%d)", offset, expected)
-	} else if ida.NextOffset() != offset+uint32(3) {
-		t.Fatalf("position counter not advanced properly")
-	} else if bytes.Compare(ida.Bytes(), []byte{0x1, 0x2, 0x3}) != 0 {
-		t.Fatalf("buffer not correct after write (1)")
-	}
-
-	data = []byte{0x4, 0x5, 0x6}
-	offset, err = ida.Allocate(data)
-	log.PanicIf(err)
-
-	expected = uint32(addressableOffset + 3)
-	if offset != expected {
-		t.Fatalf("offset not bumped correctly (3): (%d) != (%d)", offset, expected)
-	} else if ida.NextOffset() != offset+uint32(3) {
-		t.Fatalf("position counter not advanced properly")
-	} else if bytes.Compare(ida.Bytes(), []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6}) != 0 {
-		t.Fatalf("buffer not correct after write (2)")
-	}
-}
-
-func Test_IfdDataAllocator_Allocate_InitialOffset2(t *testing.T) {
-	addressableOffset := uint32(10)
-	ida := newIfdDataAllocator(addressableOffset)
-
-	if ida.NextOffset() != addressableOffset {
-		t.Fatalf("initial offset not correct: (%d) != (%d)", ida.NextOffset(), addressableOffset)
-	} else if len(ida.Bytes()) != 0 {
-		t.Fatalf("initial buffer not empty")
-	}
-
-	data := []byte{0x1, 0x2, 0x3}
-	offset, err := ida.Allocate(data)
-	log.PanicIf(err)
-
-	expected := uint32(addressableOffset + 0)
-	if offset != expected {
-		t.Fatalf("offset not bumped correctly (2): (%d) != (%d)", offset, expected)
-	} else if ida.NextOffset() != offset+uint32(3) {
-		t.Fatalf("position counter not advanced properly")
-	} else if bytes.Compare(ida.Bytes(), []byte{0x1, 0x2, 0x3}) != 0 {
-		t.Fatalf("buffer not correct after write (1)")
-	}
-
-	data = []byte{0x4, 0x5, 0x6}
-	offset, err = ida.Allocate(data)
-	log.PanicIf(err)
-
-	expected = uint32(addressableOffset + 3)
-	if offset != expected {
-		t.Fatalf("offset not bumped correctly (3): (%d) != (%d)", offset, expected)
-	} else if ida.NextOffset() != offset+uint32(3) {
-		t.Fatalf("position counter not advanced properly")
-	} else if bytes.Compare(ida.Bytes(), []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6}) != 0 {
-		t.Fatalf("buffer not correct after write (2)")
-	}
-}
-
 func Test_IfdByteEncoder__Arithmetic(t *testing.T) {
 	ibe := NewIfdByteEncoder()
 
@@ -179,51 +105,46 @@ func Test_IfdByteEncoder__Arithmetic(t *testing.T) {
 	}
 }
 
-func Test_IfdByteEncoder_encodeTagToBytes_bytes_embedded1(t *testing.T) {
-	defer func() {
-		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
-			log.PrintErrorf(err, "Test failure.")
-			panic(err)
-		}
-	}()
+// exifSimpleTestIbPayload is the encoding of `getExifSimpleTestIb()`,
+// starting at the first IFD.
+var exifSimpleTestIbPayload = []byte{
+	// IFD table block.
 
-	ibe := NewIfdByteEncoder()
+	// - Tag count
+	0x00, 0x04,
 
-	im := NewIfdMapping()
+	// - Tags
+	0x00, 0x0b, 0x00, 0x02, 0x00, 0x00, 0x00, 0x0b, 0x00, 0x00, 0x00, 0x3e,
+	0x00, 0xff, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x11, 0x22, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01, 0x33, 0x44, 0x55, 0x66,
+	0x01, 0x3e, 0x00, 0x05, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x4a,
 
-	err := LoadStandardIfds(im)
-	log.PanicIf(err)
+	// - Next IFD offset
+	0x00, 0x00, 0x00, 0x00,
 
-	ti := NewTagIndex()
-	ib := NewIfdBuilder(im, ti, exifcommon.IfdGpsInfoStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+	// IFD data block.
 
-	it, err := ti.Get(ib.IfdIdentity(), uint16(0x0000))
-	log.PanicIf(err)
+	// - The one ASCII value (and padding)
+	0x61, 0x73, 0x63, 0x69, 0x69, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x00, 0x00,
 
-	bt := NewStandardBuilderTag(exifcommon.IfdGpsInfoStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, []uint8{uint8(0x12)})
+	// - The one rational value
+	0x11, 0x11, 0x22, 0x22, 0x33, 0x33, 0x44, 0x44,
+}
 
-	b := new(bytes.Buffer)
-	bw := NewByteWriter(b, exifcommon.TestDefaultByteOrder)
+func Test_IfdByteEncoder_EncodeToExifPayload_Simple(t *testing.T) {
+	ib := getExifSimpleTestIb()
 
-	addressableOffset := uint32(0x1234)
-	ida := newIfdDataAllocator(addressableOffset)
+	ibe := NewIfdByteEncoder()
 
-	childIfdBlock, err := ibe.encodeTagToBytes(ib, bt, bw, ida, uint32(0))
+	encoded, err := ibe.EncodeToExifPayload(ib)
 	log.PanicIf(err)
 
-	if childIfdBlock != nil {
-		t.Fatalf("no child-IFDs were expected to be allocated")
-	} else if bytes.Compare(b.Bytes(), []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x12, 0x00, 0x00, 0x00}) != 0 {
-		t.Fatalf("encoded tag-entry bytes not correct")
-	} else if ida.NextOffset() != addressableOffset {
-		t.Fatalf("allocation was done but not expected")
+	if bytes.Equal(encoded, exifSimpleTestIbPayload) == false {
+		t.Fatalf("IFD table and allocated data not correct: %v", exifcommon.DumpBytesClauseToString(encoded))
 	}
 }
 
-func Test_IfdByteEncoder_encodeTagToBytes_bytes_embedded2(t *testing.T) {
-	ibe := NewIfdByteEncoder()
-
+func Test_IfdByteEncoder_EncodeToExifPayload_EmbeddedBytes(t *testing.T) {
 	im := NewIfdMapping()
 
 	err := LoadStandardIfds(im)
@@ -235,29 +156,34 @@ func Test_IfdByteEncoder_encodeTagToBytes_bytes_embedded2(t *testing.T) {
 	it, err := ti.Get(ib.IfdIdentity(), uint16(0x0000))
 	log.PanicIf(err)
 
-	bt := NewStandardBuilderTag(exifcommon.IfdGpsInfoStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, []uint8{uint8(0x12), uint8(0x34), uint8(0x56), uint8(0x78)})
+	bt := NewStandardBuilderTag(exifcommon.IfdGpsInfoStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, []uint8{uint8(0x12)})
 
-	b := new(bytes.Buffer)
-	bw := NewByteWriter(b, exifcommon.TestDefaultByteOrder)
+	err = ib.Add(bt)
+	log.PanicIf(err)
+
+	bt = NewStandardBuilderTag(exifcommon.IfdGpsInfoStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, []uint8{uint8(0x12), uint8(0x34), uint8(0x56), uint8(0x78)})
 
-	addressableOffset := uint32(0x1234)
-	ida := newIfdDataAllocator(addressableOffset)
+	err = ib.Add(bt)
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
 
-	childIfdBlock, err := ibe.encodeTagToBytes(ib, bt, bw, ida, uint32(0))
+	encoded, err := ibe.EncodeToExifPayload(ib)
 	log.PanicIf(err)
 
-	if childIfdBlock != nil {
-		t.Fatalf("no child-IFDs were expected to be allocated")
-	} else if bytes.Compare(b.Bytes(), []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x04, 0x12, 0x34, 0x56, 0x78}) != 0 {
-		t.Fatalf("encoded tag-entry bytes not correct")
-	} else if ida.NextOffset() != addressableOffset {
-		t.Fatalf("allocation was done but not expected")
+	expected := []byte{
+		0x00, 0x02,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x12, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x04, 0x12, 0x34, 0x56, 0x78,
+		0x00, 0x00, 0x00, 0x00,
 	}
-}
 
-func Test_IfdByteEncoder_encodeTagToBytes_bytes_allocated(t *testing.T) {
-	ibe := NewIfdByteEncoder()
+	if bytes.Equal(encoded, expected) == false {
+		t.Fatalf("Encoded bytes not correct: %v", exifcommon.DumpBytesClauseToString(encoded))
+	}
+}
 
+func Test_IfdByteEncoder_EncodeToExifPayload_AllocatedBytes(t *testing.T) {
 	im := NewIfdMapping()
 
 	err := LoadStandardIfds(im)
@@ -266,107 +192,50 @@ func Test_IfdByteEncoder_encodeTagToBytes_bytes_allocated(t *testing.T) {
 	ti := NewTagIndex()
 	ib := NewIfdBuilder(im, ti, exifcommon.IfdGpsInfoStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
 
-	b := new(bytes.Buffer)
-	bw := NewByteWriter(b, exifcommon.TestDefaultByteOrder)
-
-	addressableOffset := uint32(0x1234)
-	ida := newIfdDataAllocator(addressableOffset)
-
 	it, err := ti.Get(ib.IfdIdentity(), uint16(0x0000))
 	log.PanicIf(err)
 
 	bt := NewStandardBuilderTag(exifcommon.IfdGpsInfoStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, []uint8{uint8(0x12), uint8(0x34), uint8(0x56), uint8(0x78), uint8(0x9a)})
 
-	childIfdBlock, err := ibe.encodeTagToBytes(ib, bt, bw, ida, uint32(0))
+	err = ib.Add(bt)
 	log.PanicIf(err)
 
-	if childIfdBlock != nil {
-		t.Fatalf("no child-IFDs were expected to be allocated (1)")
-	} else if bytes.Compare(b.Bytes(), []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x12, 0x34}) != 0 {
-		t.Fatalf("encoded tag-entry bytes not correct (1)")
-	} else if ida.NextOffset() != addressableOffset+uint32(5) {
-		t.Fatalf("allocation offset not expected (1)")
-	} else if bytes.Compare(ida.Bytes(), []byte{0x12, 0x34, 0x56, 0x78, 0x9A}) != 0 {
-		t.Fatalf("allocated data not correct (1)")
-	}
-
-	// Test that another allocation encodes to the new offset.
-
 	bt = NewStandardBuilderTag(exifcommon.IfdGpsInfoStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, []uint8{uint8(0xbc), uint8(0xde), uint8(0xf0), uint8(0x12), uint8(0x34)})
 
-	childIfdBlock, err = ibe.encodeTagToBytes(ib, bt, bw, ida, uint32(0))
-	log.PanicIf(err)
-
-	if childIfdBlock != nil {
-		t.Fatalf("no child-IFDs were expected to be allocated (2)")
-	} else if bytes.Compare(b.Bytes(), []byte{
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x12, 0x34, // Tag 1
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x12, 0x39, // Tag 2
-	}) != 0 {
-		t.Fatalf("encoded tag-entry bytes not correct (2)")
-	} else if ida.NextOffset() != addressableOffset+uint32(10) {
-		t.Fatalf("allocation offset not expected (2)")
-	} else if bytes.Compare(ida.Bytes(), []byte{
-		0x12, 0x34, 0x56, 0x78, 0x9A,
-		0xbc, 0xde, 0xf0, 0x12, 0x34,
-	}) != 0 {
-		t.Fatalf("allocated data not correct (2)")
-	}
-}
+	err = ib.Add(bt)
+	log.PanicIf(err)
 
-func Test_IfdByteEncoder_encodeTagToBytes_childIfd__withoutAllocate(t *testing.T) {
 	ibe := NewIfdByteEncoder()
 
-	im := NewIfdMapping()
-
-	err := LoadStandardIfds(im)
+	encoded, err := ibe.EncodeToExifPayload(ib)
 	log.PanicIf(err)
 
-	ti := NewTagIndex()
-	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+	// Odd-length values are padded so that the next one starts on a word
+	// boundary.
 
-	b := new(bytes.Buffer)
-	bw := NewByteWriter(b, exifcommon.TestDefaultByteOrder)
-
-	addressableOffset := uint32(0x1234)
-	ida := newIfdDataAllocator(addressableOffset)
-
-	childIb := NewIfdBuilder(im, ti, exifcommon.IfdExifStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
-	tagValue := NewIfdBuilderTagValueFromIfdBuilder(childIb)
-	bt := NewChildIfdBuilderTag(exifcommon.IfdStandardIfdIdentity.UnindexedString(), exifcommon.IfdExifStandardIfdIdentity.TagId(), tagValue)
+	expected := []byte{
+		0x00, 0x02,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x26,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x2c,
+		0x00, 0x00, 0x00, 0x00,
 
-	nextIfdOffsetToWrite := uint32(0)
-	childIfdBlock, err := ibe.encodeTagToBytes(ib, bt, bw, ida, nextIfdOffsetToWrite)
-	log.PanicIf(err)
+		0x12, 0x34, 0x56, 0x78, 0x9a, 0x00,
+		0xbc, 0xde, 0xf0, 0x12, 0x34, 0x00,
+	}
 
-	if childIfdBlock != nil {
-		t.Fatalf("no child-IFDs were expected to be allocated")
-	} else if bytes.Compare(b.Bytes(), []byte{0x87, 0x69, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}) != 0 {
-		t.Fatalf("encoded tag-entry with child-IFD not correct")
-	} else if ida.NextOffset() != addressableOffset {
-		t.Fatalf("allocation offset not expected")
+	if bytes.Equal(encoded, expected) == false {
+		t.Fatalf("Encoded bytes not correct: %v", exifcommon.DumpBytesClauseToString(encoded))
 	}
 }
 
-func Test_IfdByteEncoder_encodeTagToBytes_childIfd__withAllocate(t *testing.T) {
-	defer func() {
-		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
-			log.PrintErrorf(err, "Test failure.")
-			panic(err)
-		}
-	}()
-
-	// Create a child IFD (represented by an IB instance) that we can allocate
-	// space for and then attach to a tag (which would normally be an entry,
-	// then, in a higher IFD).
-
+func Test_IfdByteEncoder_EncodeToExifPayload_ChildIfd(t *testing.T) {
 	im := NewIfdMapping()
 
 	err := LoadStandardIfds(im)
 	log.PanicIf(err)
 
 	ti := NewTagIndex()
+
 	childIb := NewIfdBuilder(im, ti, exifcommon.IfdExifStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
 
 	childIbTestTag := &BuilderTag{
@@ -376,293 +245,39 @@ func Test_IfdByteEncoder_encodeTagToBytes_childIfd__withAllocate(t *testing.T) {
 		value:   NewIfdBuilderTagValueFromBytes([]byte{0x12, 0x34}),
 	}
 
-	childIb.Add(childIbTestTag)
-
-	// Formally compose the tag that refers to it.
-
-	tagValue := NewIfdBuilderTagValueFromIfdBuilder(childIb)
-	bt := NewChildIfdBuilderTag(exifcommon.IfdStandardIfdIdentity.UnindexedString(), exifcommon.IfdExifStandardIfdIdentity.TagId(), tagValue)
-
-	// Encode the tag. Since we've actually provided an offset at which we can
-	// allocate data, the child-IFD will automatically be encoded, allocated,
-	// and installed into the allocated-data block (which will follow the IFD
-	// block/table in the file).
-
-	ibe := NewIfdByteEncoder()
-
-	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
-
-	b := new(bytes.Buffer)
-	bw := NewByteWriter(b, exifcommon.TestDefaultByteOrder)
-
-	// addressableOffset is the offset of where large data can be allocated
-	// (which follows the IFD table/block). Large, in that it can't be stored
-	// in the table itself. Just used for arithmetic. This is just where the
-	// data for the current IFD can be written. It's not absolute for the EXIF
-	// data in general.
-	addressableOffset := uint32(0x1234)
-	ida := newIfdDataAllocator(addressableOffset)
-
-	// This is the offset of where the next IFD can be written in the EXIF byte
-	// stream. Just used for arithmetic.
-	nextIfdOffsetToWrite := uint32(2000)
-
-	childIfdBlock, err := ibe.encodeTagToBytes(ib, bt, bw, ida, nextIfdOffsetToWrite)
+	err = childIb.Add(childIbTestTag)
 	log.PanicIf(err)
 
-	if ida.NextOffset() != addressableOffset {
-		t.Fatalf("IDA offset changed but no allocations where expected: (0x%02x)", ida.NextOffset())
-	}
-
-	tagBytes := b.Bytes()
-
-	if len(tagBytes) != 12 {
-		t.Fatalf("Tag not encoded to the right number of bytes: (%d)", len(tagBytes))
-	} else if len(childIfdBlock) != 18 {
-		t.Fatalf("Child IFD is not the right size: (%d)", len(childIfdBlock))
-	}
-
-	iteV, err := ParseOneTag(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder, tagBytes)
-	log.PanicIf(err)
-
-	if iteV.TagId() != exifcommon.IfdExifStandardIfdIdentity.TagId() {
-		t.Fatalf("IFD first tag-ID not correct: (0x%02x)", iteV.TagId())
-	} else if iteV.tagIndex != 0 {
-		t.Fatalf("IFD first tag index not correct: (%d)", iteV.tagIndex)
-	} else if iteV.TagType() != exifcommon.TypeLong {
-		t.Fatalf("IFD first tag type not correct: (%d)", iteV.TagType())
-	} else if iteV.UnitCount() != 1 {
-		t.Fatalf("IFD first tag unit-count not correct: (%d)", iteV.UnitCount())
-	} else if iteV.getValueOffset() != nextIfdOffsetToWrite {
-		t.Fatalf("IFD's child-IFD offset (as offset) is not correct: (%d) != (%d)", iteV.getValueOffset(), nextIfdOffsetToWrite)
-	} else if iteV.ChildIfdPath() != exifcommon.IfdExifStandardIfdIdentity.UnindexedString() {
-		t.Fatalf("IFD first tag IFD-name name not correct: [%s]", iteV.ChildIfdPath())
-	} else if iteV.IfdPath() != exifcommon.IfdStandardIfdIdentity.UnindexedString() {
-		t.Fatalf("IFD first tag parent IFD not correct: %v", iteV.IfdPath())
-	}
-
-	// Validate the child's raw IFD bytes.
-
-	childNextIfdOffset, childEntries, err := ParseOneIfd(im, ti, exifcommon.IfdExifStandardIfdIdentity, exifcommon.TestDefaultByteOrder, childIfdBlock, nil)
-	log.PanicIf(err)
-
-	if childNextIfdOffset != uint32(0) {
-		t.Fatalf("Child IFD: Next IFD offset should be (0): (0x%08x)", childNextIfdOffset)
-	} else if len(childEntries) != 1 {
-		t.Fatalf("Child IFD: Expected exactly one entry: (%d)", len(childEntries))
-	}
-
-	ite := childEntries[0]
-
-	if ite.TagId() != 0x8822 {
-		t.Fatalf("Child IFD first tag-ID not correct: (0x%02x)", ite.TagId())
-	} else if ite.tagIndex != 0 {
-		t.Fatalf("Child IFD first tag index not correct: (%d)", ite.tagIndex)
-	} else if ite.TagType() != exifcommon.TypeShort {
-		t.Fatalf("Child IFD first tag type not correct: (%d)", ite.TagType())
-	} else if ite.UnitCount() != 1 {
-		t.Fatalf("Child IFD first tag unit-count not correct: (%d)", ite.UnitCount())
-	} else if ite.ChildIfdPath() != "" {
-		t.Fatalf("Child IFD first tag IFD-name name not empty: [%s]", ite.ChildIfdPath())
-	} else if ite.IfdPath() != exifcommon.IfdExifStandardIfdIdentity.UnindexedString() {
-		t.Fatalf("Child IFD first tag parent IFD not correct: %v", ite.IfdPath())
-	}
-}
-
-func Test_IfdByteEncoder_encodeTagToBytes_simpleTag_allocate(t *testing.T) {
-	defer func() {
-		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
-			log.PrintErrorf(err, "Test failure.")
-			panic(err)
-		}
-	}()
-
-	// Encode the tag. Since we've actually provided an offset at which we can
-	// allocate data, the child-IFD will automatically be encoded, allocated,
-	// and installed into the allocated-data block (which will follow the IFD
-	// block/table in the file).
-
-	ibe := NewIfdByteEncoder()
-
-	im := NewIfdMapping()
-
-	err := LoadStandardIfds(im)
-	log.PanicIf(err)
-
-	ti := NewTagIndex()
 	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
 
-	it, err := ib.tagIndex.Get(ib.IfdIdentity(), uint16(0x000b))
-	log.PanicIf(err)
-
-	valueString := "testvalue"
-	bt := NewStandardBuilderTag(exifcommon.IfdStandardIfdIdentity.UnindexedString(), it, exifcommon.TestDefaultByteOrder, valueString)
-
-	b := new(bytes.Buffer)
-	bw := NewByteWriter(b, exifcommon.TestDefaultByteOrder)
-
-	// addressableOffset is the offset of where large data can be allocated
-	// (which follows the IFD table/block). Large, in that it can't be stored
-	// in the table itself. Just used for arithmetic. This is just where the
-	// data for the current IFD can be written. It's not absolute for the EXIF
-	// data in general.
-	addressableOffset := uint32(0x1234)
-	ida := newIfdDataAllocator(addressableOffset)
-
-	childIfdBlock, err := ibe.encodeTagToBytes(ib, bt, bw, ida, uint32(0))
+	err = ib.AddChildIb(childIb)
 	log.PanicIf(err)
 
-	if ida.NextOffset() == addressableOffset {
-		t.Fatalf("IDA offset did not change even though there should've been an allocation.")
-	}
-
-	tagBytes := b.Bytes()
-
-	if len(tagBytes) != 12 {
-		t.Fatalf("Tag not encoded to the right number of bytes: (%d)", len(tagBytes))
-	} else if len(childIfdBlock) != 0 {
-		t.Fatalf("Child IFD not have been allocated.")
-	}
-
-	ite, err := ParseOneTag(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder, tagBytes)
-	log.PanicIf(err)
-
-	if ite.TagId() != 0x000b {
-		t.Fatalf("Tag-ID not correct: (0x%02x)", ite.TagId())
-	} else if ite.tagIndex != 0 {
-		t.Fatalf("Tag index not correct: (%d)", ite.tagIndex)
-	} else if ite.TagType() != exifcommon.TypeAscii {
-		t.Fatalf("Tag type not correct: (%d)", ite.TagType())
-	} else if ite.UnitCount() != (uint32(len(valueString) + 1)) {
-		t.Fatalf("Tag unit-count not correct: (%d)", ite.UnitCount())
-	} else if ite.ChildIfdPath() != "" {
-		t.Fatalf("Tag's IFD-name should be empty: [%s]", ite.ChildIfdPath())
-	} else if ite.IfdPath() != exifcommon.IfdStandardIfdIdentity.UnindexedString() {
-		t.Fatalf("Tag's parent IFD is not correct: %v", ite.IfdPath())
-	}
-
-	expectedBuffer := bytes.NewBufferString(valueString)
-	expectedBuffer.Write([]byte{0x0})
-	expectedBytes := expectedBuffer.Bytes()
-
-	allocatedBytes := ida.Bytes()
-
-	if bytes.Compare(allocatedBytes, expectedBytes) != 0 {
-		t.Fatalf("Allocated bytes not correct: %v != %v", allocatedBytes, expectedBytes)
-	}
-}
-
-func Test_IfdByteEncoder_encodeIfdToBytes_simple(t *testing.T) {
-	ib := getExifSimpleTestIb()
-
-	// Write the byte stream.
-
 	ibe := NewIfdByteEncoder()
 
-	// addressableOffset is the offset of where large data can be allocated
-	// (which follows the IFD table/block). Large, in that it can't be stored
-	// in the table itself. Just used for arithmetic. This is just where the
-	// data for the current IFD can be written. It's not absolute for the EXIF
-	// data in general.
-	addressableOffset := uint32(0x1234)
-
-	tableAndAllocated, tableSize, allocatedDataSize, childIfdSizes, err := ibe.encodeIfdToBytes(ib, addressableOffset, uint32(0), false)
+	encoded, err := ibe.EncodeToExifPayload(ib)
 	log.PanicIf(err)
 
-	expectedTableSize := ibe.TableSize(4)
-	if tableSize != expectedTableSize {
-		t.Fatalf("Table-size not the right size: (%d) != (%d)", tableSize, expectedTableSize)
-	} else if len(childIfdSizes) != 0 {
-		t.Fatalf("One or more child IFDs were allocated but shouldn't have been: (%d)", len(childIfdSizes))
-	}
+	// The child IFD follows the parent's table (and allocated data, of which
+	// there is none).
 
-	// The ASCII value plus the rational size.
-	expectedAllocatedSize := 11 + 8
-
-	if int(allocatedDataSize) != expectedAllocatedSize {
-		t.Fatalf("Allocated data size not correct: (%d)", allocatedDataSize)
-	}
-
-	expectedIfdAndDataBytes := []byte{
-		// IFD table block.
-
-		// - Tag count
-		0x00, 0x04,
-
-		// - Tags
-		0x00, 0x0b, 0x00, 0x02, 0x00, 0x00, 0x00, 0x0b, 0x00, 0x00, 0x12, 0x34,
-		0x00, 0xff, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x11, 0x22, 0x00, 0x00,
-		0x01, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01, 0x33, 0x44, 0x55, 0x66,
-		0x01, 0x3e, 0x00, 0x05, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x12, 0x3f,
-
-		// - Next IFD offset
+	expected := []byte{
+		// Root IFD.
+		0x00, 0x01,
+		0x87, 0x69, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x1a,
 		0x00, 0x00, 0x00, 0x00,
 
-		// IFD data block.
-
-		// - The one ASCII value
-		0x61, 0x73, 0x63, 0x69, 0x69, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x00,
-
-		// - The one rational value
-		0x11, 0x11, 0x22, 0x22, 0x33, 0x33, 0x44, 0x44,
+		// Exif IFD.
+		0x00, 0x01,
+		0x88, 0x22, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x12, 0x34, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
 	}
 
-	if bytes.Compare(tableAndAllocated, expectedIfdAndDataBytes) != 0 {
-		t.Fatalf("IFD table and allocated data not correct: %v", exifcommon.DumpBytesClauseToString(tableAndAllocated))
+	if bytes.Equal(encoded, expected) == false {
+		t.Fatalf("Encoded bytes not correct: %v", exifcommon.DumpBytesClauseToString(encoded))
 	}
 }
 
-func Test_IfdByteEncoder_encodeIfdToBytes_fullExif(t *testing.T) {
-	defer func() {
-		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
-			log.PrintErrorf(err, "Test failure.")
-			panic(err)
-		}
-	}()
-
-	ib := getExifSimpleTestIb()
-
-	// Encode the IFD to a byte stream.
-
-	ibe := NewIfdByteEncoder()
-
-	// Run a simulation just to figure out the sizes.
-	_, tableSize, allocatedDataSize, _, err := ibe.encodeIfdToBytes(ib, uint32(0), uint32(0), false)
-	log.PanicIf(err)
-
-	addressableOffset := ExifDefaultFirstIfdOffset + tableSize
-	nextIfdOffsetToWrite := addressableOffset + allocatedDataSize
-
-	// Run the final encode now that we can correctly assign the offsets.
-	tableAndAllocated, _, _, _, err := ibe.encodeIfdToBytes(ib, addressableOffset, uint32(nextIfdOffsetToWrite), false)
-	log.PanicIf(err)
-
-	if len(tableAndAllocated) != (int(tableSize) + int(allocatedDataSize)) {
-		t.Fatalf("Table-and-data size doesn't match what was expected: (%d) != (%d + %d)", len(tableAndAllocated), tableSize, allocatedDataSize)
-	}
-
-	// Wrap the IFD in a formal EXIF block.
-
-	b := new(bytes.Buffer)
-
-	headerBytes, err := BuildExifHeader(exifcommon.TestDefaultByteOrder, ExifDefaultFirstIfdOffset)
-	log.PanicIf(err)
-
-	_, err = b.Write(headerBytes)
-	log.PanicIf(err)
-
-	_, err = b.Write(tableAndAllocated)
-	log.PanicIf(err)
-
-	// Now, try parsing it as EXIF data, making sure to resolve (read:
-	// dereference) the values (which will include the allocated ones).
-
-	exifData := b.Bytes()
-	validateExifSimpleTestIb(exifData, t)
-}
-
 func Test_IfdByteEncoder_EncodeToExifPayload(t *testing.T) {
 	defer func() {
 		if state := recover(); state != nil {
@@ -803,17 +418,17 @@ func Test_IfdByteEncoder_EncodeToExif_WithChildAndSibling(t *testing.T) {
 		`> IFD [ROOT]->[IFD]:(0) TOP
   - (0x000b)
   - (0x00ff)
+  - (0x0100)
+  - (0x013e)
   - (0x8769)
   > IFD [IFD]->[IFD/Exif]:(0) TOP
     - (0x8827)
     - (0x8833)
   < IFD [IFD]->[IFD/Exif]:(0) BOTTOM
-  - (0x0100)
   - (0x8825)
   > IFD [IFD]->[IFD/GPSInfo]:(0) TOP
     - (0x0005)
   < IFD [IFD]->[IFD/GPSInfo]:(0) BOTTOM
-  - (0x013e)
 < IFD [ROOT]->[IFD]:(0) BOTTOM
 * LINKING TO SIBLING IFD [IFD]:(1)
 > IFD [ROOT]->[IFD]:(1) TOP
@@ -891,9 +506,9 @@ func ExampleIfdByteEncoder_EncodeToExif() {
 	// Output:
 	//
 	// 0: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x000b) TAG-TYPE=[ASCII] UNIT-COUNT=(11)> [asciivalue]
-	// 1: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x0150) TAG-TYPE=[BYTE] UNIT-COUNT=(1)> [[17]]
-	// 2: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x00ff) TAG-TYPE=[SHORT] UNIT-COUNT=(1)> [[8755]]
-	// 3: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x0100) TAG-TYPE=[LONG] UNIT-COUNT=(1)> [[1146447479]]
-	// 4: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x013e) TAG-TYPE=[RATIONAL] UNIT-COUNT=(1)> [[{286335522 858997828}]]
+	// 1: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x00ff) TAG-TYPE=[SHORT] UNIT-COUNT=(1)> [[8755]]
+	// 2: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x0100) TAG-TYPE=[LONG] UNIT-COUNT=(1)> [[1146447479]]
+	// 3: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x013e) TAG-TYPE=[RATIONAL] UNIT-COUNT=(1)> [[{286335522 858997828}]]
+	// 4: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x0150) TAG-TYPE=[BYTE] UNIT-COUNT=(1)> [[17]]
 	// 5: IfdTagEntry<TAG-IFD-PATH=[IFD] TAG-ID=(0x9201) TAG-TYPE=[SRATIONAL] UNIT-COUNT=(1)> [[{286335522 858997828}]]
 }
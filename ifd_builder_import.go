@@ -0,0 +1,245 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+
+	"encoding/binary"
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+var (
+	// ErrExifTagNotImportable means that an `ExifTag` does not have enough
+	// information to rebuild its value.
+	ErrExifTagNotImportable = errors.New("exif tag not importable")
+)
+
+// NewIfdBuilderFromExifTagsJson rebuilds an IB tree from the JSON form of the
+// flat tag list returned by `GetFlatExifData`. See
+// `NewIfdBuilderFromExifTags`.
+func NewIfdBuilderFromExifTagsJson(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, data []byte, byteOrder binary.ByteOrder) (rootIb *IfdBuilder, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	exifTags := make([]ExifTag, 0)
+
+	err = json.Unmarshal(data, &exifTags)
+	log.PanicIf(err)
+
+	rootIb, err = NewIfdBuilderFromExifTags(ifdMapping, tagIndex, exifTags, byteOrder)
+	log.PanicIf(err)
+
+	return rootIb, nil
+}
+
+// NewIfdBuilderFromExifTags rebuilds an IB tree from the flat tag list
+// returned by `GetFlatExifData`. Child IFDs are created from `ChildIfdPath`.
+// If a tag has `ValueBytes`, they are decoded using `byteOrder` (which must
+// be the byte-order of the EXIF that the list was taken from) and stored
+// as-is, so the original values are reproduced exactly. Otherwise, `Value` is
+// converted using `TranslateStringToType` (BYTE values are written as numbers
+// or as hex strings and rationals as "N/D" strings or as objects with
+// "Numerator" and "Denominator"). Undefined-type tags require `ValueBytes`.
+//
+// The thumbnail offset and length tags are skipped since the thumbnail data
+// is not part of the list. Use `SetThumbnail` on the IFD1 IB to restore it.
+func NewIfdBuilderFromExifTags(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, exifTags []ExifTag, byteOrder binary.ByteOrder) (rootIb *IfdBuilder, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	rootIb = NewIfdBuilder(ifdMapping, tagIndex, exifcommon.IfdStandardIfdIdentity, byteOrder)
+
+	for i, et := range exifTags {
+		ib, err := GetOrCreateIbFromRootIb(rootIb, et.IfdPath)
+		log.PanicIf(err)
+
+		if et.ChildIfdPath != "" {
+			childFqIfdPath := fmt.Sprintf("%s/%s", et.IfdPath, path.Base(et.ChildIfdPath))
+
+			_, err := GetOrCreateIbFromRootIb(rootIb, childFqIfdPath)
+			log.PanicIf(err)
+
+			continue
+		}
+
+		if ib.IfdIdentity().Equals(exifcommon.Ifd1StandardIfdIdentity) == true && (et.TagId == ThumbnailOffsetTagId || et.TagId == ThumbnailSizeTagId) {
+			continue
+		}
+
+		bt, err := newBuilderTagFromExifTag(ib, et)
+		if err != nil {
+			ifdBuilderLogger.Warningf(nil, "Tag (%d) [%s] (0x%04x) [%s] can not be imported.", i, et.IfdPath, et.TagId, et.TagName)
+			log.Panic(err)
+		}
+
+		err = ib.Add(bt)
+		log.PanicIf(err)
+	}
+
+	return rootIb, nil
+}
+
+// newBuilderTagFromExifTag converts one `ExifTag` to a `BuilderTag`.
+func newBuilderTagFromExifTag(ib *IfdBuilder, et ExifTag) (bt *BuilderTag, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	tagType := et.TagTypeId
+	if tagType == 0 {
+		var found bool
+
+		tagType, found = exifcommon.GetTypeByName(et.TagTypeName)
+		if found == false {
+			log.Panicf("type [%s] not valid", et.TagTypeName)
+		}
+	}
+
+	ifdPath := ib.IfdIdentity().UnindexedString()
+
+	var valueBytes []byte
+
+	if len(et.ValueBytes) > 0 {
+		valueBytes = et.ValueBytes
+
+		// Decode it in order to make sure that it is well-formed.
+		if tagType != exifcommon.TypeUndefined {
+			_, err := decodeExifTagValueBytes(ifdPath, et.TagId, tagType, valueBytes, ib.byteOrder)
+			log.PanicIf(err)
+		}
+	} else if tagType == exifcommon.TypeUndefined {
+		log.Panic(ErrExifTagNotImportable)
+	} else if s, ok := et.Value.(string); ok == true && tagType == exifcommon.TypeByte && isXpTag(ifdPath, et.TagId) == true {
+		valueBytes = encodeXpString(s)
+	} else {
+		valueStrings, err := exifTagValueStrings(tagType, et.Value)
+		log.PanicIf(err)
+
+		value, err := exifcommon.TranslateStringsToType(tagType, valueStrings)
+		log.PanicIf(err)
+
+		if tagType == exifcommon.TypeAsciiNoNul {
+			valueBytes = []byte(value.(string))
+		} else {
+			ve := exifcommon.NewValueEncoder(ib.byteOrder)
+
+			ed, err := ve.Encode(value)
+			log.PanicIf(err)
+
+			valueBytes = ed.Encoded
+		}
+	}
+
+	bt = NewBuilderTag(
+		ifdPath,
+		et.TagId,
+		tagType,
+		NewIfdBuilderTagValueFromBytes(valueBytes),
+		ib.byteOrder)
+
+	return bt, nil
+}
+
+// decodeExifTagValueBytes decodes an encoded value.
+func decodeExifTagValueBytes(ifdPath string, tagId uint16, tagType exifcommon.TagTypePrimitive, valueBytes []byte, byteOrder binary.ByteOrder) (value interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	typeSize := tagType.Size()
+
+	if len(valueBytes)%typeSize != 0 {
+		log.Panicf("value of (%d) bytes does not align with type [%s]", len(valueBytes), tagType)
+	}
+
+	unitCount := uint32(len(valueBytes) / typeSize)
+
+	var vc *exifcommon.ValueContext
+
+	// Small values are embedded in the entry itself rather than addressed.
+	if len(valueBytes) <= 4 {
+		rawValueOffset := make([]byte, 4)
+		copy(rawValueOffset, valueBytes)
+
+		vc = exifcommon.NewValueContext(ifdPath, tagId, unitCount, 0, rawValueOffset, nil, tagType, byteOrder)
+	} else {
+		vc = exifcommon.NewValueContext(ifdPath, tagId, unitCount, 0, nil, valueBytes, tagType, byteOrder)
+	}
+
+	value, err = vc.Values()
+	log.PanicIf(err)
+
+	return value, nil
+}
+
+// exifTagValueStrings converts a value, as it appears in JSON, to the strings
+// that `TranslateStringsToType` expects.
+func exifTagValueStrings(tagType exifcommon.TagTypePrimitive, value interface{}) (valueStrings []string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if value == nil {
+		log.Panic(ErrExifTagNotImportable)
+	}
+
+	if s, ok := value.(string); ok == true {
+		return []string{s}, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		rv = reflect.ValueOf([]interface{}{value})
+	}
+
+	valueStrings = make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+
+		switch t := item.(type) {
+		case string:
+			valueStrings[i] = t
+		case float64:
+			if tagType == exifcommon.TypeByte {
+				valueStrings[i] = strconv.FormatUint(uint64(t), 16)
+			} else {
+				valueStrings[i] = strconv.FormatFloat(t, 'f', -1, 64)
+			}
+		case map[string]interface{}:
+			numerator, found1 := t["Numerator"]
+			denominator, found2 := t["Denominator"]
+
+			if found1 == false || found2 == false {
+				log.Panicf("object is not a rational: %v", t)
+			}
+
+			valueStrings[i] = fmt.Sprintf("%v/%v", numerator, denominator)
+		default:
+			phrase, err := exifcommon.FormatFromType(item, true)
+			log.PanicIf(err)
+
+			valueStrings[i] = phrase
+		}
+	}
+
+	return valueStrings, nil
+}
@@ -0,0 +1,153 @@
+package exif
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func TestNewIfdBuilderFromExifTagsJson_RoundTrip(t *testing.T) {
+	exifData := getTestExifData()
+
+	exifTags, err := GetFlatExifDataFromBytes(exifData)
+	log.PanicIf(err)
+
+	data, err := json.Marshal(exifTags)
+	log.PanicIf(err)
+
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	rootIb, err := NewIfdBuilderFromExifTagsJson(im, ti, data, binary.LittleEndian)
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	updatedExifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	updatedExifTags, err := GetFlatExifDataFromBytes(updatedExifData)
+	log.PanicIf(err)
+
+	// The thumbnail tags are not imported.
+	expectedExifTags := make([]ExifTag, 0)
+	for _, et := range exifTags {
+		if et.IfdPath == "IFD1" && (et.TagId == ThumbnailOffsetTagId || et.TagId == ThumbnailSizeTagId) {
+			continue
+		}
+
+		expectedExifTags = append(expectedExifTags, et)
+	}
+
+	if len(updatedExifTags) != len(expectedExifTags) {
+		t.Fatalf("Tag count not correct: (%d) != (%d)", len(updatedExifTags), len(expectedExifTags))
+	}
+
+	for i, et := range updatedExifTags {
+		expected := expectedExifTags[i]
+
+		if et.IfdPath != expected.IfdPath || et.TagId != expected.TagId || et.TagTypeId != expected.TagTypeId || et.UnitCount != expected.UnitCount {
+			t.Fatalf("Tag (%d) not correct: %s != %s", i, et, expected)
+		}
+
+		// Child-IFD offsets will change.
+		if et.ChildIfdPath == "" && bytes.Equal(et.ValueBytes, expected.ValueBytes) == false {
+			t.Fatalf("Tag (%d) value not correct: %s != %s", i, et, expected)
+		}
+	}
+}
+
+func TestNewIfdBuilderFromExifTags_Values(t *testing.T) {
+	data := []byte(`[
+		{"ifd_path": "IFD", "id": 271, "type_name": "ASCII", "value": "Some Make"},
+		{"ifd_path": "IFD", "id": 274, "type_id": 3, "value": [6]},
+		{"ifd_path": "IFD", "id": 282, "type_name": "RATIONAL", "value": [{"Numerator": 300, "Denominator": 1}]},
+		{"ifd_path": "IFD", "id": 283, "type_name": "RATIONAL", "value": "72/1"},
+		{"ifd_path": "IFD/GPSInfo", "id": 0, "type_name": "BYTE", "value": [2, 2, 0, 0]},
+		{"ifd_path": "IFD/Exif", "id": 36864, "type_name": "UNDEFINED", "value_bytes": "MDIzMA=="}
+	]`)
+
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	rootIb, err := NewIfdBuilderFromExifTagsJson(im, ti, data, binary.BigEndian)
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	index := getTestIndex(exifData)
+
+	if value := getTestTagValue(t, index, "IFD", 0x010f); value != "Some Make" {
+		t.Fatalf("Make not correct: [%v]", value)
+	} else if value := getTestTagValue(t, index, "IFD", 0x0112); reflect.DeepEqual(value, []uint16{6}) != true {
+		t.Fatalf("Orientation not correct: [%v]", value)
+	} else if value := getTestTagValue(t, index, "IFD", 0x011a); reflect.DeepEqual(value, []exifcommon.Rational{{Numerator: 300, Denominator: 1}}) != true {
+		t.Fatalf("XResolution not correct: [%v]", value)
+	} else if value := getTestTagValue(t, index, "IFD", 0x011b); reflect.DeepEqual(value, []exifcommon.Rational{{Numerator: 72, Denominator: 1}}) != true {
+		t.Fatalf("YResolution not correct: [%v]", value)
+	} else if value := getTestTagValue(t, index, "IFD/GPSInfo", TagGpsVersionId); reflect.DeepEqual(value, []byte{2, 2, 0, 0}) != true {
+		t.Fatalf("GPSVersionID not correct: [%v]", value)
+	}
+
+	results, err := index.Lookup["IFD/Exif"].FindTagWithId(0x9000)
+	log.PanicIf(err)
+
+	phrase, err := results[0].Format()
+	log.PanicIf(err)
+
+	if phrase != "0230" {
+		t.Fatalf("ExifVersion not correct: [%s]", phrase)
+	}
+}
+
+func TestNewIfdBuilderFromExifTags_NotImportable(t *testing.T) {
+	exifTags := []ExifTag{
+		{IfdPath: "IFD/Exif", TagId: 0x9000, TagTypeName: "UNDEFINED", Value: "0230"},
+	}
+
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	_, err := NewIfdBuilderFromExifTags(im, ti, exifTags, binary.BigEndian)
+	if log.Is(err, ErrExifTagNotImportable) == false {
+		t.Fatalf("Expected not-importable error: %v", err)
+	}
+}
+
+func TestNewIfdBuilderFromExifTagsJson_Asset(t *testing.T) {
+	assetsPath := exifcommon.GetTestAssetsPath()
+
+	data, err := ioutil.ReadFile(path.Join(assetsPath, "main_test_exif.json"))
+	log.PanicIf(err)
+
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	rootIb, err := NewIfdBuilderFromExifTagsJson(im, ti, data, binary.LittleEndian)
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	index := getTestIndex(exifData)
+
+	if value := getTestTagValue(t, index, "IFD", 0x0110); value != "Canon EOS 5D Mark III" {
+		t.Fatalf("Model not correct: [%v]", value)
+	} else if _, found := index.Lookup["IFD/Exif/Iop"]; found == false {
+		t.Fatalf("Interop IFD not created.")
+	}
+}
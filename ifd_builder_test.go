@@ -2445,3 +2445,55 @@ func TestGetOrCreateIbFromRootIb__Child(t *testing.T) {
 		t.Fatalf("Constructed IFDs not correct.")
 	}
 }
+
+func TestIfdBuilder_Utf8(t *testing.T) {
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	err := ib.AddStandardWithName("Make", "Canon")
+	log.PanicIf(err)
+
+	err = ib.AddStandardWithName("Artist", "Jürgen Müller")
+	log.PanicIf(err)
+
+	exifIb, err := GetOrCreateIbFromRootIb(ib, "IFD/Exif")
+	log.PanicIf(err)
+
+	err = exifIb.AddStandardWithName("ImageTitle", "東京の夜")
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(ib)
+	log.PanicIf(err)
+
+	exifTags, err := GetFlatExifDataFromBytes(exifData)
+	log.PanicIf(err)
+
+	type tagSummary struct {
+		name    string
+		tagType exifcommon.TagTypePrimitive
+		value   interface{}
+	}
+
+	actual := make([]tagSummary, 0)
+	for _, et := range exifTags {
+		if et.ChildIfdPath != "" {
+			continue
+		}
+
+		actual = append(actual, tagSummary{et.TagName, et.TagTypeId, et.Value})
+	}
+
+	expected := []tagSummary{
+		{"Make", exifcommon.TypeAscii, "Canon"},
+		{"Artist", exifcommon.TypeUtf8, "Jürgen Müller"},
+		{"ImageTitle", exifcommon.TypeUtf8, "東京の夜"},
+	}
+
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Fatalf("Tags not correct: %v", actual)
+	}
+}
@@ -0,0 +1,456 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"math/bits"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+var (
+	// ErrTimestampNotValid means that a timestamp or UTC-offset tag could not
+	// be parsed.
+	ErrTimestampNotValid = errors.New("timestamp not valid")
+)
+
+const (
+	// minimumUtcOffset and maximumUtcOffset bound the UTC-offsets that are in
+	// use anywhere (-12:00 to +14:00).
+	minimumUtcOffset = -12 * time.Hour
+	maximumUtcOffset = 14 * time.Hour
+)
+
+// TimestampShift describes a change to apply to all of the timestamps in an
+// EXIF tree.
+type TimestampShift struct {
+	// Duration is added to every timestamp (e.g. to correct a camera clock
+	// that was set wrong).
+	Duration time.Duration
+
+	// Offset, if not nil, is a new UTC-offset for the local timestamps (e.g.
+	// for a camera that was never moved to the local timezone). The local
+	// timestamps are moved so that they still describe the same instant, and
+	// the OffsetTime tags are written with the new offset. It must be within
+	// -12:00 to +14:00.
+	Offset *time.Duration
+
+	// DefaultOffset is the UTC-offset assumed for local timestamps that do not
+	// have an OffsetTime tag. It is only used along with `Offset`.
+	DefaultOffset time.Duration
+
+	// IncludeGps also shifts GPSDateStamp and GPSTimeStamp by `Duration`.
+	// They are always UTC and so are not affected by `Offset`.
+	IncludeGps bool
+}
+
+// ShiftedTimestamp describes one tag that was changed by `ShiftTimestamps`.
+type ShiftedTimestamp struct {
+	FqIfdPath string
+	TagId     uint16
+	TagName   string
+
+	// OldValue is empty if the tag was added.
+	OldValue string
+	NewValue string
+}
+
+// String returns a descriptive string.
+func (st ShiftedTimestamp) String() string {
+	return fmt.Sprintf("ShiftedTimestamp<IFD-PATH=[%s] ID=(0x%04x) NAME=[%s] OLD=[%s] NEW=[%s]>", st.FqIfdPath, st.TagId, st.TagName, st.OldValue, st.NewValue)
+}
+
+// timestampTag pairs a local timestamp with the tag that has its UTC-offset.
+type timestampTag struct {
+	fqIfdPath string
+	tagId     uint16
+
+	offsetTagId uint16
+}
+
+var (
+	// shiftedTimestampTags are the local timestamps that are shifted. The
+	// OffsetTime tags always live in the EXIF IFD.
+	shiftedTimestampTags = []timestampTag{
+		{exifcommon.IfdStandardIfdIdentity.String(), TagDateTime, TagOffsetTime},
+		{exifcommon.IfdStandardIfdIdentity.String(), TagDateTimeOriginal, TagOffsetTimeOriginal},
+		{exifcommon.IfdExifStandardIfdIdentity.String(), TagDateTimeOriginal, TagOffsetTimeOriginal},
+		{exifcommon.IfdExifStandardIfdIdentity.String(), TagDateTimeDigitized, TagOffsetTimeDigitized},
+	}
+
+	offsetTagIds = []uint16{
+		TagOffsetTime,
+		TagOffsetTimeOriginal,
+		TagOffsetTimeDigitized,
+	}
+)
+
+// ShiftTimestamps applies the shift to every timestamp in the tree: DateTime,
+// DateTimeOriginal, and DateTimeDigitized in IFD0 and the EXIF IFD, the
+// OffsetTime tags, and, optionally, the GPS date and time. This must be called
+// on the root IB (an error is returned otherwise). Timestamps that are blank (which the specification allows
+// for unknown times) are skipped.
+func (ib *IfdBuilder) ShiftTimestamps(shift TimestampShift) (shifted []ShiftedTimestamp, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	// The Exif and GPS IFDs are only found from the root.
+	if ib.IfdIdentity().Equals(exifcommon.IfdStandardIfdIdentity) == false {
+		log.Panicf("timestamps can only be shifted from the root IB, not [%s]", ib.IfdIdentity().String())
+	}
+
+	shifted = make([]ShiftedTimestamp, 0)
+
+	exifFqIfdPath := exifcommon.IfdExifStandardIfdIdentity.String()
+
+	// Several timestamps can share an offset tag, so read all of the offsets
+	// before we change any of them.
+
+	var offsetIb *IfdBuilder
+	offsetPhrases := make(map[uint16]string)
+	offsets := make(map[uint16]time.Duration)
+
+	if shift.Offset != nil {
+		if *shift.Offset < minimumUtcOffset || *shift.Offset > maximumUtcOffset {
+			log.Panic(ErrTimestampNotValid)
+		}
+
+		offsetIb, err = FindIbFromRootIb(ib, exifFqIfdPath)
+		if err != nil && log.Is(err, ErrChildIbNotFound) == false {
+			log.Panic(err)
+		}
+
+		for _, offsetTagId := range offsetTagIds {
+			offsets[offsetTagId] = shift.DefaultOffset
+
+			if offsetIb == nil {
+				continue
+			}
+
+			offsetPhrase, found, err := builderTagString(offsetIb, offsetTagId)
+			log.PanicIf(err)
+
+			offsetPhrases[offsetTagId] = offsetPhrase
+
+			if found == true && isBlankTimestamp(offsetPhrase) == false {
+				offsets[offsetTagId], err = ParseExifUtcOffset(offsetPhrase)
+				log.PanicIf(err)
+			}
+		}
+	}
+
+	updatedOffsetTagIds := make(map[uint16]struct{})
+
+	for _, tt := range shiftedTimestampTags {
+		targetIb, err := FindIbFromRootIb(ib, tt.fqIfdPath)
+		if log.Is(err, ErrChildIbNotFound) == true {
+			continue
+		} else if err != nil {
+			log.Panic(err)
+		}
+
+		phrase, found, err := builderTagString(targetIb, tt.tagId)
+		log.PanicIf(err)
+
+		if found == false || isBlankTimestamp(phrase) == true {
+			continue
+		}
+
+		timestamp, err := ParseExifFullTimestamp(phrase)
+		if err != nil {
+			ifdBuilderLogger.Warningf(nil, "Timestamp not valid: [%s] (0x%04x) [%s]", tt.fqIfdPath, tt.tagId, phrase)
+			log.Panic(ErrTimestampNotValid)
+		}
+
+		timestamp = timestamp.Add(shift.Duration)
+
+		if shift.Offset != nil {
+			timestamp = timestamp.Add(*shift.Offset - offsets[tt.offsetTagId])
+			updatedOffsetTagIds[tt.offsetTagId] = struct{}{}
+		}
+
+		st, err := setShiftedTimestamp(targetIb, tt.tagId, phrase, ExifFullTimestampString(timestamp))
+		log.PanicIf(err)
+
+		if st != nil {
+			shifted = append(shifted, *st)
+		}
+	}
+
+	// Only write the offsets for the timestamps that are actually present.
+	for _, offsetTagId := range offsetTagIds {
+		if _, found := updatedOffsetTagIds[offsetTagId]; found == false {
+			continue
+		}
+
+		if offsetIb == nil {
+			offsetIb, err = GetOrCreateIbFromRootIb(ib, exifFqIfdPath)
+			log.PanicIf(err)
+		}
+
+		st, err := setShiftedTimestamp(offsetIb, offsetTagId, offsetPhrases[offsetTagId], ExifUtcOffsetString(*shift.Offset))
+		log.PanicIf(err)
+
+		if st != nil {
+			shifted = append(shifted, *st)
+		}
+	}
+
+	if shift.IncludeGps == true && shift.Duration != 0 {
+		gpsShifted, err := shiftGpsTimestamp(ib, shift.Duration)
+		log.PanicIf(err)
+
+		shifted = append(shifted, gpsShifted...)
+	}
+
+	return shifted, nil
+}
+
+// setShiftedTimestamp sets the new value and describes the change, or returns
+// nil if there was no change.
+func setShiftedTimestamp(ib *IfdBuilder, tagId uint16, oldPhrase, newPhrase string) (st *ShiftedTimestamp, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if oldPhrase == newPhrase {
+		return nil, nil
+	}
+
+	it, err := ib.tagIndex.Get(ib.IfdIdentity(), tagId)
+	log.PanicIf(err)
+
+	err = ib.SetStandard(tagId, newPhrase)
+	log.PanicIf(err)
+
+	st = &ShiftedTimestamp{
+		FqIfdPath: ib.IfdIdentity().String(),
+		TagId:     tagId,
+		TagName:   it.Name,
+		OldValue:  oldPhrase,
+		NewValue:  newPhrase,
+	}
+
+	return st, nil
+}
+
+// shiftGpsTimestamp shifts the GPS time and, if present, the GPS date. The
+// date is rolled over as required. Fractional seconds are preserved.
+func shiftGpsTimestamp(rootIb *IfdBuilder, duration time.Duration) (shifted []ShiftedTimestamp, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	shifted = make([]ShiftedTimestamp, 0)
+
+	gpsIb, err := FindIbFromRootIb(rootIb, exifcommon.IfdGpsInfoStandardIfdIdentity.String())
+	if log.Is(err, ErrChildIbNotFound) == true {
+		return shifted, nil
+	} else if err != nil {
+		log.Panic(err)
+	}
+
+	timeBt, err := gpsIb.FindTag(TagTimestampId)
+	if log.Is(err, ErrTagEntryNotFound) == true {
+		// Without the time, we can not know whether the date needs to roll.
+		return shifted, nil
+	} else if err != nil {
+		log.Panic(err)
+	}
+
+	parser := new(exifcommon.Parser)
+
+	timeRaw, err := parser.ParseRationals(timeBt.value.Bytes(), 3, timeBt.byteOrder)
+	if err != nil || timeRaw[0].Denominator == 0 || timeRaw[1].Denominator == 0 || timeRaw[2].Denominator == 0 {
+		ifdBuilderLogger.Warningf(nil, "GPS time not valid: %v", timeRaw)
+		log.Panic(ErrTimestampNotValid)
+	}
+
+	datePhrase, hasDate, err := builderTagString(gpsIb, TagDatestampId)
+	log.PanicIf(err)
+
+	hasDate = hasDate == true && isBlankTimestamp(datePhrase) == false
+
+	timestamp := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if hasDate == true {
+		timestamp, err = time.Parse("2006:01:02", datePhrase)
+		if err != nil {
+			ifdBuilderLogger.Warningf(nil, "GPS date not valid: [%s]", datePhrase)
+			log.Panic(ErrTimestampNotValid)
+		}
+	}
+
+	secondsDenominator := uint64(timeRaw[2].Denominator)
+
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		component, ok := gpsTimeDuration(timeRaw[i], unit)
+		if ok == false {
+			ifdBuilderLogger.Warningf(nil, "GPS time not valid: %v", timeRaw)
+			log.Panic(ErrTimestampNotValid)
+		}
+
+		timestamp = timestamp.Add(component)
+	}
+
+	updated := timestamp.Add(duration)
+
+	updatedTimeRaw := []exifcommon.Rational{
+		{Numerator: uint32(updated.Hour()), Denominator: 1},
+		{Numerator: uint32(updated.Minute()), Denominator: 1},
+		gpsSecondsRational(updated.Second(), updated.Nanosecond(), secondsDenominator),
+	}
+
+	err = gpsIb.SetStandard(TagTimestampId, updatedTimeRaw)
+	log.PanicIf(err)
+
+	it, err := gpsIb.tagIndex.Get(gpsIb.IfdIdentity(), TagTimestampId)
+	log.PanicIf(err)
+
+	shifted = append(shifted, ShiftedTimestamp{
+		FqIfdPath: gpsIb.IfdIdentity().String(),
+		TagId:     TagTimestampId,
+		TagName:   it.Name,
+		OldValue:  timestamp.Format("15:04:05.999999999"),
+		NewValue:  updated.Format("15:04:05.999999999"),
+	})
+
+	if hasDate == true {
+		updatedDatePhrase := updated.Format("2006:01:02")
+
+		st, err := setShiftedTimestamp(gpsIb, TagDatestampId, datePhrase, updatedDatePhrase)
+		log.PanicIf(err)
+
+		if st != nil {
+			shifted = append(shifted, *st)
+		}
+	}
+
+	return shifted, nil
+}
+
+// gpsTimeDuration returns the duration of one component (hours, minutes, or
+// seconds) of the GPS time, including any fraction. Returns false if it is
+// too large to be represented.
+func gpsTimeDuration(component exifcommon.Rational, unit time.Duration) (duration time.Duration, ok bool) {
+	denominator := uint64(component.Denominator)
+
+	whole := uint64(component.Numerator) / denominator
+	if whole > uint64(math.MaxInt64)/uint64(unit) {
+		return 0, false
+	}
+
+	// The remainder is less than the denominator, so the quotient is less
+	// than the unit and the division can't overflow.
+	hi, lo := bits.Mul64(uint64(component.Numerator)%denominator, uint64(unit))
+	fraction, _ := bits.Div64(hi, lo, denominator)
+
+	return time.Duration(whole)*unit + time.Duration(fraction), true
+}
+
+// gpsSecondsRational returns the seconds as a rational with the given
+// denominator. The whole seconds and the fraction are scaled separately so
+// that nothing overflows. If the numerator doesn't fit, the denominator is
+// reduced (losing some precision).
+func gpsSecondsRational(seconds, nanoseconds int, denominator uint64) exifcommon.Rational {
+	numerator := uint64(seconds)*denominator + uint64(nanoseconds)*denominator/uint64(time.Second)
+
+	for numerator > math.MaxUint32 && denominator > 1 {
+		numerator /= 10
+		denominator /= 10
+	}
+
+	return exifcommon.Rational{
+		Numerator:   uint32(numerator),
+		Denominator: uint32(denominator),
+	}
+}
+
+// builderTagString returns the value of an ASCII tag.
+func builderTagString(ib *IfdBuilder, tagId uint16) (phrase string, found bool, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	bt, err := ib.FindTag(tagId)
+	if log.Is(err, ErrTagEntryNotFound) == true {
+		return "", false, nil
+	} else if err != nil {
+		log.Panic(err)
+	}
+
+	if bt.value.IsBytes() == false {
+		log.Panicf("tag (0x%04x) is not a string", tagId)
+	}
+
+	phrase = strings.TrimRight(string(bt.value.Bytes()), "\000")
+
+	return phrase, true, nil
+}
+
+// isBlankTimestamp returns true for an empty timestamp or for one with only
+// spaces and separators, which is how an unknown time is recorded.
+func isBlankTimestamp(phrase string) bool {
+	return strings.Trim(phrase, " :+-") == ""
+}
+
+// ParseExifUtcOffset parses a UTC-offset as stored in the OffsetTime tags
+// (e.g. "+09:00"). Offsets outside of -12:00 to +14:00 are not valid.
+func ParseExifUtcOffset(offsetPhrase string) (offset time.Duration, err error) {
+	if len(offsetPhrase) != 6 || (offsetPhrase[0] != '+' && offsetPhrase[0] != '-') || offsetPhrase[3] != ':' {
+		return 0, ErrTimestampNotValid
+	}
+
+	hours, err1 := strconv.ParseUint(offsetPhrase[1:3], 10, 8)
+	minutes, err2 := strconv.ParseUint(offsetPhrase[4:6], 10, 8)
+
+	if err1 != nil || err2 != nil || minutes >= 60 {
+		return 0, ErrTimestampNotValid
+	}
+
+	offset = time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+
+	if offsetPhrase[0] == '-' {
+		offset = -offset
+	}
+
+	if offset < minimumUtcOffset || offset > maximumUtcOffset {
+		return 0, ErrTimestampNotValid
+	}
+
+	return offset, nil
+}
+
+// ExifUtcOffsetString formats a UTC-offset the way that the OffsetTime tags
+// store it (e.g. "+09:00").
+func ExifUtcOffsetString(offset time.Duration) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	offset = offset.Round(time.Minute)
+
+	hours := int(offset / time.Hour)
+	minutes := int((offset % time.Hour) / time.Minute)
+
+	return fmt.Sprintf("%c%02d:%02d", sign, hours, minutes)
+}
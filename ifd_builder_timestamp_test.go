@@ -0,0 +1,301 @@
+package exif
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func getTimestampTestString(t *testing.T, rootIb *IfdBuilder, fqIfdPath string, tagId uint16) string {
+	ib, err := FindIbFromRootIb(rootIb, fqIfdPath)
+	log.PanicIf(err)
+
+	phrase, found, err := builderTagString(ib, tagId)
+	log.PanicIf(err)
+
+	if found == false {
+		t.Fatalf("Tag (0x%04x) not found in [%s].", tagId, fqIfdPath)
+	}
+
+	return phrase
+}
+
+func TestIfdBuilder_ShiftTimestamps_Duration(t *testing.T) {
+	rootIb := getTestRootIb()
+
+	shift := TimestampShift{
+		Duration: -25 * time.Hour,
+	}
+
+	shifted, err := rootIb.ShiftTimestamps(shift)
+	log.PanicIf(err)
+
+	if len(shifted) != 3 {
+		t.Fatalf("Shifted count not correct: %v", shifted)
+	}
+
+	expected := []struct {
+		fqIfdPath string
+		tagId     uint16
+	}{
+		{"IFD", TagDateTime},
+		{"IFD/Exif", TagDateTimeOriginal},
+		{"IFD/Exif", TagDateTimeDigitized},
+	}
+
+	for _, e := range expected {
+		if phrase := getTimestampTestString(t, rootIb, e.fqIfdPath, e.tagId); phrase != "2017:12:01 07:18:50" {
+			t.Fatalf("Timestamp (0x%04x) not correct: [%s]", e.tagId, phrase)
+		}
+	}
+
+	if shifted[0].TagName != "DateTime" || shifted[0].OldValue != "2017:12:02 08:18:50" || shifted[0].NewValue != "2017:12:01 07:18:50" {
+		t.Fatalf("Shift not described correctly: %s", shifted[0])
+	}
+
+	exifIb, err := FindIbFromRootIb(rootIb, "IFD/Exif")
+	log.PanicIf(err)
+
+	if _, err := exifIb.FindTag(TagOffsetTime); log.Is(err, ErrTagEntryNotFound) == false {
+		t.Fatalf("Offset should not have been added without an offset change.")
+	}
+}
+
+func TestIfdBuilder_ShiftTimestamps_Offset(t *testing.T) {
+	rootIb := getTestRootIb()
+
+	exifIb, err := FindIbFromRootIb(rootIb, "IFD/Exif")
+	log.PanicIf(err)
+
+	err = exifIb.SetStandard(TagOffsetTimeOriginal, "+02:00")
+	log.PanicIf(err)
+
+	offset := 9 * time.Hour
+
+	shift := TimestampShift{
+		Offset:        &offset,
+		DefaultOffset: -1 * time.Hour,
+	}
+
+	_, err = rootIb.ShiftTimestamps(shift)
+	log.PanicIf(err)
+
+	// DateTime and DateTimeDigitized have no offset, so the default is used.
+	if phrase := getTimestampTestString(t, rootIb, "IFD", TagDateTime); phrase != "2017:12:02 18:18:50" {
+		t.Fatalf("DateTime not correct: [%s]", phrase)
+	} else if phrase := getTimestampTestString(t, rootIb, "IFD/Exif", TagDateTimeDigitized); phrase != "2017:12:02 18:18:50" {
+		t.Fatalf("DateTimeDigitized not correct: [%s]", phrase)
+	} else if phrase := getTimestampTestString(t, rootIb, "IFD/Exif", TagDateTimeOriginal); phrase != "2017:12:02 15:18:50" {
+		t.Fatalf("DateTimeOriginal not correct: [%s]", phrase)
+	}
+
+	for _, tagId := range offsetTagIds {
+		if phrase := getTimestampTestString(t, rootIb, "IFD/Exif", tagId); phrase != "+09:00" {
+			t.Fatalf("Offset (0x%04x) not correct: [%s]", tagId, phrase)
+		}
+	}
+
+	// Make sure that it all encodes.
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	index := getTestIndex(exifData)
+
+	if value := getTestTagValue(t, index, "IFD/Exif", TagOffsetTime); value != "+09:00" {
+		t.Fatalf("Encoded offset not correct: [%v]", value)
+	}
+}
+
+func TestIfdBuilder_ShiftTimestamps_GpsRollover(t *testing.T) {
+	rootIb := getTestRootIb()
+
+	gpsIb, err := FindIbFromRootIb(rootIb, "IFD/GPSInfo")
+	log.PanicIf(err)
+
+	err = gpsIb.SetStandard(TagDatestampId, "2017:12:31")
+	log.PanicIf(err)
+
+	gpsTime := []exifcommon.Rational{
+		{Numerator: 23, Denominator: 1},
+		{Numerator: 30, Denominator: 1},
+		{Numerator: 31, Denominator: 2},
+	}
+
+	err = gpsIb.SetStandard(TagTimestampId, gpsTime)
+	log.PanicIf(err)
+
+	shift := TimestampShift{
+		Duration:   time.Hour,
+		IncludeGps: true,
+	}
+
+	shifted, err := rootIb.ShiftTimestamps(shift)
+	log.PanicIf(err)
+
+	if len(shifted) != 5 {
+		t.Fatalf("Shifted count not correct: %v", shifted)
+	}
+
+	if phrase := getTimestampTestString(t, rootIb, "IFD/GPSInfo", TagDatestampId); phrase != "2018:01:01" {
+		t.Fatalf("GPS date not rolled over: [%s]", phrase)
+	}
+
+	bt, err := gpsIb.FindTag(TagTimestampId)
+	log.PanicIf(err)
+
+	parser := new(exifcommon.Parser)
+
+	updatedTime, err := parser.ParseRationals(bt.value.Bytes(), 3, bt.byteOrder)
+	log.PanicIf(err)
+
+	expectedTime := []exifcommon.Rational{
+		{Numerator: 0, Denominator: 1},
+		{Numerator: 30, Denominator: 1},
+		{Numerator: 31, Denominator: 2},
+	}
+
+	if reflect.DeepEqual(updatedTime, expectedTime) != true {
+		t.Fatalf("GPS time not correct: %v", updatedTime)
+	}
+}
+
+func TestIfdBuilder_ShiftTimestamps_GpsFractionalTime(t *testing.T) {
+	rootIb := getTestRootIb()
+
+	gpsIb, err := FindIbFromRootIb(rootIb, "IFD/GPSInfo")
+	log.PanicIf(err)
+
+	// 10.5 hours, 15.25 minutes, and 0 seconds is 10:45:15.
+
+	gpsTime := []exifcommon.Rational{
+		{Numerator: 21, Denominator: 2},
+		{Numerator: 61, Denominator: 4},
+		{Numerator: 0, Denominator: 1},
+	}
+
+	err = gpsIb.SetStandard(TagTimestampId, gpsTime)
+	log.PanicIf(err)
+
+	shift := TimestampShift{
+		Duration:   time.Hour,
+		IncludeGps: true,
+	}
+
+	_, err = rootIb.ShiftTimestamps(shift)
+	log.PanicIf(err)
+
+	bt, err := gpsIb.FindTag(TagTimestampId)
+	log.PanicIf(err)
+
+	parser := new(exifcommon.Parser)
+
+	updatedTime, err := parser.ParseRationals(bt.value.Bytes(), 3, bt.byteOrder)
+	log.PanicIf(err)
+
+	expectedTime := []exifcommon.Rational{
+		{Numerator: 11, Denominator: 1},
+		{Numerator: 45, Denominator: 1},
+		{Numerator: 15, Denominator: 1},
+	}
+
+	if reflect.DeepEqual(updatedTime, expectedTime) != true {
+		t.Fatalf("GPS time not correct: %v", updatedTime)
+	}
+}
+
+func TestGpsTimeDuration(t *testing.T) {
+	duration, ok := gpsTimeDuration(exifcommon.Rational{Numerator: 4294967295, Denominator: 4294967294}, time.Hour)
+	if ok != true {
+		t.Fatalf("Expected a duration.")
+	} else if duration <= time.Hour || duration > time.Hour+time.Millisecond {
+		t.Fatalf("Duration not correct: %v", duration)
+	}
+
+	_, ok = gpsTimeDuration(exifcommon.Rational{Numerator: 4294967295, Denominator: 1}, time.Hour)
+	if ok != false {
+		t.Fatalf("Expected overflow to be detected.")
+	}
+}
+
+func TestGpsSecondsRational(t *testing.T) {
+	// The naive calculation (59.5s in nanoseconds times the denominator)
+	// overflows 64 bits with a denominator this large.
+	r := gpsSecondsRational(59, 500000000, 4000000000)
+
+	if r.Denominator == 0 {
+		t.Fatalf("Denominator is zero.")
+	}
+
+	seconds := float64(r.Numerator) / float64(r.Denominator)
+	if seconds < 59.49 || seconds > 59.51 {
+		t.Fatalf("Seconds not correct: %v (%f)", r, seconds)
+	}
+
+	r = gpsSecondsRational(31, 500000000, 2)
+
+	if r.Numerator != 63 || r.Denominator != 2 {
+		t.Fatalf("Seconds not correct: %v", r)
+	}
+}
+
+func TestIfdBuilder_ShiftTimestamps_NotValid(t *testing.T) {
+	rootIb := getTestRootIb()
+
+	err := rootIb.SetStandard(TagDateTime, "yesterday")
+	log.PanicIf(err)
+
+	_, err = rootIb.ShiftTimestamps(TimestampShift{Duration: time.Hour})
+	if log.Is(err, ErrTimestampNotValid) == false {
+		t.Fatalf("Expected invalid timestamp: %v", err)
+	}
+}
+
+func TestIfdBuilder_ShiftTimestamps_NotRoot(t *testing.T) {
+	rootIb := getTestRootIb()
+
+	exifIb, err := FindIbFromRootIb(rootIb, "IFD/Exif")
+	log.PanicIf(err)
+
+	_, err = exifIb.ShiftTimestamps(TimestampShift{Duration: time.Hour, IncludeGps: true})
+	if err == nil {
+		t.Fatalf("Expected error for non-root IB.")
+	}
+
+	if phrase := getTimestampTestString(t, rootIb, "IFD/Exif", TagDateTimeOriginal); phrase != "2017:12:02 08:18:50" {
+		t.Fatalf("Timestamp should not have changed: [%s]", phrase)
+	}
+}
+
+func TestParseExifUtcOffset(t *testing.T) {
+	offset, err := ParseExifUtcOffset("-03:30")
+	log.PanicIf(err)
+
+	if offset != -3*time.Hour-30*time.Minute {
+		t.Fatalf("Offset not correct: %v", offset)
+	} else if phrase := ExifUtcOffsetString(offset); phrase != "-03:30" {
+		t.Fatalf("Offset not formatted correctly: [%s]", phrase)
+	}
+
+	if phrase := ExifUtcOffsetString(0); phrase != "+00:00" {
+		t.Fatalf("Zero offset not formatted correctly: [%s]", phrase)
+	}
+
+	for _, phrase := range []string{"+14:00", "-12:00"} {
+		if _, err := ParseExifUtcOffset(phrase); err != nil {
+			t.Fatalf("Expected valid offset for [%s]: %v", phrase, err)
+		}
+	}
+
+	for _, phrase := range []string{"", "03:30", "+3:30", "+03:60", "+03-30", "+14:01", "-12:30", "+99:00"} {
+		if _, err := ParseExifUtcOffset(phrase); err != ErrTimestampNotValid {
+			t.Fatalf("Expected invalid offset for [%s]: %v", phrase, err)
+		}
+	}
+}
@@ -27,11 +27,17 @@ var (
 	// ErrNoGpsTags means that no GPS info was found.
 	ErrNoGpsTags = errors.New("no gps tags")
 
-	// ErrTagTypeNotValid means that the tag-type is not valid.
-	ErrTagTypeNotValid = errors.New("tag type invalid")
+	// ErrTagTypeNotValid means that the tag-type is not valid. It is wrapped by
+	// `exifcommon.TypeError`.
+	ErrTagTypeNotValid = exifcommon.ErrTagTypeNotValid
 
-	// ErrOffsetInvalid means that the file offset is not valid.
-	ErrOffsetInvalid = errors.New("file offset invalid")
+	// ErrOffsetInvalid means that the file offset is not valid. It is wrapped
+	// by `exifcommon.OffsetError`.
+	//
+	// This is a breaking change: this error used to be returned as it is, but
+	// now only the `*exifcommon.OffsetError` that wraps it is. Comparing with
+	// `==` no longer matches; use `errors.Is(err, ErrOffsetInvalid)`.
+	ErrOffsetInvalid = exifcommon.ErrOffsetInvalid
 )
 
 var (
@@ -83,7 +89,12 @@ type byteParser struct {
 func newByteParser(addressableData []byte, byteOrder binary.ByteOrder, ifdOffset uint32) (bp *byteParser, err error) {
 
 	if ifdOffset >= uint32(len(addressableData)) {
-		return nil, ErrOffsetInvalid
+		oe := &exifcommon.OffsetError{
+			Offset:     ifdOffset,
+			DataLength: len(addressableData),
+		}
+
+		return nil, oe
 	}
 
 	// TODO(dustin): Add test
@@ -102,7 +113,9 @@ func newByteParser(addressableData []byte, byteOrder binary.ByteOrder, ifdOffset
 // next IFD when it's time to jump).
 func (bp *byteParser) getUint16() (value uint16, raw []byte, err error) {
 	raw, err = bp.getRawUint(2)
-	log.PanicIf(err)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	value = bp.byteOrder.Uint16(raw)
 	return value, raw, nil
@@ -113,7 +126,9 @@ func (bp *byteParser) getUint16() (value uint16, raw []byte, err error) {
 // next IFD when it's time to jump).
 func (bp *byteParser) getUint32() (value uint32, raw []byte, err error) {
 	raw, err = bp.getRawUint(4)
-	log.PanicIf(err)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	value = bp.byteOrder.Uint32(raw)
 	return value, raw, nil
@@ -122,12 +137,22 @@ func (bp *byteParser) getUint32() (value uint32, raw []byte, err error) {
 func (bp *byteParser) getRawUint(needBytes int) (raw []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	// TODO(dustin): Add test
 
+	if bp.buffer.Len() < needBytes {
+		te := &exifcommon.TruncatedError{
+			Offset:    bp.currentOffset,
+			Needed:    needBytes,
+			Available: bp.buffer.Len(),
+		}
+
+		return nil, te
+	}
+
 	offset := 0
 	raw = make([]byte, needBytes)
 
@@ -159,31 +184,67 @@ type IfdEnumerate struct {
 	tagIndex       *TagIndex
 	ifdMapping     *exifcommon.IfdMapping
 	furthestOffset uint32
+
+	limits ParseLimits
+	budget *parseBudget
+
+	codecs *exifundefined.Registry
+
+	options ScanOptions
+
+	// dataErr is the error, if any, from reading the data. It is returned by
+	// the first parse.
+	dataErr error
 }
 
-// NewIfdEnumerate returns a new instance of IfdEnumerate.
+// NewIfdEnumerate returns a new instance of IfdEnumerate. An error reading
+// from the scanner is returned by `Scan` or `Collect`.
 func NewIfdEnumerate(s *Scanner, ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, byteOrder binary.ByteOrder) *IfdEnumerate {
 	exifData := make([]byte, 0)
+
+	var dataErr error
 	if s != nil {
-		var err error
-		exifData, err = s.Peek(s.scanLimit)
-		log.PanicIf(err)
+		exifData, dataErr = s.Peek(s.scanLimit)
 	}
+
 	return &IfdEnumerate{
 		exifData:   exifData,
 		byteOrder:  byteOrder,
 		ifdMapping: ifdMapping,
 		tagIndex:   tagIndex,
+		limits:     DefaultParseLimits,
+		dataErr:    dataErr,
 	}
 }
 
+// SetParseLimits replaces the resource limits (`DefaultParseLimits`, by
+// default) that apply to each `Scan` and `Collect`.
+func (ie *IfdEnumerate) SetParseLimits(limits ParseLimits) {
+	ie.limits = limits
+}
+
+// ParseLimits returns the resource limits in effect.
+func (ie *IfdEnumerate) ParseLimits() ParseLimits {
+	return ie.limits
+}
+
+// SetCodecRegistry replaces the registry (`exifundefined.DefaultRegistry()`,
+// by default) that the values of undefined-type tags are decoded with.
+func (ie *IfdEnumerate) SetCodecRegistry(codecs *exifundefined.Registry) {
+	ie.codecs = codecs
+}
+
 func (ie *IfdEnumerate) getByteParser(ifdOffset uint32) (bp *byteParser, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
+	if ie.dataErr != nil {
+		return nil, ie.dataErr
+	}
+
 	bp, err =
 		newByteParser(
 			ie.exifData,
@@ -191,11 +252,7 @@ func (ie *IfdEnumerate) getByteParser(ifdOffset uint32) (bp *byteParser, err err
 			ifdOffset)
 
 	if err != nil {
-		if err == ErrOffsetInvalid {
-			return nil, err
-		}
-
-		log.Panic(err)
+		return nil, err
 	}
 
 	return bp, nil
@@ -204,31 +261,50 @@ func (ie *IfdEnumerate) getByteParser(ifdOffset uint32) (bp *byteParser, err err
 func (ie *IfdEnumerate) parseTag(ii *exifcommon.IfdIdentity, tagPosition int, bp *byteParser) (ite *IfdTagEntry, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
+	entryOffset := bp.CurrentOffset()
+
 	tagId, _, err := bp.getUint16()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	tagTypeRaw, _, err := bp.getUint16()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	tagType := exifcommon.TagTypePrimitive(tagTypeRaw)
 
 	unitCount, _, err := bp.getUint32()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	valueOffset, rawValueOffset, err := bp.getUint32()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	if tagType.IsValid() == false {
 		ite = &IfdTagEntry{
-			tagId:   tagId,
-			tagType: tagType,
+			ifdIdentity: ii,
+			tagId:       tagId,
+			tagType:     tagType,
+			entryOffset: entryOffset,
+		}
+
+		te := &exifcommon.TypeError{
+			IfdPath: ii.String(),
+			TagId:   tagId,
+			TagType: tagType,
+			Err:     ErrTagTypeNotValid,
 		}
 
-		log.Panic(ErrTagTypeNotValid)
+		return ite, te
 	}
 
 	ite = newIfdTagEntry(
@@ -242,6 +318,9 @@ func (ie *IfdEnumerate) parseTag(ii *exifcommon.IfdIdentity, tagPosition int, bp
 		ie.exifData,
 		ie.byteOrder)
 
+	ite.entryOffset = entryOffset
+	ite.codecs = ie.undefinedCodecs()
+
 	ifdPath := ii.UnindexedString()
 
 	// If it's an IFD but not a standard one, it'll just be seen as a LONG
@@ -270,10 +349,14 @@ func (ie *IfdEnumerate) parseTag(ii *exifcommon.IfdIdentity, tagPosition int, bp
 type TagVisitorFn func(fqIfdPath string, ifdIndex int, ite *IfdTagEntry) (err error)
 
 // postparseTag do some tag-level processing here following the parse of each.
+// It returns `ErrTagNotFound` if the tag is not known at all,
+// `ErrTagNotValidForIfd` if it is only known in another IFD, and
+// `ErrTagTypeMismatch` if its type is not one that the tag supports. The tag
+// is still usable in the latter two cases.
 func (ie *IfdEnumerate) postparseTag(ite *IfdTagEntry, med *MiscellaneousExifData) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -284,6 +367,8 @@ func (ie *IfdEnumerate) postparseTag(ite *IfdTagEntry, med *MiscellaneousExifDat
 	tagId := ite.TagId()
 	tagType := ite.TagType()
 
+	isNotValidForIfd := false
+
 	it, err := ie.tagIndex.Get(ii, tagId)
 	if err == nil {
 		ite.setTagName(it.Name)
@@ -323,22 +408,23 @@ func (ie *IfdEnumerate) postparseTag(ite *IfdTagEntry, med *MiscellaneousExifDat
 			// they want to specifically manage these types of tags, they
 			// can use more advanced functionality to specifically -handle
 			// unknown tags.
-			utilityLogger.Warningf(nil,
-				"Tag with ID (0x%04x) in IFD [%s] is not recognized and "+
-					"will be ignored.", tagId, ii.String())
+			med.addTagDiagnostic(
+				DiagnosticWarning, DiagnosticTagUnknown, ite,
+				"tag with ID (0x%04x) is not recognized in any IFD", tagId)
 
 			return ErrTagNotFound
 		}
 
 		ite.setTagName(it.Name)
 
-		utilityLogger.Warningf(nil,
-			"Tag with ID (0x%04x) is not valid for IFD [%s], but it *is* "+
-				"valid as tag [%s] under IFD [%s] and has the same type "+
-				"[%s], so we will use that. This EXIF blob was probably "+
-				"written by a buggy implementation.",
-			tagId, ii.UnindexedString(), it.Name, it.IfdPath,
-			tagType)
+		isNotValidForIfd = true
+
+		med.addTagDiagnostic(
+			DiagnosticWarning, DiagnosticTagNotValidForIfd, ite,
+			"tag with ID (0x%04x) is not valid for IFD [%s], but it is "+
+				"valid as tag [%s] under IFD [%s]; this EXIF blob was "+
+				"probably written by a buggy implementation",
+			tagId, ii.UnindexedString(), it.Name, it.IfdPath)
 
 		if med != nil {
 			med.unknownTags[originalBt] = exifcommon.BasicTag{
@@ -360,12 +446,16 @@ func (ie *IfdEnumerate) postparseTag(ite *IfdTagEntry, med *MiscellaneousExifDat
 	// type and caused parsing/conversion woes. So, this is a quick fix
 	// for those scenarios.
 	if it.DoesSupportType(tagType) == false {
-		ifdEnumerateLogger.Warningf(nil,
-			"Skipping tag [%s] (0x%04x) [%s] with an unexpected type: %v ∉ %v",
-			ii.UnindexedString(), tagId, it.Name,
-			tagType, it.SupportedTypes)
+		med.addTagDiagnostic(
+			DiagnosticWarning, DiagnosticTagTypeMismatch, ite,
+			"tag [%s] has an unexpected type: %v ∉ %v",
+			it.Name, tagType, it.SupportedTypes)
+
+		return ErrTagTypeMismatch
+	}
 
-		return ErrTagNotFound
+	if isNotValidForIfd == true {
+		return ErrTagNotValidForIfd
 	}
 
 	return nil
@@ -376,15 +466,29 @@ func (ie *IfdEnumerate) postparseTag(ite *IfdTagEntry, med *MiscellaneousExifDat
 func (ie *IfdEnumerate) parseIfd(ii *exifcommon.IfdIdentity, bp *byteParser, visitor TagVisitorFn, doDescend bool, med *MiscellaneousExifData) (nextIfdOffset uint32, entries []*IfdTagEntry, thumbnailData []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
-	tagCount, _, err := bp.getUint16()
+	if ie.budget == nil {
+		ie.budget = newParseBudget(ie.limits)
+	}
+
+	ifdOffset := bp.CurrentOffset()
+
+	err = ie.budget.enterIfd(ii, ifdOffset)
 	log.PanicIf(err)
 
+	tagCount, _, err := bp.getUint16()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
 	ifdEnumerateLogger.Debugf(nil, "IFD [%s] tag-count: (%d)", ii.String(), tagCount)
 
+	err = ie.budget.checkEntries(ii, ifdOffset, int(tagCount))
+	log.PanicIf(err)
+
 	entries = make([]*IfdTagEntry, 0)
 
 	var enumeratorThumbnailOffset *IfdTagEntry
@@ -393,24 +497,52 @@ func (ie *IfdEnumerate) parseIfd(ii *exifcommon.IfdIdentity, bp *byteParser, vis
 	for i := 0; i < int(tagCount); i++ {
 		ite, err := ie.parseTag(ii, i, bp)
 		if err != nil {
-			if log.Is(err, ErrTagTypeNotValid) == true {
+			if errors.Is(err, ErrTagTypeNotValid) == true {
 				// Technically, we have the type on-file in the tags-index, but
 				// if the type stored alongside the data disagrees with it,
 				// which it apparently does, all bets are off.
-				ifdEnumerateLogger.Warningf(nil, "Tag (0x%04x) in IFD [%s] at position (%d) has invalid type (%d) and will be skipped.", ite.tagId, ii, i, ite.tagType)
+				med.addTagDiagnostic(
+					DiagnosticError, DiagnosticTagTypeNotValid, ite,
+					"tag at position (%d) has invalid type (%d) and was skipped",
+					i, ite.tagType)
+
 				continue
 			}
 
-			log.Panic(err)
+			return 0, nil, nil, err
 		}
 
+		err = ie.budget.addValue(ii, ifdOffset, ite)
+		log.PanicIf(err)
+
 		err = ie.postparseTag(ite, med)
-		if err == nil {
+		if err != nil {
 			if err == ErrTagNotFound {
-				continue
+				// Private and vendor tags aren't in any index. They are only
+				// reported (as `DiagnosticTagUnknown`), even when strict.
+			} else if err == ErrTagNotValidForIfd {
+				if ie.options.Strict == true {
+					log.Panic(fmt.Errorf("%w: [%s] (0x%04x)", ErrTagNotValidForIfd, ii, ite.TagId()))
+				}
+			} else if err == ErrTagTypeMismatch {
+				if ie.options.Strict == true {
+					log.Panic(fmt.Errorf("%w: [%s] (0x%04x) [%s]", ErrTagTypeMismatch, ii, ite.TagId(), ite.TagType()))
+				}
+			} else {
+				log.Panic(err)
 			}
 
-			log.PanicIf(err)
+			// Otherwise, we're lenient and we keep it.
+		}
+
+		if isValueMisaligned(ite) == true {
+			med.addTagDiagnostic(
+				DiagnosticInfo, DiagnosticValueMisaligned, ite,
+				"value is at odd offset (0x%08x)", ite.getValueOffset())
+
+			if ie.options.Strict == true {
+				log.Panic(fmt.Errorf("%w: [%s] (0x%04x) at offset (0x%08x)", ErrValueMisaligned, ii, ite.TagId(), ite.getValueOffset()))
+			}
 		}
 
 		tagId := ite.TagId()
@@ -471,7 +603,9 @@ func (ie *IfdEnumerate) parseIfd(ii *exifcommon.IfdIdentity, bp *byteParser, vis
 				iiChild := ii.NewChild(childIfdTag, 0)
 
 				err := ie.scan(iiChild, ite.getValueOffset(), visitor, med)
-				log.PanicIf(err)
+				if err != nil {
+					return 0, nil, nil, err
+				}
 
 				ifdEnumerateLogger.Debugf(nil, "Ascending from IFD [%s] to IFD [%s].", ite.ChildIfdPath(), ii)
 			}
@@ -500,7 +634,9 @@ func (ie *IfdEnumerate) parseIfd(ii *exifcommon.IfdIdentity, bp *byteParser, vis
 	}
 
 	nextIfdOffset, _, err = bp.getUint32()
-	log.PanicIf(err)
+	if err != nil {
+		return 0, nil, nil, err
+	}
 
 	ifdEnumerateLogger.Debugf(nil, "Next IFD at offset: (%08x)", nextIfdOffset)
 
@@ -510,7 +646,7 @@ func (ie *IfdEnumerate) parseIfd(ii *exifcommon.IfdIdentity, bp *byteParser, vis
 func (ie *IfdEnumerate) parseThumbnail(offsetIte, lengthIte *IfdTagEntry) (thumbnailData []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -539,7 +675,7 @@ func (ie *IfdEnumerate) parseThumbnail(offsetIte, lengthIte *IfdTagEntry) (thumb
 func (ie *IfdEnumerate) scan(iiGeneral *exifcommon.IfdIdentity, ifdOffset uint32, visitor TagVisitorFn, med *MiscellaneousExifData) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -552,23 +688,34 @@ func (ie *IfdEnumerate) scan(iiGeneral *exifcommon.IfdIdentity, ifdOffset uint32
 
 		bp, err := ie.getByteParser(ifdOffset)
 		if err != nil {
-			if err == ErrOffsetInvalid {
-				ifdEnumerateLogger.Errorf(nil, nil, "IFD [%s] at offset (0x%04x) is unreachable. Terminating scan.", iiSibling.String(), ifdOffset)
+			if errors.Is(err, ErrOffsetInvalid) == true {
+				d := Diagnostic{
+					Severity:  DiagnosticError,
+					Code:      DiagnosticIfdUnreachable,
+					FqIfdPath: iiSibling.String(),
+					Offset:    ifdOffset,
+					Message:   "IFD offset is outside of the data; the scan of this chain was terminated",
+				}
+
+				med.addDiagnostic(d)
+
 				break
 			}
 
-			log.Panic(err)
+			return err
 		}
 
-		nextIfdOffset, _, _, err := ie.parseIfd(iiSibling, bp, visitor, true, med)
-		log.PanicIf(err)
+		nextIfdOffset, _, _, err := ie.parseIfd(iiSibling, bp, visitor, ie.options.SkipChildIfds == false, med)
+		if err != nil {
+			return err
+		}
 
 		currentOffset := bp.CurrentOffset()
 		if currentOffset > ie.furthestOffset {
 			ie.furthestOffset = currentOffset
 		}
 
-		if nextIfdOffset == 0 {
+		if nextIfdOffset == 0 || ie.options.SkipSiblingIfds == true {
 			break
 		}
 
@@ -584,9 +731,14 @@ type MiscellaneousExifData struct {
 	// IFDs. The values represent alternative IFDs that were correctly matched
 	// to those tags and used instead.
 	unknownTags map[exifcommon.BasicTag]exifcommon.BasicTag
+
+	// diagnostics are the problems found during the parse.
+	diagnostics []Diagnostic
 }
 
-// UnknownTags returns the unknown tags encountered during the scan.
+// UnknownTags returns the unknown tags encountered during the scan. These are
+// also reported by `Diagnostics()` with the `DiagnosticTagUnknown` and
+// `DiagnosticTagNotValidForIfd` codes.
 func (med *MiscellaneousExifData) UnknownTags() map[exifcommon.BasicTag]exifcommon.BasicTag {
 	return med.unknownTags
 }
@@ -596,7 +748,24 @@ func (med *MiscellaneousExifData) UnknownTags() map[exifcommon.BasicTag]exifcomm
 func (ie *IfdEnumerate) Scan(iiRoot *exifcommon.IfdIdentity, ifdOffset uint32, visitor TagVisitorFn) (med *MiscellaneousExifData, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
+		}
+	}()
+
+	med, err = ie.ScanWithOptions(iiRoot, ifdOffset, visitor, ScanOptions{})
+	if err != nil {
+		return med, err
+	}
+
+	return med, nil
+}
+
+// ScanWithOptions is `Scan` with control over how strict the parse is and
+// what is visited.
+func (ie *IfdEnumerate) ScanWithOptions(iiRoot *exifcommon.IfdIdentity, ifdOffset uint32, visitor TagVisitorFn, options ScanOptions) (med *MiscellaneousExifData, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -604,10 +773,15 @@ func (ie *IfdEnumerate) Scan(iiRoot *exifcommon.IfdIdentity, ifdOffset uint32, v
 
 	med = &MiscellaneousExifData{
 		unknownTags: make(map[exifcommon.BasicTag]exifcommon.BasicTag),
+		diagnostics: make([]Diagnostic, 0),
 	}
 
+	ie.startParse(options)
+
 	err = ie.scan(iiRoot, ifdOffset, visitor, med)
-	log.PanicIf(err)
+	if err != nil {
+		return med, err
+	}
 
 	ifdEnumerateLogger.Debugf(nil, "Scan: It looks like the furthest offset that contained EXIF data in the EXIF blob was (%d) (Scan).", ie.FurthestOffset())
 
@@ -649,6 +823,7 @@ type Ifd struct {
 
 	ifdMapping *exifcommon.IfdMapping
 	tagIndex   *TagIndex
+	codecs     *exifundefined.Registry
 }
 
 // IfdIdentity returns IFD identity that this struct represents.
@@ -661,7 +836,7 @@ func (ifd *Ifd) IfdIdentity() *exifcommon.IfdIdentity {
 func (ifd *Ifd) ChildWithIfdPath(iiChild *exifcommon.IfdIdentity) (childIfd *Ifd, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -683,7 +858,7 @@ func (ifd *Ifd) ChildWithIfdPath(iiChild *exifcommon.IfdIdentity) (childIfd *Ifd
 func (ifd *Ifd) FindTagWithId(tagId uint16) (results []*IfdTagEntry, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -700,7 +875,7 @@ func (ifd *Ifd) FindTagWithId(tagId uint16) (results []*IfdTagEntry, err error)
 func (ifd *Ifd) FindTagWithName(tagName string) (results []*IfdTagEntry, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -972,7 +1147,7 @@ func (ifd *Ifd) DumpTree() []string {
 func (ifd *Ifd) GpsInfo() (gi *GpsInfo, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1117,7 +1292,7 @@ type ParsedTagVisitor func(*Ifd, *IfdTagEntry) error
 func (ifd *Ifd) EnumerateTagsRecursively(visitor ParsedTagVisitor) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1159,6 +1334,10 @@ type IfdIndex struct {
 	Ifds    []*Ifd
 	Tree    map[int]*Ifd
 	Lookup  map[string]*Ifd
+
+	// MiscellaneousExifData is the additional data collected during the
+	// parse.
+	MiscellaneousExifData *MiscellaneousExifData
 }
 
 // Collect enumerates the different EXIF blocks (called IFDs) and builds out an
@@ -1166,11 +1345,33 @@ type IfdIndex struct {
 func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
+		}
+	}()
+
+	index, err = ie.CollectWithOptions(rootIfdOffset, ScanOptions{})
+	if err != nil {
+		return index, err
+	}
+
+	return index, nil
+}
+
+// CollectWithOptions is `Collect` with control over how strict the parse is
+// and what is collected.
+func (ie *IfdEnumerate) CollectWithOptions(rootIfdOffset uint32, options ScanOptions) (index IfdIndex, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
 		}
 	}()
 
-	// TODO(dustin): Add MiscellaneousExifData to IfdIndex
+	med := &MiscellaneousExifData{
+		unknownTags: make(map[exifcommon.BasicTag]exifcommon.BasicTag),
+		diagnostics: make([]Diagnostic, 0),
+	}
+
+	ie.startParse(options)
 
 	tree := make(map[int]*Ifd)
 	ifds := make([]*Ifd, 0)
@@ -1202,17 +1403,20 @@ func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error
 
 		bp, err := ie.getByteParser(offset)
 		if err != nil {
-			if err == ErrOffsetInvalid {
-				return index, err
+			var oe *exifcommon.OffsetError
+			if errors.As(err, &oe) == true {
+				oe.IfdPath = ii.String()
 			}
 
-			log.Panic(err)
+			return index, err
 		}
 
 		// TODO(dustin): We don't need to pass the index in as a separate argument. Get from the II.
 
-		nextIfdOffset, entries, thumbnailData, err := ie.parseIfd(ii, bp, nil, false, nil)
-		log.PanicIf(err)
+		nextIfdOffset, entries, thumbnailData, err := ie.parseIfd(ii, bp, nil, false, med)
+		if err != nil {
+			return index, err
+		}
 
 		currentOffset := bp.CurrentOffset()
 		if currentOffset > ie.furthestOffset {
@@ -1255,6 +1459,7 @@ func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error
 
 			ifdMapping: ie.ifdMapping,
 			tagIndex:   ie.tagIndex,
+			codecs:     ie.undefinedCodecs(),
 		}
 
 		// Add ourselves to a big list of IFDs.
@@ -1279,7 +1484,7 @@ func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error
 
 		// Determine if any of our entries is a child IFD and queue it.
 		for i, ite := range entries {
-			if ite.ChildIfdPath() == "" {
+			if ite.ChildIfdPath() == "" || ie.options.SkipChildIfds == true {
 				continue
 			}
 
@@ -1308,7 +1513,7 @@ func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error
 		}
 
 		// If there's another IFD in the chain.
-		if nextIfdOffset != 0 {
+		if nextIfdOffset != 0 && ie.options.SkipSiblingIfds == false {
 			iiSibling := ii.NewSibling(ii.Index() + 1)
 
 			// Allow the next link to know what the previous link was.
@@ -1327,6 +1532,7 @@ func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error
 	index.Ifds = ifds
 	index.Tree = tree
 	index.Lookup = lookup
+	index.MiscellaneousExifData = med
 
 	err = ie.setChildrenIndex(index.RootIfd)
 	log.PanicIf(err)
@@ -1339,7 +1545,7 @@ func (ie *IfdEnumerate) Collect(rootIfdOffset uint32) (index IfdIndex, err error
 func (ie *IfdEnumerate) setChildrenIndex(ifd *Ifd) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1381,7 +1587,7 @@ func (ie *IfdEnumerate) FurthestOffset() uint32 {
 func ParseOneIfd(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exifcommon.IfdIdentity, byteOrder binary.ByteOrder, ifdBlock []byte, visitor TagVisitorFn) (nextIfdOffset uint32, entries []*IfdTagEntry, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1389,15 +1595,13 @@ func ParseOneIfd(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exif
 
 	bp, err := newByteParser(ifdBlock, byteOrder, 0)
 	if err != nil {
-		if err == ErrOffsetInvalid {
-			return 0, nil, err
-		}
-
-		log.Panic(err)
+		return 0, nil, err
 	}
 
 	nextIfdOffset, entries, _, err = ie.parseIfd(ii, bp, visitor, true, nil)
-	log.PanicIf(err)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	return nextIfdOffset, entries, nil
 }
@@ -1406,7 +1610,7 @@ func ParseOneIfd(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exif
 func ParseOneTag(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exifcommon.IfdIdentity, byteOrder binary.ByteOrder, tagBlock []byte) (ite *IfdTagEntry, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -1414,23 +1618,21 @@ func ParseOneTag(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exif
 
 	bp, err := newByteParser(tagBlock, byteOrder, 0)
 	if err != nil {
-		if err == ErrOffsetInvalid {
-			return nil, err
-		}
-
-		log.Panic(err)
+		return nil, err
 	}
 
 	ite, err = ie.parseTag(ii, 0, bp)
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	err = ie.postparseTag(ite, nil)
 	if err != nil {
-		if err == ErrTagNotFound {
-			return nil, err
+		if err == ErrTagNotFound || err == ErrTagTypeMismatch {
+			return nil, ErrTagNotFound
+		} else if err != ErrTagNotValidForIfd {
+			log.Panic(err)
 		}
-
-		log.Panic(err)
 	}
 
 	return ite, nil
@@ -1441,7 +1643,7 @@ func ParseOneTag(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, ii *exif
 func FindIfdFromRootIfd(rootIfd *Ifd, ifdPath string) (ifd *Ifd, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -0,0 +1,207 @@
+package exif
+
+import (
+	"fmt"
+)
+
+// DiagnosticSeverity describes how much a diagnostic matters.
+type DiagnosticSeverity int
+
+const (
+	// DiagnosticInfo is a deviation from the standard that has no effect on
+	// the data.
+	DiagnosticInfo DiagnosticSeverity = iota
+
+	// DiagnosticWarning is a deviation from the standard that was worked
+	// around. The data is still available.
+	DiagnosticWarning
+
+	// DiagnosticError is a problem that caused data to be skipped.
+	DiagnosticError
+)
+
+// String returns the name of the severity.
+func (ds DiagnosticSeverity) String() string {
+	switch ds {
+	case DiagnosticInfo:
+		return "info"
+	case DiagnosticWarning:
+		return "warning"
+	case DiagnosticError:
+		return "error"
+	}
+
+	return fmt.Sprintf("DiagnosticSeverity(%d)", int(ds))
+}
+
+// MarshalText renders the severity by name.
+func (ds DiagnosticSeverity) MarshalText() (text []byte, err error) {
+	return []byte(ds.String()), nil
+}
+
+// UnmarshalText parses the name of a severity.
+func (ds *DiagnosticSeverity) UnmarshalText(text []byte) (err error) {
+	for _, severity := range []DiagnosticSeverity{DiagnosticInfo, DiagnosticWarning, DiagnosticError} {
+		if string(text) == severity.String() {
+			*ds = severity
+			return nil
+		}
+	}
+
+	return fmt.Errorf("diagnostic severity [%s] not valid", string(text))
+}
+
+// DiagnosticCode identifies the kind of problem that a diagnostic describes.
+type DiagnosticCode string
+
+const (
+	// DiagnosticTagUnknown is a tag that is not known in any IFD. It is kept.
+	DiagnosticTagUnknown DiagnosticCode = "tag-unknown"
+
+	// DiagnosticTagNotValidForIfd is a tag that is only known in another IFD.
+	// It is kept and named after the tag in the other IFD. These are also
+	// returned by `MiscellaneousExifData.UnknownTags()`.
+	DiagnosticTagNotValidForIfd DiagnosticCode = "tag-not-valid-for-ifd"
+
+	// DiagnosticTagTypeMismatch is a tag with a type that the tag does not
+	// support. It is kept.
+	DiagnosticTagTypeMismatch DiagnosticCode = "tag-type-mismatch"
+
+	// DiagnosticTagTypeNotValid is a tag with a type that is not a TIFF type.
+	// It is skipped.
+	DiagnosticTagTypeNotValid DiagnosticCode = "tag-type-not-valid"
+
+	// DiagnosticValueMisaligned is a value that is stored at an odd offset.
+	DiagnosticValueMisaligned DiagnosticCode = "value-misaligned"
+
+	// DiagnosticValueUnparseable is an undefined-type value that could not be
+	// decoded. It is skipped.
+	DiagnosticValueUnparseable DiagnosticCode = "value-unparseable"
+
+	// DiagnosticIfdUnreachable is an IFD whose offset is outside of the data
+	// (or, when salvaging, does not point to an IFD). It, and any IFDs after
+	// it, are skipped.
+	DiagnosticIfdUnreachable DiagnosticCode = "ifd-unreachable"
+
+	// DiagnosticHeaderCorrupt is a header that is not valid or that does not
+	// point to an IFD. Only reported when salvaging.
+	DiagnosticHeaderCorrupt DiagnosticCode = "header-corrupt"
+
+	// DiagnosticIfdTruncated is an IFD table that is cut off by the end of the
+	// data. Only reported when salvaging.
+	DiagnosticIfdTruncated DiagnosticCode = "ifd-truncated"
+
+	// DiagnosticIfdResynced is an IFD that was found by searching because the
+	// pointer to it was not valid. Only reported when salvaging.
+	DiagnosticIfdResynced DiagnosticCode = "ifd-resynced"
+
+	// DiagnosticIfdSkipped is an IFD that was skipped because of a cycle or a
+	// parse limit. Only reported when salvaging.
+	DiagnosticIfdSkipped DiagnosticCode = "ifd-skipped"
+
+	// DiagnosticValueTruncated is a value that is cut off by the end of the
+	// data. Only reported when salvaging.
+	DiagnosticValueTruncated DiagnosticCode = "value-truncated"
+
+	// DiagnosticValueUnreachable is a value whose offset is outside of the
+	// data. The tag is skipped. Only reported when salvaging.
+	DiagnosticValueUnreachable DiagnosticCode = "value-unreachable"
+
+	// DiagnosticTagRequired is a tag that the standard requires but that is
+	// missing. Only reported by `ValidateConformance`.
+	DiagnosticTagRequired DiagnosticCode = "tag-required"
+
+	// DiagnosticTagCountNotValid is a tag with a unit-count that the standard
+	// does not allow. Only reported by `ValidateConformance`.
+	DiagnosticTagCountNotValid DiagnosticCode = "tag-count-not-valid"
+
+	// DiagnosticTagOrderNotValid is a tag that is not in ascending order of
+	// tag-ID within its IFD. Only reported by `ValidateConformance`.
+	DiagnosticTagOrderNotValid DiagnosticCode = "tag-order-not-valid"
+
+	// DiagnosticValueOutOfRange is a value that the standard does not define.
+	// Only reported by `ValidateConformance`.
+	DiagnosticValueOutOfRange DiagnosticCode = "value-out-of-range"
+
+	// DiagnosticValueFormatNotValid is a date, time, or version that is not
+	// in the format that the standard requires. Only reported by
+	// `ValidateConformance`.
+	DiagnosticValueFormatNotValid DiagnosticCode = "value-format-not-valid"
+
+	// DiagnosticAsciiNotTerminated is an ASCII value that does not end with a
+	// NUL. Only reported by `ValidateConformance`.
+	DiagnosticAsciiNotTerminated DiagnosticCode = "ascii-not-terminated"
+
+	// DiagnosticUtf8NotValid is a UTF-8 value that does not end with a NUL
+	// or that is not valid UTF-8. Only reported by `ValidateConformance`.
+	DiagnosticUtf8NotValid DiagnosticCode = "utf8-not-valid"
+)
+
+// Diagnostic describes one problem found during a parse.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Code     DiagnosticCode     `json:"code"`
+
+	// FqIfdPath is the fully-qualified path of the IFD.
+	FqIfdPath string `json:"ifd_path,omitempty"`
+
+	// TagId is the ID of the tag, or zero if the problem is with the IFD.
+	TagId uint16 `json:"tag_id,omitempty"`
+
+	// Offset is the offset of the tag entry or, if the problem is with the
+	// IFD, of the IFD.
+	Offset uint32 `json:"offset"`
+
+	Message string `json:"message"`
+}
+
+// String returns a description of the diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("Diagnostic<SEVERITY=[%s] CODE=[%s] IFD=[%s] TAG-ID=(0x%04x) OFFSET=(0x%08x) MESSAGE=[%s]>", d.Severity, d.Code, d.FqIfdPath, d.TagId, d.Offset, d.Message)
+}
+
+// addDiagnostic records a diagnostic. `med` may be `nil`, in which case it is
+// just logged.
+func (med *MiscellaneousExifData) addDiagnostic(d Diagnostic) {
+	ifdEnumerateLogger.Debugf(nil, "%s", d)
+
+	if med == nil {
+		return
+	}
+
+	med.diagnostics = append(med.diagnostics, d)
+}
+
+// addTagDiagnostic records a diagnostic for a tag.
+func (med *MiscellaneousExifData) addTagDiagnostic(severity DiagnosticSeverity, code DiagnosticCode, ite *IfdTagEntry, format string, args ...interface{}) {
+	d := Diagnostic{
+		Severity:  severity,
+		Code:      code,
+		FqIfdPath: ite.ifdIdentity.String(),
+		TagId:     ite.tagId,
+		Offset:    ite.entryOffset,
+		Message:   fmt.Sprintf(format, args...),
+	}
+
+	med.addDiagnostic(d)
+}
+
+// Diagnostics returns the problems found during the parse, in the order that
+// they were found.
+func (med *MiscellaneousExifData) Diagnostics() []Diagnostic {
+	return med.diagnostics
+}
+
+// DiagnosticsWithSeverity returns the diagnostics that are at least as severe
+// as `severity`.
+func (med *MiscellaneousExifData) DiagnosticsWithSeverity(severity DiagnosticSeverity) []Diagnostic {
+	filtered := make([]Diagnostic, 0)
+
+	for _, d := range med.diagnostics {
+		if d.Severity >= severity {
+			filtered = append(filtered, d)
+		}
+	}
+
+	return filtered
+}
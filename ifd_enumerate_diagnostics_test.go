@@ -0,0 +1,161 @@
+package exif
+
+import (
+	"testing"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func TestMiscellaneousExifData_Diagnostics(t *testing.T) {
+	entries := []optionsTestEntry{
+		// Only valid in the EXIF IFD.
+		{0xa002, exifcommon.TypeLong, 1, 100},
+
+		// Not valid anywhere.
+		{0xfffe, exifcommon.TypeLong, 1, 0},
+
+		// ImageWidth is SHORT or LONG.
+		{0x0100, exifcommon.TypeRational, 1, 82},
+
+		// Not a TIFF type.
+		{0x0101, exifcommon.TagTypePrimitive(99), 1, 0},
+
+		// BitsPerSample, at an odd offset.
+		{0x0102, exifcommon.TypeShort, 3, 83},
+	}
+
+	exifData := getOptionsTestExifData(entries, make([]byte, 16))
+
+	exifTags, med, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{})
+	log.PanicIf(err)
+
+	if len(exifTags) != 4 {
+		t.Fatalf("Tag count not correct: (%d)", len(exifTags))
+	}
+
+	expected := []struct {
+		severity DiagnosticSeverity
+		code     DiagnosticCode
+		tagId    uint16
+		position int
+	}{
+		{DiagnosticWarning, DiagnosticTagNotValidForIfd, 0xa002, 0},
+		{DiagnosticWarning, DiagnosticTagUnknown, 0xfffe, 1},
+		{DiagnosticWarning, DiagnosticTagTypeMismatch, 0x0100, 2},
+		{DiagnosticError, DiagnosticTagTypeNotValid, 0x0101, 3},
+		{DiagnosticInfo, DiagnosticValueMisaligned, 0x0102, 4},
+	}
+
+	diagnostics := med.Diagnostics()
+
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Diagnostic count not correct: %v", diagnostics)
+	}
+
+	for i, e := range expected {
+		d := diagnostics[i]
+
+		if d.Severity != e.severity || d.Code != e.code || d.TagId != e.tagId {
+			t.Fatalf("Diagnostic (%d) not correct: %s", i, d)
+		} else if d.FqIfdPath != "IFD" {
+			t.Fatalf("Diagnostic (%d) IFD not correct: %s", i, d)
+		} else if d.Offset != uint32(8+2+12*e.position) {
+			t.Fatalf("Diagnostic (%d) offset not correct: %s", i, d)
+		} else if d.Message == "" {
+			t.Fatalf("Diagnostic (%d) has no message: %s", i, d)
+		}
+	}
+
+	// The unknown tags are one category of diagnostic.
+
+	unknownTags := med.UnknownTags()
+	if len(unknownTags) != 2 {
+		t.Fatalf("Unknown tags not correct: %v", unknownTags)
+	}
+
+	errorDiagnostics := med.DiagnosticsWithSeverity(DiagnosticError)
+	if len(errorDiagnostics) != 1 || errorDiagnostics[0].Code != DiagnosticTagTypeNotValid {
+		t.Fatalf("Error diagnostics not correct: %v", errorDiagnostics)
+	}
+
+	warningDiagnostics := med.DiagnosticsWithSeverity(DiagnosticWarning)
+	if len(warningDiagnostics) != 4 {
+		t.Fatalf("Warning diagnostics not correct: %v", warningDiagnostics)
+	}
+
+	// Collect should report the same things.
+
+	s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, index, err := Collect(s, NewIfdMappingWithStandard(), NewTagIndex())
+	log.PanicIf(err)
+
+	if len(index.MiscellaneousExifData.Diagnostics()) != len(expected) {
+		t.Fatalf("Collect diagnostics not correct: %v", index.MiscellaneousExifData.Diagnostics())
+	}
+}
+
+func TestMiscellaneousExifData_Diagnostics_IfdUnreachable(t *testing.T) {
+	// The data is padded out to the scan-limit, so this must be beyond that.
+	exifData := getLimitsTestExifData([][2]uint32{{0x0100, 100}}, 0x200000)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	med, err := ie.Scan(exifcommon.IfdStandardIfdIdentity, 8, nil)
+	log.PanicIf(err)
+
+	diagnostics := med.Diagnostics()
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("Diagnostics not correct: %v", diagnostics)
+	}
+
+	d := diagnostics[0]
+
+	if d.Severity != DiagnosticError || d.Code != DiagnosticIfdUnreachable || d.FqIfdPath != "IFD1" || d.Offset != 0x200000 || d.TagId != 0 {
+		t.Fatalf("Diagnostic not correct: %s", d)
+	}
+}
+
+func TestMiscellaneousExifData_Diagnostics_RealData(t *testing.T) {
+	_, med, err := GetFlatExifDataFromBytesWithOptions(getTestExifData(), ScanOptions{})
+	log.PanicIf(err)
+
+	if len(med.Diagnostics()) != 0 {
+		t.Fatalf("Real data should not have any diagnostics: %v", med.Diagnostics())
+	}
+}
+
+func TestDiagnosticSeverity_String(t *testing.T) {
+	if DiagnosticWarning.String() != "warning" {
+		t.Fatalf("Severity name not correct: [%s]", DiagnosticWarning)
+	} else if DiagnosticSeverity(99).String() != "DiagnosticSeverity(99)" {
+		t.Fatalf("Unknown severity name not correct: [%s]", DiagnosticSeverity(99))
+	}
+}
+
+func TestDiagnosticSeverity_Text(t *testing.T) {
+	text, err := DiagnosticError.MarshalText()
+	log.PanicIf(err)
+
+	if string(text) != "error" {
+		t.Fatalf("Text not correct: [%s]", string(text))
+	}
+
+	var ds DiagnosticSeverity
+
+	err = ds.UnmarshalText([]byte("warning"))
+	log.PanicIf(err)
+
+	if ds != DiagnosticWarning {
+		t.Fatalf("Severity not correct: [%s]", ds)
+	}
+
+	err = ds.UnmarshalText([]byte("fatal"))
+	if err == nil {
+		t.Fatalf("Expected error for unknown severity.")
+	}
+}
@@ -0,0 +1,179 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+var (
+	// ErrIfdCycle means that an IFD pointed back to an IFD that was already
+	// parsed.
+	ErrIfdCycle = errors.New("IFD cycle detected")
+
+	// ErrTooManyIfds means that the EXIF has more IFDs than the limit allows.
+	ErrTooManyIfds = errors.New("too many IFDs")
+
+	// ErrIfdTooDeep means that child IFDs are nested deeper than the limit
+	// allows.
+	ErrIfdTooDeep = errors.New("IFD nested too deeply")
+
+	// ErrTooManyEntries means that an IFD has more entries than the limit
+	// allows.
+	ErrTooManyEntries = errors.New("too many IFD entries")
+
+	// ErrValueBudgetExceeded means that the values of all of the tags add up
+	// to more bytes than the limit allows.
+	ErrValueBudgetExceeded = errors.New("tag-value budget exceeded")
+)
+
+// ParseLimits are the resource budgets for a single `Scan` or `Collect`. They
+// protect against crafted EXIF that would otherwise loop forever or exhaust
+// memory. A zero field means no limit.
+type ParseLimits struct {
+	// MaxIfds is the maximum number of IFDs, counting both siblings and
+	// children.
+	MaxIfds int
+
+	// MaxDepth is the maximum nesting of child IFDs. The root IFD (and its
+	// siblings) have a depth of zero.
+	MaxDepth int
+
+	// MaxEntriesPerIfd is the maximum number of tags in a single IFD.
+	MaxEntriesPerIfd int
+
+	// MaxTotalValueBytes is the maximum number of bytes that the values of all
+	// tags can add up to, as described by their types and unit-counts.
+	MaxTotalValueBytes int64
+}
+
+var (
+	// DefaultParseLimits are the limits that `IfdEnumerate` starts with. They
+	// are generous for any real-world image.
+	DefaultParseLimits = ParseLimits{
+		MaxIfds:            128,
+		MaxDepth:           8,
+		MaxEntriesPerIfd:   4096,
+		MaxTotalValueBytes: 128 * 1024 * 1024,
+	}
+)
+
+// ParseLimitError is returned when the EXIF violates a limit (or has a
+// cycle). It wraps one of `ErrIfdCycle`, `ErrTooManyIfds`, `ErrIfdTooDeep`,
+// `ErrTooManyEntries`, or `ErrValueBudgetExceeded`.
+type ParseLimitError struct {
+	// Err is the specific violation.
+	Err error
+
+	// FqIfdPath is the IFD that we were parsing.
+	FqIfdPath string
+
+	// Offset is the offset of that IFD.
+	Offset uint32
+
+	// Limit is the limit that was exceeded (zero for a cycle).
+	Limit int64
+}
+
+// Error returns the message.
+func (ple *ParseLimitError) Error() string {
+	if ple.Err == ErrIfdCycle {
+		return fmt.Sprintf("%s: IFD [%s] at offset (0x%08x) was already parsed", ple.Err.Error(), ple.FqIfdPath, ple.Offset)
+	}
+
+	return fmt.Sprintf("%s: IFD [%s] at offset (0x%08x) exceeds limit (%d)", ple.Err.Error(), ple.FqIfdPath, ple.Offset, ple.Limit)
+}
+
+// Unwrap returns the specific violation.
+func (ple *ParseLimitError) Unwrap() error {
+	return ple.Err
+}
+
+// parseBudget tracks the resources used by one parse against the limits.
+type parseBudget struct {
+	limits ParseLimits
+
+	visited         map[uint32]struct{}
+	ifdCount        int
+	totalValueBytes int64
+}
+
+func newParseBudget(limits ParseLimits) *parseBudget {
+	return &parseBudget{
+		limits:  limits,
+		visited: make(map[uint32]struct{}),
+	}
+}
+
+// enterIfd accounts for an IFD that is about to be parsed.
+func (pb *parseBudget) enterIfd(ii *exifcommon.IfdIdentity, offset uint32) error {
+	if _, found := pb.visited[offset]; found == true {
+		return &ParseLimitError{
+			Err:       ErrIfdCycle,
+			FqIfdPath: ii.String(),
+			Offset:    offset,
+		}
+	}
+
+	pb.visited[offset] = struct{}{}
+	pb.ifdCount++
+
+	if pb.limits.MaxIfds > 0 && pb.ifdCount > pb.limits.MaxIfds {
+		return &ParseLimitError{
+			Err:       ErrTooManyIfds,
+			FqIfdPath: ii.String(),
+			Offset:    offset,
+			Limit:     int64(pb.limits.MaxIfds),
+		}
+	}
+
+	depth := strings.Count(ii.UnindexedString(), "/")
+
+	if pb.limits.MaxDepth > 0 && depth > pb.limits.MaxDepth {
+		return &ParseLimitError{
+			Err:       ErrIfdTooDeep,
+			FqIfdPath: ii.String(),
+			Offset:    offset,
+			Limit:     int64(pb.limits.MaxDepth),
+		}
+	}
+
+	return nil
+}
+
+// checkEntries checks the tag-count of an IFD.
+func (pb *parseBudget) checkEntries(ii *exifcommon.IfdIdentity, offset uint32, tagCount int) error {
+	if pb.limits.MaxEntriesPerIfd > 0 && tagCount > pb.limits.MaxEntriesPerIfd {
+		return &ParseLimitError{
+			Err:       ErrTooManyEntries,
+			FqIfdPath: ii.String(),
+			Offset:    offset,
+			Limit:     int64(pb.limits.MaxEntriesPerIfd),
+		}
+	}
+
+	return nil
+}
+
+// addValue accounts for the value of one tag.
+func (pb *parseBudget) addValue(ii *exifcommon.IfdIdentity, offset uint32, ite *IfdTagEntry) error {
+	typeSize := int64(1)
+	if ite.TagType() != exifcommon.TypeUndefined {
+		typeSize = int64(ite.TagType().Size())
+	}
+
+	pb.totalValueBytes += typeSize * int64(ite.UnitCount())
+
+	if pb.limits.MaxTotalValueBytes > 0 && pb.totalValueBytes > pb.limits.MaxTotalValueBytes {
+		return &ParseLimitError{
+			Err:       ErrValueBudgetExceeded,
+			FqIfdPath: ii.String(),
+			Offset:    offset,
+			Limit:     pb.limits.MaxTotalValueBytes,
+		}
+	}
+
+	return nil
+}
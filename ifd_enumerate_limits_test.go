@@ -0,0 +1,173 @@
+package exif
+
+import (
+	"errors"
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+// getLimitsTestExifData builds a little-endian EXIF blob with a single IFD at
+// offset (8) with the given (tag-ID, LONG value) entries and next-IFD offset.
+func getLimitsTestExifData(entries [][2]uint32, nextIfdOffset uint32) []byte {
+	b := []byte{'I', 'I', 0x2a, 0x00, 0x08, 0x00, 0x00, 0x00}
+
+	b = append(b, 0, 0)
+	binary.LittleEndian.PutUint16(b[len(b)-2:], uint16(len(entries)))
+
+	for _, entry := range entries {
+		raw := make([]byte, 12)
+
+		binary.LittleEndian.PutUint16(raw[0:], uint16(entry[0]))
+		binary.LittleEndian.PutUint16(raw[2:], uint16(exifcommon.TypeLong))
+		binary.LittleEndian.PutUint32(raw[4:], 1)
+		binary.LittleEndian.PutUint32(raw[8:], entry[1])
+
+		b = append(b, raw...)
+	}
+
+	b = append(b, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(b[len(b)-4:], nextIfdOffset)
+
+	return b
+}
+
+func getLimitsTestEnumerate(exifData []byte) *IfdEnumerate {
+	s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	return NewIfdEnumerate(s, im, ti, binary.LittleEndian)
+}
+
+func checkParseLimitError(t *testing.T, err error, expected error) *ParseLimitError {
+	if err == nil {
+		t.Fatalf("Expected error [%v].", expected)
+	} else if log.Is(err, expected) == false {
+		t.Fatalf("Expected error [%v]: %v", expected, err)
+	}
+
+	var ple *ParseLimitError
+	if errors.As(err, &ple) == false {
+		t.Fatalf("Error is not a ParseLimitError: %v", err)
+	}
+
+	return ple
+}
+
+func TestIfdEnumerate_Collect_SiblingCycle(t *testing.T) {
+	// ImageWidth, and the next IFD points back at ourselves.
+	exifData := getLimitsTestExifData([][2]uint32{{0x0100, 100}}, 8)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	_, err := ie.Collect(8)
+	ple := checkParseLimitError(t, err, ErrIfdCycle)
+
+	if ple.FqIfdPath != "IFD1" || ple.Offset != 8 {
+		t.Fatalf("Error not correct: %v", ple)
+	}
+
+	ie = getLimitsTestEnumerate(exifData)
+
+	_, err = ie.Scan(exifcommon.IfdStandardIfdIdentity, 8, nil)
+	checkParseLimitError(t, err, ErrIfdCycle)
+}
+
+func TestIfdEnumerate_Collect_ChildCycle(t *testing.T) {
+	// The EXIF IFD pointer points back at ourselves.
+	exifData := getLimitsTestExifData([][2]uint32{{0x8769, 8}}, 0)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	_, err := ie.Collect(8)
+	ple := checkParseLimitError(t, err, ErrIfdCycle)
+
+	if ple.FqIfdPath != "IFD/Exif" {
+		t.Fatalf("Error not correct: %v", ple)
+	}
+
+	ie = getLimitsTestEnumerate(exifData)
+
+	_, err = ie.Scan(exifcommon.IfdStandardIfdIdentity, 8, nil)
+	checkParseLimitError(t, err, ErrIfdCycle)
+
+	// The top-level function should return the same thing.
+
+	s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, _, err = Collect(s, NewIfdMappingWithStandard(), NewTagIndex())
+	checkParseLimitError(t, err, ErrIfdCycle)
+}
+
+func TestIfdEnumerate_Collect_TooManyEntries(t *testing.T) {
+	exifData := getLimitsTestExifData([][2]uint32{{0x0100, 100}, {0x0101, 100}}, 0)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	limits := DefaultParseLimits
+	limits.MaxEntriesPerIfd = 1
+
+	ie.SetParseLimits(limits)
+
+	_, err := ie.Collect(8)
+	ple := checkParseLimitError(t, err, ErrTooManyEntries)
+
+	if ple.Limit != 1 {
+		t.Fatalf("Limit not correct: %v", ple)
+	}
+
+	// Zero means unlimited.
+	ie.SetParseLimits(ParseLimits{})
+
+	_, err = ie.Collect(8)
+	log.PanicIf(err)
+}
+
+func TestIfdEnumerate_ParseLimits_RealData(t *testing.T) {
+	exifData := getTestExifData()
+
+	eh, err := ParseExifHeader(exifData)
+	log.PanicIf(err)
+
+	cases := []struct {
+		limits   ParseLimits
+		expected error
+	}{
+		{ParseLimits{MaxIfds: 3}, ErrTooManyIfds},
+		{ParseLimits{MaxDepth: 1}, ErrIfdTooDeep},
+		{ParseLimits{MaxTotalValueBytes: 1000}, ErrValueBudgetExceeded},
+	}
+
+	for _, c := range cases {
+		ie := getLimitsTestEnumerate(exifData)
+		ie.SetParseLimits(c.limits)
+
+		_, err := ie.Collect(eh.FirstIfdOffset)
+		checkParseLimitError(t, err, c.expected)
+
+		ie = getLimitsTestEnumerate(exifData)
+		ie.SetParseLimits(c.limits)
+
+		_, err = ie.Scan(exifcommon.IfdStandardIfdIdentity, eh.FirstIfdOffset, nil)
+		checkParseLimitError(t, err, c.expected)
+	}
+
+	// The defaults should be fine for real data, and repeated parses should
+	// not trip the cycle detection.
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	_, err = ie.Collect(eh.FirstIfdOffset)
+	log.PanicIf(err)
+
+	_, err = ie.Collect(eh.FirstIfdOffset)
+	log.PanicIf(err)
+}
@@ -0,0 +1,96 @@
+package exif
+
+import (
+	"errors"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+var (
+	// ErrTagNotValidForIfd means that a tag is not known in the IFD that it
+	// appeared in. Only returned by a strict parse.
+	ErrTagNotValidForIfd = errors.New("tag not valid for IFD")
+
+	// ErrTagTypeMismatch means that a tag has a type that is not supported
+	// by the tag. Only returned by a strict parse.
+	ErrTagTypeMismatch = errors.New("tag type not supported by tag")
+
+	// ErrValueMisaligned means that a value is stored at an odd offset,
+	// whereas TIFF requires values to start on a word boundary. Only returned
+	// by a strict parse.
+	ErrValueMisaligned = errors.New("tag value misaligned")
+)
+
+// ScanOptions controls how the EXIF is parsed. The zero value is the default,
+// lenient behavior.
+type ScanOptions struct {
+	// Strict fails the parse on any known tag that appears in an IFD that it
+	// is not valid for, any tag with a type that the tag does not support, and
+	// any value that is not word-aligned. Otherwise, these tags are kept.
+	// Tags that are not known at all (e.g. private tags) are always kept.
+	Strict bool
+
+	// SkipChildIfds does not descend into child IFDs (e.g. the EXIF and GPS
+	// IFDs).
+	SkipChildIfds bool
+
+	// SkipSiblingIfds does not follow the chain of IFDs (e.g. from IFD0 to
+	// the thumbnail IFD).
+	SkipSiblingIfds bool
+
+	// SkipUndefinedDecode does not decode the values of undefined-type tags.
+	// Their raw bytes are returned instead. This applies to the flat tag list.
+	SkipUndefinedDecode bool
+
+	// SkipFormatting does not compute the formatted strings. This applies to
+	// the flat tag list.
+	SkipFormatting bool
+
+	// Limits, if not nil, replaces the resource limits of the enumerator.
+	Limits *ParseLimits
+
+	// Codecs, if not nil, replaces the undefined-type codec registry of the
+	// enumerator.
+	Codecs *exifundefined.Registry
+}
+
+// startParse resets the per-parse state.
+func (ie *IfdEnumerate) startParse(options ScanOptions) {
+	ie.options = options
+
+	limits := ie.limits
+	if options.Limits != nil {
+		limits = *options.Limits
+	}
+
+	ie.budget = newParseBudget(limits)
+}
+
+// undefinedCodecs returns the registry that undefined-type values will be
+// decoded with.
+func (ie *IfdEnumerate) undefinedCodecs() *exifundefined.Registry {
+	if ie.options.Codecs != nil {
+		return ie.options.Codecs
+	} else if ie.codecs != nil {
+		return ie.codecs
+	}
+
+	return exifundefined.DefaultRegistry()
+}
+
+// isValueMisaligned returns true if the value is stored outside of the entry
+// at an odd offset.
+func isValueMisaligned(ite *IfdTagEntry) bool {
+	typeSize := uint64(1)
+	if ite.TagType() != exifcommon.TypeUndefined {
+		typeSize = uint64(ite.TagType().Size())
+	}
+
+	// Embedded values are always aligned.
+	if typeSize*uint64(ite.UnitCount()) <= 4 {
+		return false
+	}
+
+	return ite.getValueOffset()%2 != 0
+}
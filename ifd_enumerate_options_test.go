@@ -0,0 +1,353 @@
+package exif
+
+import (
+	"bytes"
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+type optionsTestEntry struct {
+	tagId     uint16
+	tagType   exifcommon.TagTypePrimitive
+	unitCount uint32
+	value     uint32
+}
+
+// getOptionsTestExifData builds a little-endian EXIF blob with a single IFD
+// at offset (8), followed by the given extra data.
+func getOptionsTestExifData(entries []optionsTestEntry, extra []byte) []byte {
+	b := []byte{'I', 'I', 0x2a, 0x00, 0x08, 0x00, 0x00, 0x00}
+
+	b = append(b, 0, 0)
+	binary.LittleEndian.PutUint16(b[len(b)-2:], uint16(len(entries)))
+
+	for _, entry := range entries {
+		raw := make([]byte, 12)
+
+		binary.LittleEndian.PutUint16(raw[0:], entry.tagId)
+		binary.LittleEndian.PutUint16(raw[2:], uint16(entry.tagType))
+		binary.LittleEndian.PutUint32(raw[4:], entry.unitCount)
+		binary.LittleEndian.PutUint32(raw[8:], entry.value)
+
+		b = append(b, raw...)
+	}
+
+	b = append(b, 0, 0, 0, 0)
+	b = append(b, extra...)
+
+	return b
+}
+
+func TestGetFlatExifDataFromBytesWithOptions_StrictAndLenient(t *testing.T) {
+	// The extra data starts at (8 + 2 + 12 * n + 4).
+	cases := []struct {
+		name     string
+		entries  []optionsTestEntry
+		expected error
+	}{
+		{
+			// PixelXDimension belongs in the EXIF IFD.
+			name:     "wrong IFD",
+			entries:  []optionsTestEntry{{0xa002, exifcommon.TypeLong, 1, 100}},
+			expected: ErrTagNotValidForIfd,
+		},
+		{
+			// ImageWidth is SHORT or LONG.
+			name:     "type mismatch",
+			entries:  []optionsTestEntry{{0x0100, exifcommon.TypeRational, 1, 26}},
+			expected: ErrTagTypeMismatch,
+		},
+		{
+			// Two SHORTs fit in the entry, but three do not.
+			name:     "misaligned",
+			entries:  []optionsTestEntry{{0x0102, exifcommon.TypeShort, 3, 27}},
+			expected: ErrValueMisaligned,
+		},
+	}
+
+	extra := make([]byte, 16)
+
+	for _, c := range cases {
+		exifData := getOptionsTestExifData(c.entries, extra)
+
+		_, _, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{Strict: true})
+		if log.Is(err, c.expected) == false {
+			t.Fatalf("Strict parse of [%s] did not fail correctly: %v", c.name, err)
+		}
+
+		s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+		log.PanicIf(err)
+
+		_, _, err = CollectWithOptions(s, NewIfdMappingWithStandard(), NewTagIndex(), ScanOptions{Strict: true})
+		if log.Is(err, c.expected) == false {
+			t.Fatalf("Strict collect of [%s] did not fail correctly: %v", c.name, err)
+		}
+
+		exifTags, _, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{})
+		log.PanicIf(err)
+
+		if len(exifTags) != 1 || exifTags[0].TagId != c.entries[0].tagId {
+			t.Fatalf("Lenient parse of [%s] did not keep the tag: %v", c.name, exifTags)
+		}
+	}
+}
+
+func TestGetFlatExifDataFromBytesWithOptions_StrictUnknownTag(t *testing.T) {
+	// Tags that aren't in any index (e.g. private tags) aren't misplaced.
+	exifData := getOptionsTestExifData([]optionsTestEntry{{0xfffe, exifcommon.TypeLong, 1, 0}}, nil)
+
+	exifTags, med, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{Strict: true})
+	log.PanicIf(err)
+
+	if len(exifTags) != 1 || exifTags[0].TagId != 0xfffe {
+		t.Fatalf("Unknown tag not kept: %v", exifTags)
+	}
+
+	diagnostics := med.Diagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].Code != DiagnosticTagUnknown {
+		t.Fatalf("Unknown tag not reported: %v", diagnostics)
+	}
+}
+
+func TestGetFlatExifDataFromBytesWithOptions_UnknownTags(t *testing.T) {
+	exifData := getOptionsTestExifData([]optionsTestEntry{{0xa002, exifcommon.TypeLong, 1, 100}}, nil)
+
+	exifTags, med, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{})
+	log.PanicIf(err)
+
+	if exifTags[0].TagName != "PixelXDimension" {
+		t.Fatalf("Tag not named from the other IFD: [%s]", exifTags[0].TagName)
+	}
+
+	unknownTags := med.UnknownTags()
+
+	bt := exifcommon.BasicTag{
+		FqIfdPath: "IFD",
+		IfdPath:   "IFD",
+		TagId:     0xa002,
+	}
+
+	if alternative, found := unknownTags[bt]; found == false {
+		t.Fatalf("Unknown tag not recorded: %v", unknownTags)
+	} else if alternative.IfdPath != "IFD/Exif" {
+		t.Fatalf("Alternative not correct: %v", alternative)
+	}
+}
+
+func TestGetFlatExifDataFromBytesWithOptions_Toggles(t *testing.T) {
+	exifData := getTestExifData()
+
+	allExifTags, _, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{})
+	log.PanicIf(err)
+
+	// Only IFD0.
+
+	exifTags, _, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{SkipChildIfds: true, SkipSiblingIfds: true})
+	log.PanicIf(err)
+
+	for _, et := range exifTags {
+		if et.IfdPath != "IFD" {
+			t.Fatalf("Tag from another IFD returned: %s", et)
+		}
+	}
+
+	if len(exifTags) == 0 || len(exifTags) >= len(allExifTags) {
+		t.Fatalf("Tag count not correct: (%d) of (%d)", len(exifTags), len(allExifTags))
+	}
+
+	// Siblings but no children.
+
+	exifTags, _, err = GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{SkipChildIfds: true})
+	log.PanicIf(err)
+
+	hasIfd1 := false
+	for _, et := range exifTags {
+		if et.IfdPath == "IFD1" {
+			hasIfd1 = true
+		} else if et.IfdPath != "IFD" {
+			t.Fatalf("Tag from a child IFD returned: %s", et)
+		}
+	}
+
+	if hasIfd1 == false {
+		t.Fatalf("Sibling IFD not visited.")
+	}
+
+	// No decoding or formatting.
+
+	exifTags, _, err = GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{SkipUndefinedDecode: true, SkipFormatting: true})
+	log.PanicIf(err)
+
+	for _, et := range exifTags {
+		if et.Formatted != "" || et.FormattedFirst != "" {
+			t.Fatalf("Tag was formatted: %s", et)
+		}
+
+		if et.TagTypeId != exifcommon.TypeUndefined {
+			continue
+		}
+
+		raw, ok := et.Value.([]byte)
+		if ok == false {
+			t.Fatalf("Undefined tag was decoded: %s", et)
+		} else if bytes.Equal(raw, et.ValueBytes) == false {
+			t.Fatalf("Undefined tag bytes not correct: %s", et)
+		}
+	}
+
+	if len(exifTags) < len(allExifTags) {
+		t.Fatalf("Undecoded tag count not correct: (%d) < (%d)", len(exifTags), len(allExifTags))
+	}
+}
+
+func TestIfdEnumerate_CollectWithOptions(t *testing.T) {
+	exifData := getTestExifData()
+
+	s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, index, err := CollectWithOptions(s, NewIfdMappingWithStandard(), NewTagIndex(), ScanOptions{SkipChildIfds: true})
+	log.PanicIf(err)
+
+	if len(index.Ifds) != 2 || index.Ifds[0].IfdIdentity().String() != "IFD" || index.Ifds[1].IfdIdentity().String() != "IFD1" {
+		t.Fatalf("IFDs not correct: %v", index.Ifds)
+	} else if index.MiscellaneousExifData == nil {
+		t.Fatalf("MiscellaneousExifData not set.")
+	}
+
+	// The limits in the options should be used.
+
+	s, err = NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, _, err = CollectWithOptions(s, NewIfdMappingWithStandard(), NewTagIndex(), ScanOptions{Limits: &ParseLimits{MaxIfds: 1}})
+	if log.Is(err, ErrTooManyIfds) == false {
+		t.Fatalf("Limits not applied: %v", err)
+	}
+}
+
+type optionsTestVersion struct {
+	version string
+}
+
+func (optionsTestVersion) EncoderName() string {
+	return "optionsTestVersionCodec"
+}
+
+func (otv optionsTestVersion) String() string {
+	return otv.version
+}
+
+// optionsTestVersionCodec stores a version as "v" followed by the digits.
+type optionsTestVersionCodec struct {
+}
+
+func (optionsTestVersionCodec) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	encoded = []byte(value.(optionsTestVersion).version[1:])
+	return encoded, uint32(len(encoded)), nil
+}
+
+func (optionsTestVersionCodec) Decode(valueContext *exifcommon.ValueContext) (value exifundefined.EncodeableValue, err error) {
+	valueContext.SetUndefinedValueType(exifcommon.TypeAsciiNoNul)
+
+	s, err := valueContext.ReadAsciiNoNul()
+	if err != nil {
+		return nil, err
+	}
+
+	return optionsTestVersion{version: "v" + s}, nil
+}
+
+func getOptionsTestCodecs() *exifundefined.Registry {
+	codecs := exifundefined.DefaultRegistry().Clone()
+
+	err := codecs.RegisterEncoder(optionsTestVersion{}, optionsTestVersionCodec{})
+	log.PanicIf(err)
+
+	err = codecs.RegisterDecoder(exifcommon.IfdExifStandardIfdIdentity.UnindexedString(), 0x9000, optionsTestVersionCodec{})
+	log.PanicIf(err)
+
+	return codecs
+}
+
+func TestGetFlatExifDataFromBytesWithOptions_Codecs(t *testing.T) {
+	exifData := getTestExifData()
+
+	getExifVersion := func(options ScanOptions) interface{} {
+		exifTags, _, err := GetFlatExifDataFromBytesWithOptions(exifData, options)
+		log.PanicIf(err)
+
+		for _, et := range exifTags {
+			if et.IfdPath == "IFD/Exif" && et.TagId == 0x9000 {
+				return et.Value
+			}
+		}
+
+		t.Fatalf("ExifVersion not found.")
+		return nil
+	}
+
+	if value := getExifVersion(ScanOptions{}); value != (exifundefined.Tag9000ExifVersion{ExifVersion: "0230"}) {
+		t.Fatalf("Default value not correct: %v", value)
+	}
+
+	if value := getExifVersion(ScanOptions{Codecs: getOptionsTestCodecs()}); value != (optionsTestVersion{version: "v0230"}) {
+		t.Fatalf("Overridden value not correct: %v", value)
+	}
+}
+
+func TestIfdEnumerate_SetCodecRegistry(t *testing.T) {
+	exifData := getTestExifData()
+
+	ie := getLimitsTestEnumerate(exifData)
+	ie.SetCodecRegistry(getOptionsTestCodecs())
+
+	index, err := ie.Collect(ExifDefaultFirstIfdOffset)
+	log.PanicIf(err)
+
+	results, err := index.Lookup["IFD/Exif"].FindTagWithId(0x9000)
+	log.PanicIf(err)
+
+	value, err := results[0].Value()
+	log.PanicIf(err)
+
+	if value != (optionsTestVersion{version: "v0230"}) {
+		t.Fatalf("Value not correct: %v", value)
+	}
+
+	// The registry carries over to a builder and can encode the custom value.
+
+	rootIb := NewIfdBuilderFromExistingChain(index.RootIfd)
+
+	exifIb, err := GetOrCreateIbFromRootIb(rootIb, "IFD/Exif")
+	log.PanicIf(err)
+
+	err = exifIb.SetStandard(0x9000, optionsTestVersion{version: "v0231"})
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	updatedExifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	exifTags, _, err := GetFlatExifDataFromBytesWithOptions(updatedExifData, ScanOptions{})
+	log.PanicIf(err)
+
+	for _, et := range exifTags {
+		if et.IfdPath == "IFD/Exif" && et.TagId == 0x9000 {
+			if et.Value != (exifundefined.Tag9000ExifVersion{ExifVersion: "0231"}) {
+				t.Fatalf("Encoded value not correct: %v", et.Value)
+			}
+
+			return
+		}
+	}
+
+	t.Fatalf("ExifVersion not found.")
+}
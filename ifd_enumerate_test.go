@@ -2,11 +2,13 @@ package exif
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"path"
 	"reflect"
 	"testing"
 
+	"encoding/binary"
 	"io/ioutil"
 
 	log "github.com/dsoprea/go-logging"
@@ -621,3 +623,90 @@ func ExampleIfd_FindTagWithName() {
 	// Output:
 	// Canon EOS 5D Mark III
 }
+
+func TestIfdEnumerate_Collect_OffsetError(t *testing.T) {
+	exifData := getLimitsTestExifData([][2]uint32{{0x0100, 100}}, 0)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	// The data is padded out to the scan-limit, so this must be beyond that.
+	_, err := ie.Collect(0x200000)
+
+	var oe *exifcommon.OffsetError
+	if errors.As(err, &oe) == false {
+		t.Fatalf("Expected offset error: %v", err)
+	} else if errors.Is(err, ErrOffsetInvalid) == false {
+		t.Fatalf("Offset error does not wrap ErrOffsetInvalid.")
+	} else if oe.Offset != 0x200000 || oe.IfdPath != "IFD" {
+		t.Fatalf("Offset error not correct: %v", oe)
+	}
+}
+
+func TestIfdEnumerate_ParseOneIfd_TruncatedError(t *testing.T) {
+	// Claims two entries but only has one.
+	exifData := getLimitsTestExifData([][2]uint32{{0x0100, 100}}, 0)
+	ifdBlock := exifData[8 : len(exifData)-4]
+	ifdBlock[0] = 2
+
+	_, _, err := ParseOneIfd(NewIfdMappingWithStandard(), NewTagIndex(), exifcommon.IfdStandardIfdIdentity, binary.LittleEndian, ifdBlock, nil)
+
+	// This should be returned as it is rather than wrapped.
+	te, ok := err.(*exifcommon.TruncatedError)
+	if ok == false {
+		t.Fatalf("Expected truncated error: %v", err)
+	} else if te.Offset != 14 || te.Needed != 2 || te.Available != 0 {
+		t.Fatalf("Truncated error not correct: %v", te)
+	}
+}
+
+func TestIfdEnumerate_ValueOffsetError(t *testing.T) {
+	// Make, with its value beyond the end of the data.
+	entries := []optionsTestEntry{{0x010f, exifcommon.TypeAscii, 8, 0x200000}}
+	exifData := getOptionsTestExifData(entries, nil)
+
+	_, _, err := GetFlatExifDataFromBytesWithOptions(exifData, ScanOptions{})
+
+	var oe *exifcommon.OffsetError
+	if errors.As(err, &oe) == false {
+		t.Fatalf("Expected offset error: %v", err)
+	} else if oe.TagId != 0x010f || oe.IfdPath != "IFD" || oe.Offset != 0x200000 {
+		t.Fatalf("Offset error not correct: %v", oe)
+	}
+}
+
+func TestParseOneTag_TypeError(t *testing.T) {
+	tagBlock := []byte{
+		0x01, 0x00,
+		0x00, 0x63,
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00,
+	}
+
+	_, err := ParseOneTag(NewIfdMappingWithStandard(), NewTagIndex(), exifcommon.IfdStandardIfdIdentity, binary.BigEndian, tagBlock)
+
+	var te *exifcommon.TypeError
+	if errors.As(err, &te) == false {
+		t.Fatalf("Expected type error: %v", err)
+	} else if errors.Is(err, ErrTagTypeNotValid) == false {
+		t.Fatalf("Type error does not wrap ErrTagTypeNotValid.")
+	} else if te.TagId != 0x0100 || te.TagType != 0x63 || te.IfdPath != "IFD" {
+		t.Fatalf("Type error not correct: %v", te)
+	}
+}
+
+func TestIfdEnumerate_Scan_NonErrorPanic(t *testing.T) {
+	exifData := getLimitsTestExifData([][2]uint32{{0x0100, 100}}, 0)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	visitor := func(fqIfdPath string, ifdIndex int, ite *IfdTagEntry) (err error) {
+		panic("not an error")
+	}
+
+	_, err := ie.Scan(exifcommon.IfdStandardIfdIdentity, 8, visitor)
+	if err == nil {
+		t.Fatalf("Expected error.")
+	} else if err.Error() != "not an error" {
+		t.Fatalf("Error not correct: [%s]", err.Error())
+	}
+}
@@ -42,10 +42,17 @@ type IfdTagEntry struct {
 
 	isUnhandledUnknown bool
 
+	// entryOffset is the offset of the entry in the IFD table.
+	entryOffset uint32
+
 	addressableData []byte
 	byteOrder       binary.ByteOrder
 
 	tagName string
+
+	// codecs decodes undefined-type values. If nil, the default registry is
+	// used.
+	codecs *exifundefined.Registry
 }
 
 func newIfdTagEntry(ii *exifcommon.IfdIdentity, tagId uint16, tagIndex int, tagType exifcommon.TagTypePrimitive, unitCount uint32, valueOffset uint32, rawValueOffset []byte, addressableData []byte, byteOrder binary.ByteOrder) *IfdTagEntry {
@@ -93,6 +100,16 @@ func (ite *IfdTagEntry) TagId() uint16 {
 	return ite.tagId
 }
 
+// undefinedCodecs returns the registry that undefined-type values are decoded
+// with.
+func (ite *IfdTagEntry) undefinedCodecs() *exifundefined.Registry {
+	if ite.codecs == nil {
+		return exifundefined.DefaultRegistry()
+	}
+
+	return ite.codecs
+}
+
 // IsThumbnailOffset returns true if the tag has the IFD and tag-ID of a
 // thumbnail offset.
 func (ite *IfdTagEntry) IsThumbnailOffset() bool {
@@ -136,14 +153,14 @@ func (ite *IfdTagEntry) getValueOffset() uint32 {
 func (ite *IfdTagEntry) GetRawBytes() (rawBytes []byte, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext := ite.getValueContext()
 
 	if ite.tagType == exifcommon.TypeUndefined {
-		value, err := exifundefined.Decode(valueContext)
+		value, err := ite.undefinedCodecs().Decode(valueContext)
 		if err != nil {
 			if err == exifcommon.ErrUnhandledUndefinedTypedTag {
 				ite.setIsUnhandledUnknown(true)
@@ -158,7 +175,7 @@ func (ite *IfdTagEntry) GetRawBytes() (rawBytes []byte, err error) {
 		// Encode it back, in order to get the raw bytes. This is the best,
 		// general way to do it with an undefined tag.
 
-		rawBytes, _, err := exifundefined.Encode(value, ite.byteOrder)
+		rawBytes, _, err := ite.undefinedCodecs().EncodeTag(ite.IfdPath(), ite.tagId, value, ite.byteOrder)
 		log.PanicIf(err)
 
 		return rawBytes, nil
@@ -170,11 +187,29 @@ func (ite *IfdTagEntry) GetRawBytes() (rawBytes []byte, err error) {
 	return rawBytes, nil
 }
 
+// getRawUndefinedBytes returns the raw bytes for an undefined-type tag without
+// decoding them. This works for undefined tags that we have no codec for.
+func (ite *IfdTagEntry) getRawUndefinedBytes() (rawBytes []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	valueContext := ite.getValueContext()
+	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
+
+	rawBytes, err = valueContext.ReadRawEncoded()
+	log.PanicIf(err)
+
+	return rawBytes, nil
+}
+
 // Value returns the specific, parsed, typed value from the tag.
 func (ite *IfdTagEntry) Value() (value interface{}, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -183,7 +218,7 @@ func (ite *IfdTagEntry) Value() (value interface{}, err error) {
 	if ite.tagType == exifcommon.TypeUndefined {
 		var err error
 
-		value, err = exifundefined.Decode(valueContext)
+		value, err = ite.undefinedCodecs().Decode(valueContext)
 		if err != nil {
 			if err == exifcommon.ErrUnhandledUndefinedTypedTag || err == exifundefined.ErrUnparseableValue {
 				return nil, err
@@ -191,6 +226,11 @@ func (ite *IfdTagEntry) Value() (value interface{}, err error) {
 
 			log.Panic(err)
 		}
+	} else if ite.tagType == exifcommon.TypeByte && isXpTag(ite.ifdIdentity.UnindexedString(), ite.tagId) == true {
+		rawBytes, err := valueContext.ReadBytes()
+		log.PanicIf(err)
+
+		value = decodeXpString(rawBytes)
 	} else {
 		var err error
 
@@ -205,7 +245,7 @@ func (ite *IfdTagEntry) Value() (value interface{}, err error) {
 func (ite *IfdTagEntry) Format() (phrase string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -230,7 +270,7 @@ func (ite *IfdTagEntry) Format() (phrase string, err error) {
 func (ite *IfdTagEntry) FormatFirst() (phrase string, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -0,0 +1,228 @@
+// Command generatetags compiles the standard tag definitions (assets/tags.yaml)
+// into Go tables so that they don't have to be parsed at runtime. It is run
+// via `go generate` from the root package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"go/format"
+	"io/ioutil"
+
+	log "github.com/dsoprea/go-logging"
+	"gopkg.in/yaml.v2"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+var (
+	// typeIdentifiers are the names of the exifcommon constants that we emit.
+	typeIdentifiers = map[exifcommon.TagTypePrimitive]string{
+		exifcommon.TypeByte:           "TypeByte",
+		exifcommon.TypeAscii:          "TypeAscii",
+		exifcommon.TypeShort:          "TypeShort",
+		exifcommon.TypeLong:           "TypeLong",
+		exifcommon.TypeRational:       "TypeRational",
+		exifcommon.TypeUndefined:      "TypeUndefined",
+		exifcommon.TypeSignedLong:     "TypeSignedLong",
+		exifcommon.TypeSignedRational: "TypeSignedRational",
+		exifcommon.TypeUtf8:           "TypeUtf8",
+		exifcommon.TypeFloat:          "TypeFloat",
+		exifcommon.TypeDouble:         "TypeDouble",
+	}
+
+	// ifdQualifiers are inserted into the names of the constants for tags
+	// whose names are already taken by a tag with a different ID in an
+	// earlier IFD (e.g. the TIFF/EP tags in IFD0 that the EXIF standard later
+	// redefined with new IDs).
+	ifdQualifiers = map[string]string{
+		"IFD":          "Ifd",
+		"IFD/Exif":     "Exif",
+		"IFD/Exif/Iop": "Iop",
+		"IFD/GPSInfo":  "Gps",
+	}
+)
+
+type encodedTag struct {
+	Id        int      `yaml:"id"`
+	Name      string   `yaml:"name"`
+	TypeName  string   `yaml:"type_name"`
+	TypeNames []string `yaml:"type_names"`
+}
+
+type parameters struct {
+	InputFilepath  string
+	OutputFilepath string
+	PackageName    string
+}
+
+var (
+	arguments = new(parameters)
+)
+
+func main() {
+	defer func() {
+		if state := recover(); state != nil {
+			err := log.Wrap(state)
+			log.PrintError(err)
+			os.Exit(1)
+		}
+	}()
+
+	flag.StringVar(&arguments.InputFilepath, "input", "assets/tags.yaml", "Tag definitions (YAML)")
+	flag.StringVar(&arguments.OutputFilepath, "output", "tags_standard.go", "Go file to write")
+	flag.StringVar(&arguments.PackageName, "package", "exif", "Package of the Go file")
+
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(arguments.InputFilepath)
+	log.PanicIf(err)
+
+	encodedIfds := yaml.MapSlice{}
+
+	err = yaml.Unmarshal(data, &encodedIfds)
+	log.PanicIf(err)
+
+	source, err := generate(encodedIfds)
+	log.PanicIf(err)
+
+	err = ioutil.WriteFile(arguments.OutputFilepath, source, 0644)
+	log.PanicIf(err)
+}
+
+// generate produces the Go source. The IFDs and tags keep the order that they
+// have in the definitions so that the output is stable.
+func generate(encodedIfds yaml.MapSlice) (source []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	constants := new(bytes.Buffer)
+	table := new(bytes.Buffer)
+
+	names := make(map[string]uint16)
+
+	for _, item := range encodedIfds {
+		ifdPath := item.Key.(string)
+
+		// Round-trip the IFD through YAML to get the typed structure.
+
+		raw, err := yaml.Marshal(item.Value)
+		log.PanicIf(err)
+
+		tags := make([]encodedTag, 0)
+
+		err = yaml.Unmarshal(raw, &tags)
+		log.PanicIf(err)
+
+		fmt.Fprintf(constants, "\n// Tag-IDs in [%s].\nconst (\n", ifdPath)
+
+		ifdNames := make(map[string]struct{})
+
+		for _, tagInfo := range tags {
+			tagTypeNames := tagInfo.TypeNames
+			if tagTypeNames == nil {
+				if tagInfo.TypeName == "" {
+					log.Panicf("no tag-types were given for tag [%s] (0x%04x) [%s]", ifdPath, tagInfo.Id, tagInfo.Name)
+				}
+
+				tagTypeNames = []string{
+					tagInfo.TypeName,
+				}
+			} else if tagInfo.TypeName != "" {
+				log.Panicf("both 'type_names' and 'type_name' were given for tag [%s] (0x%04x) [%s]", ifdPath, tagInfo.Id, tagInfo.Name)
+			}
+
+			typeExpressions := make([]string, 0, len(tagTypeNames))
+			for _, tagTypeName := range tagTypeNames {
+				tagType, found := exifcommon.GetTypeByName(tagTypeName)
+				if found == false {
+					fmt.Fprintf(os.Stderr, "Type [%s] for tag [%s] is not valid and is being ignored.\n", tagTypeName, tagInfo.Name)
+					continue
+				}
+
+				typeExpressions = append(typeExpressions, "exifcommon."+typeIdentifierFor(tagType))
+			}
+
+			if len(typeExpressions) == 0 {
+				fmt.Fprintf(os.Stderr, "Tag [%s] (0x%04x) [%s] does not have any supported types and will not be registered.\n", ifdPath, tagInfo.Id, tagInfo.Name)
+				continue
+			}
+
+			tagId := uint16(tagInfo.Id)
+
+			if _, found := ifdNames[tagInfo.Name]; found == true {
+				log.Panicf("tag name defined more than once for IFD [%s]: [%s]", ifdPath, tagInfo.Name)
+			}
+
+			ifdNames[tagInfo.Name] = struct{}{}
+
+			// The constants are not qualified by IFD unless the name was
+			// already used for a different ID.
+			constantName := "Tag" + tagInfo.Name
+			if existingId, found := names[constantName]; found == false {
+				names[constantName] = tagId
+				fmt.Fprintf(constants, "\t%s uint16 = 0x%04x\n", constantName, tagId)
+			} else if existingId != tagId {
+				qualifier, found := ifdQualifiers[ifdPath]
+				if found == false {
+					log.Panicf("tag name used with more than one ID in an IFD without a qualifier: [%s] [%s]", ifdPath, tagInfo.Name)
+				}
+
+				constantName = "Tag" + qualifier + tagInfo.Name
+				if _, found := names[constantName]; found == true {
+					log.Panicf("tag name used with more than one ID: [%s] [%s]", ifdPath, tagInfo.Name)
+				}
+
+				names[constantName] = tagId
+				fmt.Fprintf(constants, "\t%s uint16 = 0x%04x\n", constantName, tagId)
+			}
+
+			fmt.Fprintf(table, "\t{Id: 0x%04x, Name: %q, IfdPath: %q, SupportedTypes: []exifcommon.TagTypePrimitive{", tagId, tagInfo.Name, ifdPath)
+
+			for i, typeExpression := range typeExpressions {
+				if i > 0 {
+					table.WriteString(", ")
+				}
+
+				table.WriteString(typeExpression)
+			}
+
+			table.WriteString("}},\n")
+		}
+
+		constants.WriteString(")\n")
+	}
+
+	b := new(bytes.Buffer)
+
+	fmt.Fprintf(b, "// Code generated by generatetags from %s. DO NOT EDIT.\n\n", arguments.InputFilepath)
+	fmt.Fprintf(b, "package %s\n\n", arguments.PackageName)
+	b.WriteString("import (\n\t\"github.com/imclaren/go-exif/common\"\n)\n")
+	b.Write(constants.Bytes())
+	b.WriteString("\nvar (\n")
+	b.WriteString("\t// standardTags are the tags that all devices/applications should support.\n")
+	b.WriteString("\tstandardTags = []IndexedTag{\n")
+	b.Write(table.Bytes())
+	b.WriteString("\t}\n)\n")
+
+	source, err = format.Source(b.Bytes())
+	log.PanicIf(err)
+
+	return source, nil
+}
+
+// typeIdentifierFor returns the name of the constant for the type.
+func typeIdentifierFor(tagType exifcommon.TagTypePrimitive) string {
+	identifier, found := typeIdentifiers[tagType]
+	if found == false {
+		log.Panicf("type (%d) has no identifier", tagType)
+	}
+
+	return identifier
+}
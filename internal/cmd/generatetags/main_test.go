@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"path"
+	"testing"
+
+	"io/ioutil"
+
+	"github.com/dsoprea/go-logging"
+	"gopkg.in/yaml.v2"
+)
+
+// TestGenerate_UpToDate makes sure that the tables in the root package were
+// regenerated after the definitions were changed.
+func TestGenerate_UpToDate(t *testing.T) {
+	rootPath := path.Join("..", "..", "..")
+
+	data, err := ioutil.ReadFile(path.Join(rootPath, "assets", "tags.yaml"))
+	log.PanicIf(err)
+
+	encodedIfds := yaml.MapSlice{}
+
+	err = yaml.Unmarshal(data, &encodedIfds)
+	log.PanicIf(err)
+
+	arguments.InputFilepath = "assets/tags.yaml"
+	arguments.PackageName = "exif"
+
+	source, err := generate(encodedIfds)
+	log.PanicIf(err)
+
+	expected, err := ioutil.ReadFile(path.Join(rootPath, "tags_standard.go"))
+	log.PanicIf(err)
+
+	if bytes.Equal(source, expected) == false {
+		t.Fatalf("tags_standard.go is not up to date. Run `go generate`.")
+	}
+}
+
+func TestGenerate_NameReused(t *testing.T) {
+	data := []byte(`IFD:
+- id: 0x0001
+  name: Reused
+  type_name: SHORT
+- id: 0x0002
+  name: Reused
+  type_name: SHORT
+`)
+
+	encodedIfds := yaml.MapSlice{}
+
+	err := yaml.Unmarshal(data, &encodedIfds)
+	log.PanicIf(err)
+
+	_, err = generate(encodedIfds)
+	if err == nil {
+		t.Fatalf("Expected error for name reused within an IFD.")
+	}
+}
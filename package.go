@@ -5,4 +5,9 @@
 // The EXIF data must first be extracted and then provided to us. Conversely,
 // when constructing new EXIF data, the caller is responsible for packaging
 // this in whichever format they require.
+//
+// Damaged data is reported with `exifcommon.OffsetError`, `TypeError`, and
+// `TruncatedError`. `IfdEnumerate`, `ValueContext`, and the undefined-type
+// codecs return these as they are, so a type assertion or `errors.As` finds
+// them.
 package exif
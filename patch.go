@@ -0,0 +1,646 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"encoding/json"
+
+	log "github.com/dsoprea/go-logging"
+	"gopkg.in/yaml.v2"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+const (
+	// PatchOpSet sets (adds or replaces) a tag value.
+	PatchOpSet = "set"
+
+	// PatchOpDelete deletes all occurrences of a tag.
+	PatchOpDelete = "delete"
+
+	// PatchOpCopy copies the value of a tag to another tag.
+	PatchOpCopy = "copy"
+
+	// PatchOpRename moves the value of a tag to another tag.
+	PatchOpRename = "rename"
+
+	// PatchOpShiftDate shifts a timestamp tag by a duration. If no tag is
+	// given, all of the timestamps are shifted (see
+	// `IfdBuilder.ShiftTimestamps`).
+	PatchOpShiftDate = "shift-date"
+
+	// PatchOpSetGps sets the GPS coordinates (and, optionally, the altitude).
+	PatchOpSetGps = "set-gps"
+)
+
+var (
+	patchLogger = log.NewLogger("exif.patch")
+)
+
+var (
+	// ErrPatchNotValid means that one or more operations in the patch failed
+	// validation. Nothing was applied.
+	ErrPatchNotValid = errors.New("patch not valid")
+
+	// ErrPatchFailed means that an operation failed while being applied. The
+	// operations before it were applied and the ones after it were not.
+	ErrPatchFailed = errors.New("patch failed")
+)
+
+// PatchOperation is a single edit. Tags are addressed by the fully-qualified
+// IFD-path and the tag name.
+//
+// Values may be given as a string, a number, or a list of either. Rationals
+// are given as "numerator/denominator" strings and BYTE values as hex strings
+// (the same as they are formatted).
+type PatchOperation struct {
+	Op      string      `json:"op" yaml:"op"`
+	IfdPath string      `json:"ifd_path,omitempty" yaml:"ifd_path,omitempty"`
+	Tag     string      `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Value   interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// ToIfdPath and ToTag are the destination for "copy" and "rename". The IFD
+	// defaults to the source IFD.
+	ToIfdPath string `json:"to_ifd_path,omitempty" yaml:"to_ifd_path,omitempty"`
+	ToTag     string `json:"to_tag,omitempty" yaml:"to_tag,omitempty"`
+
+	// Shift is the duration (e.g. "-1h30m") for "shift-date".
+	Shift string `json:"shift,omitempty" yaml:"shift,omitempty"`
+
+	// IncludeGps also shifts the GPS date and time when "shift-date" is
+	// given no tag.
+	IncludeGps bool `json:"include_gps,omitempty" yaml:"include_gps,omitempty"`
+
+	// Latitude, Longitude, and Altitude are the decimal position for
+	// "set-gps". Altitude is in meters and is optional.
+	Latitude  *float64 `json:"latitude,omitempty" yaml:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty" yaml:"longitude,omitempty"`
+	Altitude  *float64 `json:"altitude,omitempty" yaml:"altitude,omitempty"`
+}
+
+// String returns a descriptive string.
+func (po PatchOperation) String() string {
+	return fmt.Sprintf("PatchOperation<OP=[%s] IFD-PATH=[%s] TAG=[%s]>", po.Op, po.IfdPath, po.Tag)
+}
+
+// toIfdPath returns the destination IFD-path.
+func (po PatchOperation) toIfdPath() string {
+	if po.ToIfdPath != "" {
+		return po.ToIfdPath
+	}
+
+	return po.IfdPath
+}
+
+// Patch is a list of edits to apply, in order, to an IFD-builder tree.
+type Patch struct {
+	Operations []PatchOperation `json:"operations" yaml:"operations"`
+}
+
+// PatchResult describes the outcome of one operation.
+type PatchResult struct {
+	Index     int            `json:"index"`
+	Operation PatchOperation `json:"operation"`
+	Applied   bool           `json:"applied"`
+	Message   string         `json:"message,omitempty"`
+
+	// Err is the error, if the operation failed validation or failed to
+	// apply.
+	Err error `json:"-"`
+}
+
+// String returns a descriptive string.
+func (pr PatchResult) String() string {
+	status := "skipped"
+	if pr.Err != nil {
+		status = "failed"
+	} else if pr.Applied == true {
+		status = "applied"
+	}
+
+	return fmt.Sprintf("(%d) %s [%s] [%s] %s: %s", pr.Index, pr.Operation.Op, pr.Operation.IfdPath, pr.Operation.Tag, status, pr.Message)
+}
+
+// ParsePatchJson parses a patch from JSON.
+func ParsePatchJson(data []byte) (patch *Patch, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	patch = new(Patch)
+
+	err = json.Unmarshal(data, patch)
+	log.PanicIf(err)
+
+	return patch, nil
+}
+
+// ParsePatchYaml parses a patch from YAML.
+func ParsePatchYaml(data []byte) (patch *Patch, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	patch = new(Patch)
+
+	err = yaml.Unmarshal(data, patch)
+	log.PanicIf(err)
+
+	return patch, nil
+}
+
+// Validate checks every operation against the IFD mapping and the tag index
+// without applying anything. If any operation is invalid, the results will
+// describe why and `ErrPatchNotValid` is returned.
+func (patch *Patch) Validate(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex) (results []PatchResult, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	results = make([]PatchResult, len(patch.Operations))
+	isValid := true
+
+	for i, po := range patch.Operations {
+		results[i] = PatchResult{
+			Index:     i,
+			Operation: po,
+		}
+
+		err := validatePatchOperation(ifdMapping, tagIndex, po)
+		if err != nil {
+			results[i].Err = err
+			results[i].Message = err.Error()
+
+			isValid = false
+		}
+	}
+
+	if isValid == false {
+		return results, ErrPatchNotValid
+	}
+
+	return results, nil
+}
+
+// Apply validates and then applies the operations, in order, to the given
+// root IB. If validation fails, nothing is applied and `ErrPatchNotValid` is
+// returned. If an operation fails to apply, we stop and return
+// `ErrPatchFailed`; the IB will reflect the operations that came before it.
+func (patch *Patch) Apply(rootIb *IfdBuilder) (results []PatchResult, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	results, err = patch.Validate(rootIb.ifdMapping, rootIb.tagIndex)
+	if err != nil {
+		if err == ErrPatchNotValid {
+			return results, err
+		}
+
+		log.Panic(err)
+	}
+
+	for i, po := range patch.Operations {
+		message, err := applyPatchOperation(rootIb, po)
+		if err != nil {
+			results[i].Err = err
+			results[i].Message = err.Error()
+
+			for j := i + 1; j < len(results); j++ {
+				results[j].Message = "not applied due to an earlier failure"
+			}
+
+			return results, ErrPatchFailed
+		}
+
+		patchLogger.Debugf(nil, "Applied patch operation (%d): %s: %s", i, po, message)
+
+		results[i].Applied = true
+		results[i].Message = message
+	}
+
+	return results, nil
+}
+
+// patchValueStrings normalizes a decoded JSON/YAML value to a list of strings.
+func patchValueStrings(value interface{}) (valueStrings []string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch t := value.(type) {
+	case nil:
+		log.Panicf("value is required")
+	case []interface{}:
+		valueStrings = make([]string, 0, len(t))
+
+		for _, item := range t {
+			if _, ok := item.([]interface{}); ok == true {
+				log.Panicf("nested lists are not supported")
+			}
+
+			itemStrings, err := patchValueStrings(item)
+			log.PanicIf(err)
+
+			valueStrings = append(valueStrings, itemStrings...)
+		}
+
+		return valueStrings, nil
+	case string:
+		return []string{t}, nil
+	case float64:
+		return []string{strconv.FormatFloat(t, 'f', -1, 64)}, nil
+	case int:
+		return []string{strconv.Itoa(t)}, nil
+	case int64:
+		return []string{strconv.FormatInt(t, 10)}, nil
+	case uint64:
+		return []string{strconv.FormatUint(t, 10)}, nil
+	}
+
+	log.Panicf("value type not supported: [%v] (%T)", value, value)
+	return nil, nil
+}
+
+// patchValueForTag converts the patch value to a value that can be encoded for
+// the given tag.
+func patchValueForTag(it *IndexedTag, value interface{}) (typedValue interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if it.DoesSupportType(exifcommon.TypeUndefined) == true {
+		log.Panicf("tag [%s] has an undefined type and can not be set from a patch", it.Name)
+	}
+
+	if s, ok := value.(string); ok == true && isXpTag(it.IfdPath, it.Id) == true {
+		return s, nil
+	}
+
+	valueStrings, err := patchValueStrings(value)
+	log.PanicIf(err)
+
+	// Tags that support both RATIONAL and SRATIONAL are encoded according to
+	// the sign of the value. Negative values can't be stored in a tag that
+	// only supports RATIONAL.
+	var sample interface{} = exifcommon.Rational{}
+	if it.DoesSupportType(exifcommon.TypeRational) == true {
+		for _, valueString := range valueStrings {
+			if isNegativeRationalString(valueString) == false {
+				continue
+			}
+
+			if it.DoesSupportType(exifcommon.TypeSignedRational) == false {
+				log.Panicf("tag [%s] only supports unsigned rationals and can not be set to a negative value: [%s]", it.Name, valueString)
+			}
+
+			sample = exifcommon.SignedRational{}
+			break
+		}
+	}
+
+	tagType := it.GetEncodingType(sample)
+
+	typedValue, err = exifcommon.TranslateStringsToType(tagType, valueStrings)
+	log.PanicIf(err)
+
+	return typedValue, nil
+}
+
+// isNegativeRationalString returns true if the numerator or the denominator
+// (but not both) of the rational string is negative.
+func isNegativeRationalString(valueString string) bool {
+	isNegative := false
+	for _, part := range strings.SplitN(valueString, "/", 2) {
+		if strings.HasPrefix(strings.TrimSpace(part), "-") == true {
+			isNegative = !isNegative
+		}
+	}
+
+	return isNegative
+}
+
+// getPatchTag resolves the FQ IFD-path and tag-name.
+func getPatchTag(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, fqIfdPath, tagName string) (it *IndexedTag, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if fqIfdPath == "" {
+		log.Panicf("IFD-path is required")
+	} else if tagName == "" {
+		log.Panicf("tag is required")
+	}
+
+	ii, err := exifcommon.NewIfdIdentityFromString(ifdMapping, fqIfdPath)
+	if err != nil {
+		log.Panicf("IFD-path [%s] is not valid: %s", fqIfdPath, err.Error())
+	}
+
+	it, err = tagIndex.GetWithName(ii, tagName)
+	if err != nil {
+		if log.Is(err, ErrTagNotFound) == true {
+			log.Panicf("tag [%s] is not known for IFD [%s]", tagName, ii.UnindexedString())
+		}
+
+		log.Panic(err)
+	}
+
+	return it, nil
+}
+
+func validatePatchOperation(ifdMapping *exifcommon.IfdMapping, tagIndex *TagIndex, po PatchOperation) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch po.Op {
+	case PatchOpSet:
+		it, err := getPatchTag(ifdMapping, tagIndex, po.IfdPath, po.Tag)
+		log.PanicIf(err)
+
+		_, err = patchValueForTag(it, po.Value)
+		log.PanicIf(err)
+	case PatchOpDelete:
+		_, err := getPatchTag(ifdMapping, tagIndex, po.IfdPath, po.Tag)
+		log.PanicIf(err)
+	case PatchOpCopy, PatchOpRename:
+		fromIt, err := getPatchTag(ifdMapping, tagIndex, po.IfdPath, po.Tag)
+		log.PanicIf(err)
+
+		toIt, err := getPatchTag(ifdMapping, tagIndex, po.toIfdPath(), po.ToTag)
+		log.PanicIf(err)
+
+		if fromIt == toIt {
+			log.Panicf("source and destination are the same tag")
+		}
+
+		isCompatible := false
+		for _, tagType := range fromIt.SupportedTypes {
+			if toIt.DoesSupportType(tagType) == true {
+				isCompatible = true
+				break
+			}
+		}
+
+		if isCompatible == false {
+			log.Panicf("tag [%s] %v can not hold the value of tag [%s] %v", toIt.Name, toIt.SupportedTypes, fromIt.Name, fromIt.SupportedTypes)
+		}
+	case PatchOpShiftDate:
+		if po.Tag != "" {
+			it, err := getPatchTag(ifdMapping, tagIndex, po.IfdPath, po.Tag)
+			log.PanicIf(err)
+
+			if it.DoesSupportType(exifcommon.TypeAscii) == false {
+				log.Panicf("tag [%s] is not a timestamp", it.Name)
+			}
+		}
+
+		_, err := time.ParseDuration(po.Shift)
+		if err != nil {
+			log.Panicf("shift [%s] is not a valid duration: %s", po.Shift, err.Error())
+		}
+	case PatchOpSetGps:
+		if po.IfdPath != "" && po.IfdPath != exifcommon.IfdGpsInfoStandardIfdIdentity.String() {
+			log.Panicf("GPS information can only be set in [%s]", exifcommon.IfdGpsInfoStandardIfdIdentity.String())
+		} else if po.Latitude == nil || po.Longitude == nil {
+			log.Panicf("latitude and longitude are required")
+		} else if math.Abs(*po.Latitude) > 90.0 {
+			log.Panicf("latitude out of range: (%f)", *po.Latitude)
+		} else if math.Abs(*po.Longitude) > 180.0 {
+			log.Panicf("longitude out of range: (%f)", *po.Longitude)
+		}
+	default:
+		log.Panicf("operation [%s] not valid", po.Op)
+	}
+
+	return nil
+}
+
+func applyPatchOperation(rootIb *IfdBuilder, po PatchOperation) (message string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch po.Op {
+	case PatchOpSet:
+		ib, err := GetOrCreateIbFromRootIb(rootIb, po.IfdPath)
+		log.PanicIf(err)
+
+		it, err := rootIb.tagIndex.GetWithName(ib.IfdIdentity(), po.Tag)
+		log.PanicIf(err)
+
+		value, err := patchValueForTag(it, po.Value)
+		log.PanicIf(err)
+
+		err = ib.SetStandardWithName(po.Tag, value)
+		log.PanicIf(err)
+
+		return fmt.Sprintf("set to [%v]", value), nil
+	case PatchOpDelete:
+		ib, err := FindIbFromRootIb(rootIb, po.IfdPath)
+		if err != nil {
+			if log.Is(err, ErrChildIbNotFound) == true {
+				return "IFD not present", nil
+			}
+
+			log.Panic(err)
+		}
+
+		it, err := rootIb.tagIndex.GetWithName(ib.IfdIdentity(), po.Tag)
+		log.PanicIf(err)
+
+		n, err := ib.DeleteAll(it.Id)
+		log.PanicIf(err)
+
+		if n == 0 {
+			return "tag not present", nil
+		}
+
+		return fmt.Sprintf("deleted (%d) occurrence(s)", n), nil
+	case PatchOpCopy, PatchOpRename:
+		fromIb, err := FindIbFromRootIb(rootIb, po.IfdPath)
+		log.PanicIf(err)
+
+		fromBt, err := fromIb.FindTagWithName(po.Tag)
+		log.PanicIf(err)
+
+		if fromBt.value.IsIb() == true {
+			log.Panicf("tag [%s] is a child IFD and can not be copied", po.Tag)
+		}
+
+		toIb, err := GetOrCreateIbFromRootIb(rootIb, po.toIfdPath())
+		log.PanicIf(err)
+
+		toIt, err := rootIb.tagIndex.GetWithName(toIb.IfdIdentity(), po.ToTag)
+		log.PanicIf(err)
+
+		if toIt.DoesSupportType(fromBt.typeId) == false {
+			log.Panicf("tag [%s] does not support type [%s]", po.ToTag, fromBt.typeId)
+		}
+
+		valueBytes := make([]byte, len(fromBt.value.Bytes()))
+		copy(valueBytes, fromBt.value.Bytes())
+
+		toBt := NewBuilderTag(
+			toIb.IfdIdentity().UnindexedString(),
+			toIt.Id,
+			fromBt.typeId,
+			NewIfdBuilderTagValueFromBytes(valueBytes),
+			fromBt.byteOrder)
+
+		err = toIb.Set(toBt)
+		log.PanicIf(err)
+
+		if po.Op == PatchOpCopy {
+			return fmt.Sprintf("copied to [%s] [%s]", po.toIfdPath(), po.ToTag), nil
+		}
+
+		_, err = fromIb.DeleteAll(fromBt.tagId)
+		log.PanicIf(err)
+
+		return fmt.Sprintf("renamed to [%s] [%s]", po.toIfdPath(), po.ToTag), nil
+	case PatchOpShiftDate:
+		if po.Tag == "" {
+			duration, err := time.ParseDuration(po.Shift)
+			log.PanicIf(err)
+
+			shift := TimestampShift{
+				Duration:   duration,
+				IncludeGps: po.IncludeGps,
+			}
+
+			shifted, err := rootIb.ShiftTimestamps(shift)
+			log.PanicIf(err)
+
+			return fmt.Sprintf("shifted (%d) timestamps", len(shifted)), nil
+		}
+
+		ib, err := FindIbFromRootIb(rootIb, po.IfdPath)
+		log.PanicIf(err)
+
+		bt, err := ib.FindTagWithName(po.Tag)
+		log.PanicIf(err)
+
+		phrase := strings.TrimRight(string(bt.value.Bytes()), "\x00")
+
+		timestamp, err := ParseExifFullTimestamp(phrase)
+		log.PanicIf(err)
+
+		duration, err := time.ParseDuration(po.Shift)
+		log.PanicIf(err)
+
+		updatedPhrase := ExifFullTimestampString(timestamp.Add(duration))
+
+		err = ib.SetStandardWithName(po.Tag, updatedPhrase)
+		log.PanicIf(err)
+
+		return fmt.Sprintf("shifted [%s] to [%s]", phrase, updatedPhrase), nil
+	case PatchOpSetGps:
+		err := setGpsPosition(rootIb, *po.Latitude, *po.Longitude, po.Altitude)
+		log.PanicIf(err)
+
+		return fmt.Sprintf("set to (%f, %f)", *po.Latitude, *po.Longitude), nil
+	}
+
+	log.Panicf("operation [%s] not valid", po.Op)
+	return "", nil
+}
+
+// setGpsPosition writes the given decimal position to the GPS IFD, creating it
+// if necessary. The altitude is optional.
+func setGpsPosition(rootIb *IfdBuilder, latitude, longitude float64, altitude *float64) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	gpsIb, err := GetOrCreateIbFromRootIb(rootIb, exifcommon.IfdGpsInfoStandardIfdIdentity.String())
+	log.PanicIf(err)
+
+	_, err = gpsIb.FindTag(TagGpsVersionId)
+	if log.Is(err, ErrTagEntryNotFound) == true {
+		err = gpsIb.SetStandard(TagGpsVersionId, []byte{2, 2, 0, 0})
+		log.PanicIf(err)
+	} else if err != nil {
+		log.Panic(err)
+	}
+
+	latitudeGd := NewGpsDegreesFromDecimal(latitude, true)
+
+	err = gpsIb.SetStandard(TagLatitudeRefId, string([]byte{latitudeGd.Orientation}))
+	log.PanicIf(err)
+
+	err = gpsIb.SetStandard(TagLatitudeId, gpsDegreesRationals(latitudeGd))
+	log.PanicIf(err)
+
+	longitudeGd := NewGpsDegreesFromDecimal(longitude, false)
+
+	err = gpsIb.SetStandard(TagLongitudeRefId, string([]byte{longitudeGd.Orientation}))
+	log.PanicIf(err)
+
+	err = gpsIb.SetStandard(TagLongitudeId, gpsDegreesRationals(longitudeGd))
+	log.PanicIf(err)
+
+	if altitude != nil {
+		altitudeRef := byte(0)
+		if *altitude < 0 {
+			altitudeRef = 1
+		}
+
+		err = gpsIb.SetStandard(TagAltitudeRefId, []byte{altitudeRef})
+		log.PanicIf(err)
+
+		altitudeRational := []exifcommon.Rational{
+			{Numerator: uint32(math.Round(math.Abs(*altitude) * 100.0)), Denominator: 100},
+		}
+
+		err = gpsIb.SetStandard(TagAltitudeId, altitudeRational)
+		log.PanicIf(err)
+	}
+
+	return nil
+}
+
+// gpsDegreesRationals returns the rationals for the given coordinate,
+// preserving the fractional seconds (unlike `Raw()`). Seconds that round up
+// to 60 are carried into the minutes (and the minutes into the degrees).
+func gpsDegreesRationals(gd GpsDegrees) []exifcommon.Rational {
+	thousandths := uint64(math.Round(gd.Seconds * 1000.0))
+
+	minutes := uint64(gd.Minutes) + thousandths/60000
+	thousandths %= 60000
+
+	degrees := uint64(gd.Degrees) + minutes/60
+	minutes %= 60
+
+	return []exifcommon.Rational{
+		{Numerator: uint32(degrees), Denominator: 1},
+		{Numerator: uint32(minutes), Denominator: 1},
+		{Numerator: uint32(thousandths), Denominator: 1000},
+	}
+}
@@ -0,0 +1,197 @@
+package exif
+
+import (
+	"errors"
+	"io"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+var (
+	// ErrInPlaceNotPatchable means that the tag points to something other than
+	// its own value (a child IFD or the thumbnail) and can not be patched.
+	ErrInPlaceNotPatchable = errors.New("tag can not be patched in place")
+
+	// ErrInPlaceTypeMismatch means that the new value does not encode to the
+	// type that the tag already has.
+	ErrInPlaceTypeMismatch = errors.New("value does not have the type of the tag")
+
+	// ErrInPlaceValueTooLarge means that the new value needs more space than
+	// the existing one has.
+	ErrInPlaceValueTooLarge = errors.New("value does not fit in place")
+)
+
+// inPlaceWrite is one write, relative to the start of the EXIF block.
+type inPlaceWrite struct {
+	offset uint32
+	data   []byte
+}
+
+// InPlacePatcher overwrites tag values where they are already stored. Since
+// nothing moves, the offsets elsewhere in the file (including the ones inside
+// maker notes) stay valid and large files don't have to be rewritten. The new
+// value must have the same type as the tag and must not need more space than
+// the current one. A shorter value is allowed: the unit-count is updated and
+// the leftover space is zeroed.
+type InPlacePatcher struct {
+	w io.WriterAt
+
+	// exifOffset is the offset of the EXIF header in the file (e.g.
+	// `Scanner.Start`, or (0) for TIFF-based files).
+	exifOffset int64
+}
+
+// NewInPlacePatcher returns a patcher that writes to the given writer. The
+// EXIF block is expected to begin at `exifOffset`.
+func NewInPlacePatcher(w io.WriterAt, exifOffset int64) *InPlacePatcher {
+	return &InPlacePatcher{
+		w:          w,
+		exifOffset: exifOffset,
+	}
+}
+
+// writeSeekerAt adapts an `io.WriteSeeker` to an `io.WriterAt`.
+type writeSeekerAt struct {
+	ws io.WriteSeeker
+}
+
+func (wsa writeSeekerAt) WriteAt(p []byte, off int64) (n int, err error) {
+	_, err = wsa.ws.Seek(off, io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+
+	return wsa.ws.Write(p)
+}
+
+// NewInPlacePatcherWithWriteSeeker returns a patcher that writes to an
+// `io.WriteSeeker` (such as an `io.ReadWriteSeeker`). The position of the
+// writer is not restored.
+func NewInPlacePatcherWithWriteSeeker(ws io.WriteSeeker, exifOffset int64) *InPlacePatcher {
+	return NewInPlacePatcher(writeSeekerAt{ws: ws}, exifOffset)
+}
+
+// Patch overwrites the value of the given tag, which must have been parsed
+// from the same data that we are writing to. The tag itself is not updated;
+// parse the data again to see the new value.
+func (ipp *InPlacePatcher) Patch(ite *IfdTagEntry, value interface{}) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	writes, err := getInPlaceWrites(ite, value)
+	if err != nil {
+		if err == ErrInPlaceNotPatchable || err == ErrInPlaceTypeMismatch || err == ErrInPlaceValueTooLarge {
+			return err
+		}
+
+		log.Panic(err)
+	}
+
+	for _, ipw := range writes {
+		_, err := ipp.w.WriteAt(ipw.data, ipp.exifOffset+int64(ipw.offset))
+		log.PanicIf(err)
+	}
+
+	return nil
+}
+
+// getInPlaceWrites encodes the value and determines where it goes.
+func getInPlaceWrites(ite *IfdTagEntry, value interface{}) (writes []inPlaceWrite, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if ite.ChildIfdPath() != "" || ite.IsThumbnailOffset() == true {
+		return nil, ErrInPlaceNotPatchable
+	}
+
+	if sv, ok := value.(structuredValue); ok == true {
+		value = sv.primitiveValue()
+	}
+
+	var encoded []byte
+	var unitCount uint32
+
+	if ite.tagType == exifcommon.TypeUndefined {
+		encodeable, ok := value.(exifundefined.EncodeableValue)
+		if ok == false {
+			return nil, ErrInPlaceTypeMismatch
+		}
+
+		encoded, unitCount, err = ite.undefinedCodecs().EncodeTag(ite.IfdPath(), ite.tagId, encodeable, ite.byteOrder)
+		log.PanicIf(err)
+	} else {
+		ve := exifcommon.NewValueEncoder(ite.byteOrder)
+
+		var ed exifcommon.EncodedData
+
+		// Strings encode the same either way. Only the type differs.
+		if s, ok := value.(string); ok == true && ite.tagType == exifcommon.TypeUtf8 {
+			ed, err = ve.EncodeUtf8(s)
+		} else if s, ok := value.(string); ok == true && ite.tagType == exifcommon.TypeByte && isXpTag(ite.ifdIdentity.UnindexedString(), ite.tagId) == true {
+			encoded := encodeXpString(s)
+
+			ed = exifcommon.EncodedData{
+				Type:      exifcommon.TypeByte,
+				Encoded:   encoded,
+				UnitCount: uint32(len(encoded)),
+			}
+		} else {
+			ed, err = ve.Encode(value)
+		}
+
+		log.PanicIf(err)
+
+		if ed.Type != ite.tagType {
+			return nil, ErrInPlaceTypeMismatch
+		}
+
+		encoded = ed.Encoded
+		unitCount = ed.UnitCount
+	}
+
+	effectiveType := ite.tagType
+	if effectiveType == exifcommon.TypeUndefined {
+		effectiveType = exifcommon.TypeByte
+	}
+
+	currentSize := ite.unitCount * uint32(effectiveType.Size())
+	newSize := uint32(len(encoded))
+
+	// Small values always go in the entry itself. Anything else has to fit in
+	// the space that was allocated for the current value.
+	if newSize > 4 && newSize > currentSize {
+		return nil, ErrInPlaceValueTooLarge
+	}
+
+	writes = make([]inPlaceWrite, 0, 2)
+
+	if unitCount != ite.unitCount {
+		countBytes := make([]byte, 4)
+		ite.byteOrder.PutUint32(countBytes, unitCount)
+
+		writes = append(writes, inPlaceWrite{offset: ite.entryOffset + 4, data: countBytes})
+	}
+
+	if newSize <= 4 {
+		slot := make([]byte, 4)
+		copy(slot, encoded)
+
+		writes = append(writes, inPlaceWrite{offset: ite.entryOffset + 8, data: slot})
+	} else {
+		data := make([]byte, currentSize)
+		copy(data, encoded)
+
+		writes = append(writes, inPlaceWrite{offset: ite.valueOffset, data: data})
+	}
+
+	return writes, nil
+}
@@ -0,0 +1,141 @@
+package exif
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"io/ioutil"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func getInPlaceTestTag(index IfdIndex, fqIfdPath string, tagName string) *IfdTagEntry {
+	results, err := index.Lookup[fqIfdPath].FindTagWithName(tagName)
+	log.PanicIf(err)
+
+	return results[0]
+}
+
+func TestInPlacePatcher_Patch(t *testing.T) {
+	exifData := getTestExifData()
+
+	index := getTestIndex(exifData)
+
+	// Put something in front of the EXIF data, as with a JPEG.
+	prefix := []byte("leading data")
+
+	filepath := path.Join(t.TempDir(), "patch.exif")
+
+	err := ioutil.WriteFile(filepath, append(prefix, exifData...), 0644)
+	log.PanicIf(err)
+
+	f, err := os.OpenFile(filepath, os.O_RDWR, 0)
+	log.PanicIf(err)
+
+	defer f.Close()
+
+	ipp := NewInPlacePatcherWithWriteSeeker(f, int64(len(prefix)))
+
+	patches := []struct {
+		tagName string
+		value   interface{}
+	}{
+		{"Orientation", []uint16{6}},
+		{"XResolution", []exifcommon.Rational{{Numerator: 300, Denominator: 1}}},
+		{"Model", "Canon EOS 6D Mark II"},
+		{"Make", "Sony"},
+	}
+
+	for _, patch := range patches {
+		err := ipp.Patch(getInPlaceTestTag(index, "IFD", patch.tagName), patch.value)
+		log.PanicIf(err)
+	}
+
+	f.Close()
+
+	patchedData, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	if len(patchedData) != len(prefix)+len(exifData) {
+		t.Fatalf("File size changed: (%d)", len(patchedData))
+	} else if bytes.Equal(patchedData[:len(prefix)], prefix) == false {
+		t.Fatalf("Leading data changed.")
+	}
+
+	patchedIndex := getTestIndex(patchedData[len(prefix):])
+
+	for _, patch := range patches {
+		value, err := getInPlaceTestTag(patchedIndex, "IFD", patch.tagName).Value()
+		log.PanicIf(err)
+
+		if reflect.DeepEqual(value, patch.value) == false {
+			t.Fatalf("Value of [%s] not correct: %v", patch.tagName, value)
+		}
+	}
+
+	// Nothing else changed.
+
+	diff, err := DiffIfdIndexes(index, patchedIndex)
+	log.PanicIf(err)
+
+	changed := make(map[uint16]bool)
+	for _, de := range diff.Entries {
+		changed[de.TagId] = true
+	}
+
+	expected := map[uint16]bool{
+		0x010f: true,
+		0x0110: true,
+		0x0112: true,
+		0x011a: true,
+	}
+
+	if reflect.DeepEqual(changed, expected) == false {
+		t.Fatalf("Changes not correct:\n%s", diff)
+	}
+}
+
+func TestInPlacePatcher_Patch_Refused(t *testing.T) {
+	exifData := getTestExifData()
+
+	index := getTestIndex(exifData)
+
+	f, err := ioutil.TempFile(t.TempDir(), "patch")
+	log.PanicIf(err)
+
+	defer f.Close()
+
+	_, err = f.Write(exifData)
+	log.PanicIf(err)
+
+	ipp := NewInPlacePatcher(f, 0)
+
+	cases := []struct {
+		tagName  string
+		value    interface{}
+		expected error
+	}{
+		{"Make", "Panasonic", ErrInPlaceValueTooLarge},
+		{"Orientation", []uint32{6}, ErrInPlaceTypeMismatch},
+		{"ExifTag", []uint32{0}, ErrInPlaceNotPatchable},
+	}
+
+	for _, c := range cases {
+		err := ipp.Patch(getInPlaceTestTag(index, "IFD", c.tagName), c.value)
+		if err != c.expected {
+			t.Fatalf("Expected [%v] for [%s]: %v", c.expected, c.tagName, err)
+		}
+	}
+
+	written, err := ioutil.ReadFile(f.Name())
+	log.PanicIf(err)
+
+	if bytes.Equal(written, exifData) == false {
+		t.Fatalf("Refused patches should not write anything.")
+	}
+}
@@ -0,0 +1,325 @@
+package exif
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func TestParsePatchJson(t *testing.T) {
+	data := []byte(`{
+		"operations": [
+			{"op": "set", "ifd_path": "IFD", "tag": "Artist", "value": "Some Artist"},
+			{"op": "set", "ifd_path": "IFD", "tag": "Orientation", "value": 6},
+			{"op": "set-gps", "latitude": -33.8675, "longitude": 151.2, "altitude": 12.5}
+		]
+	}`)
+
+	patch, err := ParsePatchJson(data)
+	log.PanicIf(err)
+
+	if len(patch.Operations) != 3 {
+		t.Fatalf("Operation count not correct: (%d)", len(patch.Operations))
+	}
+
+	po := patch.Operations[1]
+	if po.Op != PatchOpSet || po.IfdPath != "IFD" || po.Tag != "Orientation" || po.Value != float64(6) {
+		t.Fatalf("Operation not correct: %v", po)
+	}
+
+	po = patch.Operations[2]
+	if *po.Latitude != -33.8675 || *po.Longitude != 151.2 || *po.Altitude != 12.5 {
+		t.Fatalf("GPS operation not correct: %v", po)
+	}
+}
+
+func TestParsePatchYaml(t *testing.T) {
+	data := []byte(`
+operations:
+- op: set
+  ifd_path: IFD
+  tag: XResolution
+  value: 300/1
+- op: shift-date
+  ifd_path: IFD/Exif
+  tag: DateTimeOriginal
+  shift: -1h
+`)
+
+	patch, err := ParsePatchYaml(data)
+	log.PanicIf(err)
+
+	if len(patch.Operations) != 2 {
+		t.Fatalf("Operation count not correct: (%d)", len(patch.Operations))
+	}
+
+	po := patch.Operations[0]
+	if po.Tag != "XResolution" || po.Value != "300/1" {
+		t.Fatalf("Operation not correct: %v", po)
+	}
+
+	po = patch.Operations[1]
+	if po.Op != PatchOpShiftDate || po.Shift != "-1h" {
+		t.Fatalf("Operation not correct: %v", po)
+	}
+}
+
+func TestPatch_Apply(t *testing.T) {
+	data := []byte(`{
+		"operations": [
+			{"op": "set", "ifd_path": "IFD", "tag": "Artist", "value": "Some Artist"},
+			{"op": "set", "ifd_path": "IFD", "tag": "XResolution", "value": "300/1"},
+			{"op": "set", "ifd_path": "IFD", "tag": "Orientation", "value": 6},
+			{"op": "delete", "ifd_path": "IFD", "tag": "Copyright"},
+			{"op": "delete", "ifd_path": "IFD", "tag": "Software"},
+			{"op": "copy", "ifd_path": "IFD", "tag": "Model", "to_tag": "ImageDescription"},
+			{"op": "rename", "ifd_path": "IFD", "tag": "Make", "to_tag": "DocumentName"},
+			{"op": "shift-date", "ifd_path": "IFD/Exif", "tag": "DateTimeOriginal", "shift": "-25h"},
+			{"op": "set-gps", "latitude": -33.8675, "longitude": 151.2, "altitude": -12.5}
+		]
+	}`)
+
+	patch, err := ParsePatchJson(data)
+	log.PanicIf(err)
+
+	rootIb := getTestRootIb()
+
+	results, err := patch.Apply(rootIb)
+	log.PanicIf(err)
+
+	for _, pr := range results {
+		if pr.Applied != true {
+			t.Fatalf("Operation not applied: %s", pr)
+		}
+	}
+
+	if results[4].Message != "tag not present" {
+		t.Fatalf("Delete of missing tag not reported: %s", results[4])
+	}
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	index := getTestIndex(exifData)
+
+	if value := getTestTagValue(t, index, "IFD", 0x013b); value != "Some Artist" {
+		t.Fatalf("Artist not correct: [%v]", value)
+	}
+
+	expectedResolution := []exifcommon.Rational{{Numerator: 300, Denominator: 1}}
+	if value := getTestTagValue(t, index, "IFD", 0x011a); reflect.DeepEqual(value, expectedResolution) != true {
+		t.Fatalf("XResolution not correct: [%v]", value)
+	}
+
+	if value := getTestTagValue(t, index, "IFD", 0x0112); reflect.DeepEqual(value, []uint16{6}) != true {
+		t.Fatalf("Orientation not correct: [%v]", value)
+	}
+
+	if _, err := index.RootIfd.FindTagWithId(0x8298); log.Is(err, ErrTagNotFound) == false {
+		t.Fatalf("Copyright not deleted.")
+	}
+
+	if value := getTestTagValue(t, index, "IFD", 0x010e); value != "Canon EOS 5D Mark III" {
+		t.Fatalf("ImageDescription not correct: [%v]", value)
+	}
+
+	if value := getTestTagValue(t, index, "IFD", 0x0110); value != "Canon EOS 5D Mark III" {
+		t.Fatalf("Model not retained after copy: [%v]", value)
+	}
+
+	if value := getTestTagValue(t, index, "IFD", 0x010d); value != "Canon" {
+		t.Fatalf("DocumentName not correct: [%v]", value)
+	}
+
+	if _, err := index.RootIfd.FindTagWithId(0x010f); log.Is(err, ErrTagNotFound) == false {
+		t.Fatalf("Make not removed by rename.")
+	}
+
+	if value := getTestTagValue(t, index, "IFD/Exif", 0x9003); value != "2017:12:01 07:18:50" {
+		t.Fatalf("DateTimeOriginal not correct: [%v]", value)
+	}
+
+	gpsIfd := index.Lookup["IFD/GPSInfo"]
+
+	gi, err := gpsIfd.GpsInfo()
+	log.PanicIf(err)
+
+	if math.Abs(gi.Latitude.Decimal()-(-33.8675)) > 0.000001 {
+		t.Fatalf("Latitude not correct: %s", gi.Latitude)
+	} else if math.Abs(gi.Longitude.Decimal()-151.2) > 0.000001 {
+		t.Fatalf("Longitude not correct: %s", gi.Longitude)
+	}
+
+	if value := getTestTagValue(t, index, "IFD/GPSInfo", TagAltitudeRefId); reflect.DeepEqual(value, []byte{1}) != true {
+		t.Fatalf("Altitude-ref not correct: [%v]", value)
+	}
+}
+
+func TestPatch_Apply_NotValid(t *testing.T) {
+	data := []byte(`{
+		"operations": [
+			{"op": "set", "ifd_path": "IFD", "tag": "Artist", "value": "Some Artist"},
+			{"op": "set", "ifd_path": "IFD", "tag": "NotARealTag", "value": "x"},
+			{"op": "set", "ifd_path": "IFD", "tag": "Orientation", "value": "abc"},
+			{"op": "set", "ifd_path": "IFD/NotAnIfd", "tag": "Artist", "value": "x"},
+			{"op": "copy", "ifd_path": "IFD", "tag": "Model", "to_tag": "Orientation"},
+			{"op": "shift-date", "ifd_path": "IFD", "tag": "DateTime", "shift": "tomorrow"},
+			{"op": "set-gps", "latitude": 91, "longitude": 0},
+			{"op": "explode"}
+		]
+	}`)
+
+	patch, err := ParsePatchJson(data)
+	log.PanicIf(err)
+
+	rootIb := getTestRootIb()
+
+	results, err := patch.Apply(rootIb)
+	if err != ErrPatchNotValid {
+		t.Fatalf("Expected validation failure: %v", err)
+	}
+
+	if results[0].Err != nil {
+		t.Fatalf("First operation should be valid: %s", results[0])
+	}
+
+	for _, pr := range results[1:] {
+		if pr.Err == nil {
+			t.Fatalf("Operation should not be valid: %s", pr)
+		} else if pr.Applied == true {
+			t.Fatalf("Operation should not be applied: %s", pr)
+		}
+	}
+
+	bt, err := rootIb.FindTagWithName("Artist")
+	log.PanicIf(err)
+
+	if string(bt.value.Bytes()) == "Some Artist\000" {
+		t.Fatalf("Valid operation was applied despite a failed validation.")
+	}
+}
+
+func TestPatch_Apply_Failed(t *testing.T) {
+	patch := &Patch{
+		Operations: []PatchOperation{
+			{Op: PatchOpSet, IfdPath: "IFD", Tag: "Artist", Value: "Some Artist"},
+			{Op: PatchOpCopy, IfdPath: "IFD", Tag: "Software", ToTag: "DocumentName"},
+			{Op: PatchOpSet, IfdPath: "IFD", Tag: "Copyright", Value: "Someone"},
+		},
+	}
+
+	rootIb := getTestRootIb()
+
+	results, err := patch.Apply(rootIb)
+	if err != ErrPatchFailed {
+		t.Fatalf("Expected apply failure: %v", err)
+	}
+
+	if results[0].Applied != true {
+		t.Fatalf("First operation should be applied: %s", results[0])
+	} else if results[1].Err == nil {
+		t.Fatalf("Second operation should fail: %s", results[1])
+	} else if results[2].Applied == true || results[2].Err != nil {
+		t.Fatalf("Third operation should be skipped: %s", results[2])
+	}
+}
+
+func TestPatch_Apply_ShiftAllDates(t *testing.T) {
+	patch, err := ParsePatchYaml([]byte(`
+operations:
+- op: shift-date
+  shift: 1h
+`))
+	log.PanicIf(err)
+
+	rootIb := getTestRootIb()
+
+	results, err := patch.Apply(rootIb)
+	log.PanicIf(err)
+
+	if results[0].Message != "shifted (3) timestamps" {
+		t.Fatalf("Result not correct: %s", results[0])
+	}
+
+	if phrase := getTimestampTestString(t, rootIb, "IFD/Exif", TagDateTimeDigitized); phrase != "2017:12:02 09:18:50" {
+		t.Fatalf("DateTimeDigitized not correct: [%s]", phrase)
+	}
+}
+
+func TestGpsDegreesRationals_Carry(t *testing.T) {
+	testCases := []struct {
+		gd       GpsDegrees
+		expected []exifcommon.Rational
+	}{
+		{
+			GpsDegrees{Degrees: 12, Minutes: 34, Seconds: 56.789},
+			[]exifcommon.Rational{{Numerator: 12, Denominator: 1}, {Numerator: 34, Denominator: 1}, {Numerator: 56789, Denominator: 1000}},
+		},
+		{
+			GpsDegrees{Degrees: 12, Minutes: 34, Seconds: 59.9996},
+			[]exifcommon.Rational{{Numerator: 12, Denominator: 1}, {Numerator: 35, Denominator: 1}, {Numerator: 0, Denominator: 1000}},
+		},
+		{
+			GpsDegrees{Degrees: 12, Minutes: 59, Seconds: 59.9999},
+			[]exifcommon.Rational{{Numerator: 13, Denominator: 1}, {Numerator: 0, Denominator: 1}, {Numerator: 0, Denominator: 1000}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		rationals := gpsDegreesRationals(testCase.gd)
+		if reflect.DeepEqual(rationals, testCase.expected) != true {
+			t.Fatalf("Rationals not correct for %v: %v", testCase.gd, rationals)
+		}
+	}
+}
+
+func TestPatchValueForTag_Rationals(t *testing.T) {
+	ti := NewTagIndex()
+
+	// ExposureTime in IFD0 supports both RATIONAL and SRATIONAL.
+
+	it, err := ti.Get(exifcommon.IfdStandardIfdIdentity, 0x829a)
+	log.PanicIf(err)
+
+	value, err := patchValueForTag(it, "1/250")
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, []exifcommon.Rational{{Numerator: 1, Denominator: 250}}) != true {
+		t.Fatalf("Unsigned value not correct: %v", value)
+	}
+
+	value, err = patchValueForTag(it, "1/-250")
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, []exifcommon.SignedRational{{Numerator: 1, Denominator: -250}}) != true {
+		t.Fatalf("Signed value not correct: %v", value)
+	}
+
+	// XResolution only supports RATIONAL.
+
+	it, err = ti.Get(exifcommon.IfdStandardIfdIdentity, 0x011a)
+	log.PanicIf(err)
+
+	_, err = patchValueForTag(it, "-72/1")
+	if err == nil {
+		t.Fatalf("Expected error for negative value of RATIONAL-only tag.")
+	}
+
+	// ExposureBiasValue only supports SRATIONAL, whatever the sign.
+
+	it, err = ti.Get(exifcommon.IfdExifStandardIfdIdentity, 0x9204)
+	log.PanicIf(err)
+
+	value, err = patchValueForTag(it, "1/3")
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, []exifcommon.SignedRational{{Numerator: 1, Denominator: 3}}) != true {
+		t.Fatalf("SRATIONAL-only value not correct: %v", value)
+	}
+}
@@ -0,0 +1,573 @@
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+// RepairChangeType describes the kind of change that a `RepairChange`
+// represents.
+type RepairChangeType string
+
+const (
+	// RepairTagDropped indicates a tag that could not be recovered and was
+	// left out.
+	RepairTagDropped RepairChangeType = "tag-dropped"
+
+	// RepairTagMoved indicates a tag that was moved to the IFD that it belongs
+	// in.
+	RepairTagMoved RepairChangeType = "tag-moved"
+
+	// RepairTypeChanged indicates a tag whose value was converted to a type
+	// that is valid for it.
+	RepairTypeChanged RepairChangeType = "type-changed"
+
+	// RepairChildIfdDropped indicates a child-IFD pointer whose IFD could not
+	// be recovered and that was left out.
+	RepairChildIfdDropped RepairChangeType = "child-ifd-dropped"
+
+	// RepairThumbnailResized indicates a thumbnail whose length was
+	// recomputed.
+	RepairThumbnailResized RepairChangeType = "thumbnail-resized"
+
+	// RepairThumbnailDropped indicates a thumbnail that could not be recovered
+	// and was left out.
+	RepairThumbnailDropped RepairChangeType = "thumbnail-dropped"
+)
+
+var (
+	jpegSoiMarker = []byte{0xff, 0xd8}
+	jpegEoiMarker = []byte{0xff, 0xd9}
+)
+
+// RepairChange describes one change made by `RepairExifData`. `FqIfdPath` is
+// where the tag was found in the damaged data.
+type RepairChange struct {
+	Change    RepairChangeType `json:"change"`
+	FqIfdPath string           `json:"ifd_path"`
+
+	TagId   uint16 `json:"tag_id,omitempty"`
+	TagName string `json:"tag_name,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// String returns a one-line description of the change.
+func (rc RepairChange) String() string {
+	if rc.TagName == "" && rc.TagId == 0 {
+		return fmt.Sprintf("%s [%s]: %s", rc.Change, rc.FqIfdPath, rc.Message)
+	}
+
+	return fmt.Sprintf("%s [%s] (0x%04x) [%s]: %s", rc.Change, rc.FqIfdPath, rc.TagId, rc.TagName, rc.Message)
+}
+
+// RepairResult is the output of `RepairExifData`.
+type RepairResult struct {
+	// ExifData is the rebuilt EXIF blob, starting with the TIFF header. It is
+	// written in the byte-order of the original.
+	ExifData []byte
+
+	// ByteOrder is the byte-order of the original (and of `ExifData`).
+	ByteOrder binary.ByteOrder
+
+	// Changes is the change log: every tag that was moved, converted, or left
+	// out on top of the damage that the salvage already reported.
+	Changes []RepairChange
+
+	// Damage is the damage report of the salvage parse that the repair was
+	// built from.
+	Damage []Diagnostic
+}
+
+// repairer holds the state of one repair.
+type repairer struct {
+	data      []byte
+	byteOrder binary.ByteOrder
+
+	ifdMapping *exifcommon.IfdMapping
+	tagIndex   *TagIndex
+
+	changes []RepairChange
+}
+
+// RepairExifData rebuilds a valid EXIF blob from a damaged one. The data is
+// parsed with `SalvageFlatExifData` and only the tags that were recovered
+// completely are re-encoded (with `IfdByteEncoder`). Along the way:
+//
+//   - Tags that are filed in the wrong IFD are moved to the IFD that
+//     `TagIndex.FindFirst` finds them in (if it doesn't already have them).
+//   - Tags with a type that is not valid for them are converted to one that
+//     is, if it can be done without loss (e.g. LONG to SHORT or ASCII to
+//     UNDEFINED). Otherwise, they are dropped.
+//   - Child-IFD pointers whose IFD could not be recovered are dropped.
+//   - The thumbnail length is recomputed from the JPEG markers if the recorded
+//     length is wrong or the thumbnail is cut off.
+//
+// Every change is recorded in the change log of the result.
+func RepairExifData(exifData []byte) (rr *RepairResult, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	sr, err := SalvageFlatExifData(exifData)
+	if err != nil {
+		if err == ErrNothingSalvaged {
+			return nil, err
+		}
+
+		log.Panic(err)
+	}
+
+	rp := &repairer{
+		data:       exifData,
+		byteOrder:  sr.ByteOrder,
+		ifdMapping: NewIfdMappingWithStandard(),
+		tagIndex:   StandardTagIndex(),
+		changes:    make([]RepairChange, 0),
+	}
+
+	exifTags := rp.repairTags(sr.Tags)
+	if len(exifTags) == 0 {
+		return nil, ErrNothingSalvaged
+	}
+
+	exifTags = rp.dropDanglingChildIfds(exifTags)
+
+	rootIb := NewIfdBuilder(rp.ifdMapping, rp.tagIndex, exifcommon.IfdStandardIfdIdentity, rp.byteOrder)
+
+	var thumbnailTags []ExifTag
+	for _, et := range exifTags {
+		if et.IfdPath == ThumbnailFqIfdPath && (et.TagId == ThumbnailOffsetTagId || et.TagId == ThumbnailSizeTagId) {
+			thumbnailTags = append(thumbnailTags, et)
+			continue
+		}
+
+		ib, err := GetOrCreateIbFromRootIb(rootIb, et.IfdPath)
+		log.PanicIf(err)
+
+		if et.ChildIfdPath != "" {
+			childFqIfdPath := fmt.Sprintf("%s/%s", et.IfdPath, path.Base(et.ChildIfdPath))
+
+			_, err := GetOrCreateIbFromRootIb(rootIb, childFqIfdPath)
+			log.PanicIf(err)
+
+			continue
+		}
+
+		bt, err := newBuilderTagFromExifTag(ib, et)
+		if err != nil {
+			rp.addTagChange(RepairTagDropped, et, "value could not be encoded: %s", err.Error())
+			continue
+		}
+
+		err = ib.Add(bt)
+		log.PanicIf(err)
+	}
+
+	thumbnail := rp.repairThumbnail(thumbnailTags)
+	if thumbnail != nil {
+		ib, err := GetOrCreateIbFromRootIb(rootIb, ThumbnailFqIfdPath)
+		log.PanicIf(err)
+
+		err = ib.SetThumbnail(thumbnail)
+		log.PanicIf(err)
+	}
+
+	ibe := NewIfdByteEncoder()
+
+	encoded, err := ibe.EncodeToExif(rootIb)
+	log.PanicIf(err)
+
+	rr = &RepairResult{
+		ExifData:  encoded,
+		ByteOrder: rp.byteOrder,
+		Changes:   rp.changes,
+		Damage:    sr.Damage,
+	}
+
+	return rr, nil
+}
+
+// addTagChange records a change to a tag.
+func (rp *repairer) addTagChange(change RepairChangeType, et ExifTag, format string, args ...interface{}) {
+	rc := RepairChange{
+		Change:    change,
+		FqIfdPath: et.IfdPath,
+		TagId:     et.TagId,
+		TagName:   et.TagName,
+		Message:   fmt.Sprintf(format, args...),
+	}
+
+	rp.changes = append(rp.changes, rc)
+}
+
+// repairTags drops the partial tags, moves the mis-filed tags, and fixes the
+// types.
+func (rp *repairer) repairTags(salvagedTags []SalvagedTag) (exifTags []ExifTag) {
+	exifTags = make([]ExifTag, 0, len(salvagedTags))
+
+	// These are the tags that are in the right place, so that we don't move a
+	// tag into an IFD that already has it.
+	present := make(map[string]map[uint16]struct{})
+
+	for _, st := range salvagedTags {
+		if _, found := present[st.IfdPath]; found == false {
+			present[st.IfdPath] = make(map[uint16]struct{})
+		}
+
+		present[st.IfdPath][st.TagId] = struct{}{}
+	}
+
+	for _, st := range salvagedTags {
+		et := st.ExifTag
+
+		if st.Partial == true {
+			rp.addTagChange(RepairTagDropped, et, "value is cut off")
+			continue
+		} else if et.ChildIfdPath != "" {
+			exifTags = append(exifTags, et)
+			continue
+		}
+
+		ii, err := exifcommon.NewIfdIdentityFromString(rp.ifdMapping, et.IfdPath)
+		log.PanicIf(err)
+
+		it, err := rp.tagIndex.Get(ii, et.TagId)
+		if err == ErrTagNotFound {
+			it, err = rp.tagIndex.FindFirst(et.TagId, et.TagTypeId, nil)
+			if err == ErrTagNotFound {
+				// We don't know it anywhere. Keep it where it is.
+				exifTags = append(exifTags, et)
+				continue
+			}
+
+			log.PanicIf(err)
+
+			// The tag-index has unindexed paths. Those are also the
+			// fully-qualified paths of the first IFD of each kind.
+			if _, found := present[it.IfdPath][et.TagId]; found == true {
+				rp.addTagChange(RepairTagDropped, et, "tag belongs in IFD [%s], which already has it", it.IfdPath)
+				continue
+			}
+
+			rp.addTagChange(RepairTagMoved, et, "moved to IFD [%s]", it.IfdPath)
+
+			if _, found := present[it.IfdPath]; found == false {
+				present[it.IfdPath] = make(map[uint16]struct{})
+			}
+
+			present[it.IfdPath][et.TagId] = struct{}{}
+
+			et.IfdPath = it.IfdPath
+		} else {
+			log.PanicIf(err)
+		}
+
+		if it.DoesSupportType(et.TagTypeId) == true {
+			exifTags = append(exifTags, et)
+			continue
+		}
+
+		converted, ok := rp.convertTag(et, it)
+		if ok == false {
+			rp.addTagChange(RepairTagDropped, et, "type [%s] is not valid and the value can not be converted", et.TagTypeId)
+			continue
+		}
+
+		rp.addTagChange(RepairTypeChanged, et, "converted from [%s] to [%s]", et.TagTypeId, converted.TagTypeId)
+
+		exifTags = append(exifTags, converted)
+	}
+
+	return exifTags
+}
+
+// convertTag converts the value of a tag to the first type that the
+// tag-index allows and that it can be converted to without loss.
+func (rp *repairer) convertTag(et ExifTag, it *IndexedTag) (converted ExifTag, ok bool) {
+	ve := exifcommon.NewValueEncoder(rp.byteOrder)
+
+	for _, toType := range it.SupportedTypes {
+		value, ok := repairConvertValue(et.Value, et.ValueBytes, et.TagTypeId, toType)
+		if ok == false {
+			continue
+		}
+
+		converted = et
+		converted.TagTypeId = toType
+		converted.TagTypeName = toType.String()
+		converted.Value = value
+
+		if toType == exifcommon.TypeUndefined {
+			converted.ValueBytes = value.([]byte)
+			converted.UnitCount = uint32(len(converted.ValueBytes))
+		} else {
+			ed, err := ve.Encode(value)
+			if err != nil {
+				continue
+			}
+
+			converted.ValueBytes = ed.Encoded
+			converted.UnitCount = ed.UnitCount
+		}
+
+		return converted, true
+	}
+
+	return converted, false
+}
+
+// repairConvertValue converts a value between the integer types, between the
+// rational types, or between the byte-like types. `ok` is false if the types
+// are not compatible or a unit does not fit.
+func repairConvertValue(value interface{}, valueBytes []byte, fromType, toType exifcommon.TagTypePrimitive) (converted interface{}, ok bool) {
+	if integers, ok := integerValueUnits(value); ok == true {
+		switch toType {
+		case exifcommon.TypeByte:
+			byteValues := make([]byte, len(integers))
+			for i, n := range integers {
+				if n < 0 || n > 0xff {
+					return nil, false
+				}
+
+				byteValues[i] = byte(n)
+			}
+
+			return byteValues, true
+		case exifcommon.TypeShort:
+			shorts := make([]uint16, len(integers))
+			for i, n := range integers {
+				if n < 0 || n > 0xffff {
+					return nil, false
+				}
+
+				shorts[i] = uint16(n)
+			}
+
+			return shorts, true
+		case exifcommon.TypeLong:
+			longs := make([]uint32, len(integers))
+			for i, n := range integers {
+				if n < 0 || n > 0xffffffff {
+					return nil, false
+				}
+
+				longs[i] = uint32(n)
+			}
+
+			return longs, true
+		case exifcommon.TypeSignedLong:
+			signedLongs := make([]int32, len(integers))
+			for i, n := range integers {
+				if n < -0x80000000 || n > 0x7fffffff {
+					return nil, false
+				}
+
+				signedLongs[i] = int32(n)
+			}
+
+			return signedLongs, true
+		}
+	}
+
+	switch t := value.(type) {
+	case []exifcommon.Rational:
+		if toType != exifcommon.TypeSignedRational {
+			break
+		}
+
+		signedRationals := make([]exifcommon.SignedRational, len(t))
+		for i, r := range t {
+			if r.Numerator > 0x7fffffff || r.Denominator > 0x7fffffff {
+				return nil, false
+			}
+
+			signedRationals[i] = exifcommon.SignedRational{Numerator: int32(r.Numerator), Denominator: int32(r.Denominator)}
+		}
+
+		return signedRationals, true
+	case []exifcommon.SignedRational:
+		if toType != exifcommon.TypeRational {
+			break
+		}
+
+		rationals := make([]exifcommon.Rational, len(t))
+		for i, r := range t {
+			if r.Numerator < 0 || r.Denominator < 0 {
+				return nil, false
+			}
+
+			rationals[i] = exifcommon.Rational{Numerator: uint32(r.Numerator), Denominator: uint32(r.Denominator)}
+		}
+
+		return rationals, true
+	}
+
+	isByteLike := func(tagType exifcommon.TagTypePrimitive) bool {
+		return tagType == exifcommon.TypeByte || tagType == exifcommon.TypeAscii || tagType == exifcommon.TypeAsciiNoNul || tagType == exifcommon.TypeUtf8 || tagType == exifcommon.TypeUndefined
+	}
+
+	if isByteLike(fromType) == false || isByteLike(toType) == false || valueBytes == nil {
+		return nil, false
+	}
+
+	if toType == exifcommon.TypeAscii || toType == exifcommon.TypeUtf8 {
+		if i := bytes.IndexByte(valueBytes, 0); i >= 0 {
+			valueBytes = valueBytes[:i]
+		}
+
+		return string(valueBytes), true
+	}
+
+	return valueBytes, true
+}
+
+// integerValueUnits returns the units of an integer value of any integer type.
+func integerValueUnits(value interface{}) (integers []int64, ok bool) {
+	switch t := value.(type) {
+	case []uint8:
+		integers = make([]int64, len(t))
+		for i, n := range t {
+			integers[i] = int64(n)
+		}
+	case []uint16:
+		integers = make([]int64, len(t))
+		for i, n := range t {
+			integers[i] = int64(n)
+		}
+	case []uint32:
+		integers = make([]int64, len(t))
+		for i, n := range t {
+			integers[i] = int64(n)
+		}
+	case []int32:
+		integers = make([]int64, len(t))
+		for i, n := range t {
+			integers[i] = int64(n)
+		}
+	default:
+		return nil, false
+	}
+
+	return integers, true
+}
+
+// dropDanglingChildIfds drops the child-IFD pointers of IFDs that have no
+// tags. Dropping a pointer can leave its parent empty, so this repeats until
+// nothing changes.
+func (rp *repairer) dropDanglingChildIfds(exifTags []ExifTag) []ExifTag {
+	for {
+		populated := make(map[string]struct{})
+		for _, et := range exifTags {
+			populated[et.IfdPath] = struct{}{}
+		}
+
+		filtered := make([]ExifTag, 0, len(exifTags))
+		for _, et := range exifTags {
+			if et.ChildIfdPath != "" {
+				childFqIfdPath := fmt.Sprintf("%s/%s", et.IfdPath, path.Base(et.ChildIfdPath))
+
+				if _, found := populated[childFqIfdPath]; found == false {
+					rp.addTagChange(RepairChildIfdDropped, et, "child IFD [%s] could not be recovered", childFqIfdPath)
+					continue
+				}
+			}
+
+			filtered = append(filtered, et)
+		}
+
+		if len(filtered) == len(exifTags) {
+			return filtered
+		}
+
+		exifTags = filtered
+	}
+}
+
+// repairThumbnail returns the thumbnail data described by the thumbnail
+// offset and length tags, or nil if it could not be recovered. If the
+// recorded length is wrong and the thumbnail is a JPEG, the length is
+// recomputed from the end-of-image marker.
+func (rp *repairer) repairThumbnail(thumbnailTags []ExifTag) (thumbnail []byte) {
+	var offsetTag, lengthTag *ExifTag
+	for i, et := range thumbnailTags {
+		if et.TagId == ThumbnailOffsetTagId {
+			offsetTag = &thumbnailTags[i]
+		} else {
+			lengthTag = &thumbnailTags[i]
+		}
+	}
+
+	if offsetTag == nil {
+		if lengthTag != nil {
+			rp.addTagChange(RepairThumbnailDropped, *lengthTag, "thumbnail has a length but no offset")
+		}
+
+		return nil
+	}
+
+	offset, found := repairFirstUint(offsetTag.Value)
+	if found == false || offset >= uint64(len(rp.data)) {
+		rp.addTagChange(RepairThumbnailDropped, *offsetTag, "thumbnail offset is outside of the data")
+		return nil
+	}
+
+	available := rp.data[offset:]
+
+	length := uint64(0)
+	hasLength := false
+	if lengthTag != nil {
+		length, hasLength = repairFirstUint(lengthTag.Value)
+	}
+
+	isJpeg := bytes.HasPrefix(available, jpegSoiMarker)
+
+	if hasLength == true && length > 0 && length <= uint64(len(available)) {
+		thumbnail = available[:length]
+
+		// Either it is not a JPEG (in which case we have no way of checking
+		// the length) or it is a complete JPEG.
+		if isJpeg == false || bytes.HasSuffix(thumbnail, jpegEoiMarker) == true {
+			return thumbnail
+		}
+	}
+
+	if isJpeg == true {
+		if i := bytes.Index(available[len(jpegSoiMarker):], jpegEoiMarker); i >= 0 {
+			recomputed := available[:len(jpegSoiMarker)+i+len(jpegEoiMarker)]
+
+			rp.addTagChange(RepairThumbnailResized, *offsetTag, "thumbnail length recomputed from (%d) to (%d)", length, len(recomputed))
+
+			return recomputed
+		}
+	}
+
+	rp.addTagChange(RepairThumbnailDropped, *offsetTag, "thumbnail at offset (0x%08x) is cut off or its length (%d) is not valid", offset, length)
+
+	return nil
+}
+
+// repairFirstUint returns the first unit of an unsigned integer value.
+func repairFirstUint(value interface{}) (n uint64, found bool) {
+	switch t := value.(type) {
+	case []uint16:
+		if len(t) > 0 {
+			return uint64(t[0]), true
+		}
+	case []uint32:
+		if len(t) > 0 {
+			return uint64(t[0]), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,176 @@
+package exif
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func getRepairTestIndex(exifData []byte) IfdIndex {
+	eh, err := ParseExifHeader(exifData)
+	log.PanicIf(err)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	index, err := ie.Collect(eh.FirstIfdOffset)
+	log.PanicIf(err)
+
+	return index
+}
+
+func getRepairTestChange(rr *RepairResult, change RepairChangeType, tagId uint16) (rc RepairChange, found bool) {
+	for _, rc := range rr.Changes {
+		if rc.Change == change && rc.TagId == tagId {
+			return rc, true
+		}
+	}
+
+	return rc, false
+}
+
+func TestRepairExifData_Intact(t *testing.T) {
+	exifData := getTestExifData()
+
+	rr, err := RepairExifData(exifData)
+	log.PanicIf(err)
+
+	if len(rr.Changes) != 0 {
+		t.Fatalf("Intact data should not have changes: %v", rr.Changes)
+	} else if rr.ByteOrder != binary.LittleEndian {
+		t.Fatalf("Byte-order not correct: [%v]", rr.ByteOrder)
+	}
+
+	originalTags, err := GetFlatExifDataFromBytes(exifData)
+	log.PanicIf(err)
+
+	repairedTags, err := GetFlatExifDataFromBytes(rr.ExifData)
+	log.PanicIf(err)
+
+	if len(repairedTags) != len(originalTags) {
+		t.Fatalf("Tag count not correct: (%d) != (%d)", len(repairedTags), len(originalTags))
+	}
+
+	for i, original := range originalTags {
+		repaired := repairedTags[i]
+
+		if repaired.IfdPath != original.IfdPath || repaired.TagId != original.TagId {
+			t.Fatalf("Tag (%d) not correct: %s != %s", i, repaired, original)
+		} else if original.ChildIfdPath != "" || original.TagId == ThumbnailOffsetTagId {
+			continue
+		} else if reflect.DeepEqual(repaired.ValueBytes, original.ValueBytes) == false {
+			t.Fatalf("Value of tag (%d) not correct: %s", i, original)
+		}
+	}
+}
+
+func TestRepairExifData_Tags(t *testing.T) {
+	entries := []optionsTestEntry{
+		// Orientation is a SHORT.
+		{0x0112, exifcommon.TypeLong, 1, 6},
+
+		// Software is ASCII and a SHORT can't be converted to it.
+		{0x0131, exifcommon.TypeShort, 1, 5},
+
+		// The GPS IFD isn't there.
+		{0x8825, exifcommon.TypeLong, 1, 0x200000},
+
+		// PixelXDimension belongs in the EXIF IFD.
+		{0xa002, exifcommon.TypeLong, 1, 640},
+	}
+
+	exifData := getOptionsTestExifData(entries, nil)
+
+	rr, err := RepairExifData(exifData)
+	log.PanicIf(err)
+
+	if len(rr.Changes) != 4 {
+		t.Fatalf("Change count not correct: %v", rr.Changes)
+	}
+
+	if rc, found := getRepairTestChange(rr, RepairTypeChanged, 0x0112); found == false {
+		t.Fatalf("Type change not recorded: %v", rr.Changes)
+	} else if rc.String() != "type-changed [IFD] (0x0112) [Orientation]: converted from [LONG] to [SHORT]" {
+		t.Fatalf("Type change not correct: [%s]", rc)
+	}
+
+	if _, found := getRepairTestChange(rr, RepairTagDropped, 0x0131); found == false {
+		t.Fatalf("Drop not recorded: %v", rr.Changes)
+	} else if _, found := getRepairTestChange(rr, RepairChildIfdDropped, 0x8825); found == false {
+		t.Fatalf("Child-IFD drop not recorded: %v", rr.Changes)
+	} else if _, found := getRepairTestChange(rr, RepairTagMoved, 0xa002); found == false {
+		t.Fatalf("Move not recorded: %v", rr.Changes)
+	}
+
+	// The repaired data is clean.
+
+	repairedTags, med, err := GetFlatExifDataFromBytesWithOptions(rr.ExifData, ScanOptions{Strict: true})
+	log.PanicIf(err)
+
+	if len(med.Diagnostics()) != 0 {
+		t.Fatalf("Repaired data has diagnostics: %v", med.Diagnostics())
+	}
+
+	values := make(map[string]interface{})
+	for _, et := range repairedTags {
+		values[et.IfdPath+"/"+et.TagName] = et.Value
+	}
+
+	expected := map[string]interface{}{
+		"IFD/Orientation":          []uint16{6},
+		"IFD/ExifTag":              values["IFD/ExifTag"],
+		"IFD/Exif/PixelXDimension": []uint32{640},
+	}
+
+	if reflect.DeepEqual(values, expected) == false {
+		t.Fatalf("Repaired tags not correct: %v", values)
+	}
+}
+
+func TestRepairExifData_ThumbnailLength(t *testing.T) {
+	exifData := getTestExifData()
+
+	index := getRepairTestIndex(exifData)
+
+	ifd1 := index.Lookup[ThumbnailFqIfdPath]
+
+	originalThumbnail, err := ifd1.Thumbnail()
+	log.PanicIf(err)
+
+	results, err := ifd1.FindTagWithId(ThumbnailSizeTagId)
+	log.PanicIf(err)
+
+	corrupted := make([]byte, len(exifData))
+	copy(corrupted, exifData)
+
+	// Overwrite the length with one that runs past the end of the data.
+	binary.LittleEndian.PutUint32(corrupted[results[0].entryOffset+8:], 0x00ffffff)
+
+	rr, err := RepairExifData(corrupted)
+	log.PanicIf(err)
+
+	if _, found := getRepairTestChange(rr, RepairThumbnailResized, ThumbnailOffsetTagId); found == false {
+		t.Fatalf("Resize not recorded: %v", rr.Changes)
+	}
+
+	repairedIndex := getRepairTestIndex(rr.ExifData)
+
+	repairedThumbnail, err := repairedIndex.Lookup[ThumbnailFqIfdPath].Thumbnail()
+	log.PanicIf(err)
+
+	if bytes.Equal(repairedThumbnail, originalThumbnail) == false {
+		t.Fatalf("Thumbnail not correct: (%d) bytes != (%d) bytes", len(repairedThumbnail), len(originalThumbnail))
+	}
+}
+
+func TestRepairExifData_Nothing(t *testing.T) {
+	_, err := RepairExifData(make([]byte, 100))
+	if err != ErrNothingSalvaged {
+		t.Fatalf("Expected ErrNothingSalvaged: %v", err)
+	}
+}
@@ -0,0 +1,599 @@
+package exif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+	exifundefined "github.com/imclaren/go-exif/undefined"
+)
+
+var (
+	// ErrNothingSalvaged means that no IFD could be found in the data.
+	ErrNothingSalvaged = errors.New("no IFDs could be salvaged")
+)
+
+const (
+	// salvageMaxPlausibleEntries is the largest tag-count that we will accept
+	// when guessing where an IFD is.
+	salvageMaxPlausibleEntries = 512
+
+	// salvageMinResyncEntries is the smallest tag-count that we will accept
+	// when searching for an IFD. A single entry is too easy to find by chance.
+	salvageMinResyncEntries = 2
+)
+
+// SalvagedTag is a tag recovered by `SalvageFlatExifData`.
+type SalvagedTag struct {
+	ExifTag
+
+	// Partial is true if the value runs past the end of the data. `Value` and
+	// `ValueBytes` have just the units that are completely present.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// SalvageResult is what `SalvageFlatExifData` was able to recover.
+type SalvageResult struct {
+	// ByteOrder is the byte-order that the data was read with. This is a guess
+	// if the header was corrupt.
+	ByteOrder binary.ByteOrder
+
+	// FirstIfdOffset is the offset that the first IFD was read from.
+	FirstIfdOffset uint32
+
+	// Tags are the tags whose entries (and at least some of whose values)
+	// were intact, in the order that they were found.
+	Tags []SalvagedTag
+
+	// Damage is the damage report: everything that had to be guessed, cut
+	// short, or skipped, along with the usual parse diagnostics.
+	Damage []Diagnostic
+}
+
+// IsDamaged returns true if any problems were found.
+func (sr *SalvageResult) IsDamaged() bool {
+	return len(sr.Damage) > 0
+}
+
+// salvageRange is a region of the data that is accounted for.
+type salvageRange struct {
+	start, end uint64
+}
+
+// salvager holds the state of one salvage.
+type salvager struct {
+	data      []byte
+	byteOrder binary.ByteOrder
+
+	ie     *IfdEnumerate
+	med    *MiscellaneousExifData
+	budget *parseBudget
+
+	// claimed are the IFD tables and values that we have read. We will not
+	// look for IFDs inside of them.
+	claimed []salvageRange
+
+	tags []SalvagedTag
+}
+
+// SalvageFlatExifData recovers as much as possible from an EXIF blob that is
+// truncated or corrupted (`exifData` should start at the TIFF header, as for
+// `GetFlatExifDataFromBytes`). Every tag whose entry is intact is returned.
+// Values that run past the end of the data are cut back to the units that are
+// present and marked as partial. If the header is corrupt, both byte-orders
+// are tried. If a next-IFD pointer is garbage, the data after the IFD is
+// searched for a plausible IFD table. An error is returned only if no IFD at
+// all can be found.
+func SalvageFlatExifData(exifData []byte) (sr *SalvageResult, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	sv := &salvager{
+		data: exifData,
+		med: &MiscellaneousExifData{
+			unknownTags: make(map[exifcommon.BasicTag]exifcommon.BasicTag),
+			diagnostics: make([]Diagnostic, 0),
+		},
+		budget: newParseBudget(DefaultParseLimits),
+		tags:   make([]SalvagedTag, 0),
+	}
+
+	byteOrder, firstIfdOffset, found := sv.findHeader()
+	if found == false {
+		return nil, ErrNothingSalvaged
+	}
+
+	sv.byteOrder = byteOrder
+
+	sv.ie = NewIfdEnumerate(nil, NewIfdMappingWithStandard(), StandardTagIndex(), byteOrder)
+	sv.ie.exifData = exifData
+
+	sv.claim(0, ExifSignatureLength)
+	sv.salvageChain(exifcommon.IfdStandardIfdIdentity, firstIfdOffset, true)
+
+	sr = &SalvageResult{
+		ByteOrder:      byteOrder,
+		FirstIfdOffset: firstIfdOffset,
+		Tags:           sv.tags,
+		Damage:         sv.med.Diagnostics(),
+	}
+
+	return sr, nil
+}
+
+// findHeader returns the byte-order and first-IFD offset. If the header is
+// not valid or does not point at a plausible IFD, we guess.
+func (sv *salvager) findHeader() (byteOrder binary.ByteOrder, firstIfdOffset uint32, found bool) {
+	eh, err := ParseExifHeader(sv.data)
+	if err == nil && sv.plausibleEntryCount(eh.ByteOrder, eh.FirstIfdOffset, false) > 0 {
+		return eh.ByteOrder, eh.FirstIfdOffset, true
+	}
+
+	// Prefer the byte-order that the header claims, if it is intact.
+	byteOrders := []binary.ByteOrder{binary.BigEndian, binary.LittleEndian}
+	if len(sv.data) >= 2 && bytes.Equal(sv.data[:2], ExifLittleEndianSignature[:2]) == true {
+		byteOrders = []binary.ByteOrder{binary.LittleEndian, binary.BigEndian}
+	}
+
+	bestCount := 0
+	for _, thisByteOrder := range byteOrders {
+		candidates := []uint32{ExifSignatureLength}
+		if len(sv.data) >= ExifSignatureLength {
+			candidates = append([]uint32{thisByteOrder.Uint32(sv.data[4:8])}, candidates...)
+		}
+
+		for _, candidate := range candidates {
+			count := sv.plausibleEntryCount(thisByteOrder, candidate, true)
+			if count > bestCount {
+				byteOrder = thisByteOrder
+				firstIfdOffset = candidate
+				bestCount = count
+			}
+		}
+	}
+
+	// The pointer is garbage and the IFD doesn't directly follow the header.
+	// Search.
+	if bestCount == 0 {
+		for _, thisByteOrder := range byteOrders {
+			offset, found := sv.findIfd(thisByteOrder, ExifSignatureLength)
+			if found == true {
+				byteOrder = thisByteOrder
+				firstIfdOffset = offset
+				bestCount = 1
+
+				break
+			}
+		}
+	}
+
+	if bestCount == 0 {
+		if err == nil {
+			// The header is intact. Let the IFD parse report the problem.
+			return eh.ByteOrder, eh.FirstIfdOffset, true
+		}
+
+		return nil, 0, false
+	}
+
+	d := Diagnostic{
+		Severity: DiagnosticWarning,
+		Code:     DiagnosticHeaderCorrupt,
+		Offset:   0,
+		Message:  fmt.Sprintf("header is corrupt; guessed byte-order [%v] and first IFD at offset (0x%08x)", byteOrder, firstIfdOffset),
+	}
+
+	sv.med.addDiagnostic(d)
+
+	return byteOrder, firstIfdOffset, true
+}
+
+// plausibleEntryCount returns the number of entries of the IFD at the given
+// offset that are present in the data, or zero if it does not look like an
+// IFD. The entries must have valid types and, if `requireOrder` is true (we
+// are guessing rather than following a pointer), ascending tag-IDs.
+func (sv *salvager) plausibleEntryCount(byteOrder binary.ByteOrder, offset uint32, requireOrder bool) int {
+	dataLength := uint64(len(sv.data))
+
+	if offset < ExifSignatureLength || uint64(offset)+2 > dataLength {
+		return 0
+	}
+
+	tagCount := int(byteOrder.Uint16(sv.data[offset:]))
+	if tagCount == 0 || tagCount > salvageMaxPlausibleEntries {
+		return 0
+	}
+
+	presentCount := int((dataLength - uint64(offset) - 2) / 12)
+	if presentCount > tagCount {
+		presentCount = tagCount
+	}
+
+	previousTagId := -1
+	for i := 0; i < presentCount; i++ {
+		entryOffset := int(offset) + 2 + i*12
+
+		tagId := int(byteOrder.Uint16(sv.data[entryOffset:]))
+		tagType := exifcommon.TagTypePrimitive(byteOrder.Uint16(sv.data[entryOffset+2:]))
+
+		if tagType.IsValid() == false || tagType == exifcommon.TypeAsciiNoNul {
+			return 0
+		} else if requireOrder == true && tagId <= previousTagId {
+			return 0
+		}
+
+		previousTagId = tagId
+	}
+
+	return presentCount
+}
+
+// findIfd searches for a plausible, complete IFD table, starting at the given
+// offset, that is not in a region that we have already read.
+func (sv *salvager) findIfd(byteOrder binary.ByteOrder, startAt uint32) (offset uint32, found bool) {
+	for candidate := uint64(startAt); candidate+2+12 <= uint64(len(sv.data)); candidate += 2 {
+		if sv.isClaimed(candidate) == true {
+			continue
+		}
+
+		tagCount := int(byteOrder.Uint16(sv.data[candidate:]))
+		if tagCount < salvageMinResyncEntries {
+			continue
+		}
+
+		if sv.plausibleEntryCount(byteOrder, uint32(candidate), true) == tagCount {
+			return uint32(candidate), true
+		}
+	}
+
+	return 0, false
+}
+
+// claim marks a region as accounted for.
+func (sv *salvager) claim(start, length uint64) {
+	sv.claimed = append(sv.claimed, salvageRange{start: start, end: start + length})
+}
+
+// isClaimed returns true if the offset is in a region that we have read.
+func (sv *salvager) isClaimed(offset uint64) bool {
+	for _, r := range sv.claimed {
+		if offset >= r.start && offset < r.end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// salvageChain salvages an IFD and its siblings. If `resync` is true, a
+// garbage next-IFD pointer is recovered from by searching for the next IFD.
+func (sv *salvager) salvageChain(iiGeneral *exifcommon.IfdIdentity, ifdOffset uint32, resync bool) {
+	for ifdIndex := 0; ; ifdIndex++ {
+		iiSibling := iiGeneral.NewSibling(ifdIndex)
+
+		nextIfdOffset, ifdEnd, ok := sv.salvageIfd(iiSibling, ifdOffset)
+		if ok == false || nextIfdOffset == 0 {
+			break
+		}
+
+		if sv.isPlausibleNextIfd(nextIfdOffset) == true {
+			ifdOffset = nextIfdOffset
+			continue
+		}
+
+		iiNext := iiGeneral.NewSibling(ifdIndex + 1)
+
+		if resync == true {
+			if offset, found := sv.findIfd(sv.byteOrder, ifdEnd); found == true {
+				d := Diagnostic{
+					Severity:  DiagnosticWarning,
+					Code:      DiagnosticIfdResynced,
+					FqIfdPath: iiNext.String(),
+					Offset:    offset,
+					Message:   fmt.Sprintf("next-IFD pointer (0x%08x) is not valid; found a plausible IFD at offset (0x%08x) instead", nextIfdOffset, offset),
+				}
+
+				sv.med.addDiagnostic(d)
+
+				ifdOffset = offset
+				continue
+			}
+		}
+
+		d := Diagnostic{
+			Severity:  DiagnosticError,
+			Code:      DiagnosticIfdUnreachable,
+			FqIfdPath: iiNext.String(),
+			Offset:    nextIfdOffset,
+			Message:   "next-IFD pointer is not valid and no IFD could be found in its place",
+		}
+
+		sv.med.addDiagnostic(d)
+
+		break
+	}
+}
+
+// isPlausibleNextIfd returns true if a next-IFD pointer can be followed.
+func (sv *salvager) isPlausibleNextIfd(offset uint32) bool {
+	if sv.isClaimed(uint64(offset)) == true {
+		return false
+	}
+
+	return sv.plausibleEntryCount(sv.byteOrder, offset, false) > 0
+}
+
+// salvageIfd salvages the entries of one IFD and then its child IFDs. It
+// returns the next-IFD offset (zero if there isn't one or it is cut off), and
+// where the IFD table ends.
+func (sv *salvager) salvageIfd(ii *exifcommon.IfdIdentity, ifdOffset uint32) (nextIfdOffset uint32, ifdEnd uint32, ok bool) {
+	dataLength := uint64(len(sv.data))
+
+	if uint64(ifdOffset)+2 > dataLength {
+		d := Diagnostic{
+			Severity:  DiagnosticError,
+			Code:      DiagnosticIfdUnreachable,
+			FqIfdPath: ii.String(),
+			Offset:    ifdOffset,
+			Message:   "IFD offset is outside of the data",
+		}
+
+		sv.med.addDiagnostic(d)
+
+		return 0, 0, false
+	}
+
+	err := sv.budget.enterIfd(ii, ifdOffset)
+	if err != nil {
+		d := Diagnostic{
+			Severity:  DiagnosticError,
+			Code:      DiagnosticIfdSkipped,
+			FqIfdPath: ii.String(),
+			Offset:    ifdOffset,
+			Message:   err.Error(),
+		}
+
+		sv.med.addDiagnostic(d)
+
+		return 0, 0, false
+	}
+
+	tagCount := uint64(sv.byteOrder.Uint16(sv.data[ifdOffset:]))
+	presentCount := (dataLength - uint64(ifdOffset) - 2) / 12
+
+	isTruncated := false
+	if tagCount > presentCount {
+		d := Diagnostic{
+			Severity:  DiagnosticError,
+			Code:      DiagnosticIfdTruncated,
+			FqIfdPath: ii.String(),
+			Offset:    ifdOffset,
+			Message:   fmt.Sprintf("IFD has (%d) entries but only (%d) are present", tagCount, presentCount),
+		}
+
+		sv.med.addDiagnostic(d)
+
+		tagCount = presentCount
+		isTruncated = true
+	}
+
+	tableEnd := uint64(ifdOffset) + 2 + tagCount*12
+	sv.claim(uint64(ifdOffset), tableEnd+4-uint64(ifdOffset))
+
+	children := make([]*IfdTagEntry, 0)
+
+	for i := 0; i < int(tagCount); i++ {
+		entryOffset := uint32(uint64(ifdOffset) + 2 + uint64(i)*12)
+
+		ite, ok := sv.salvageEntry(ii, i, entryOffset)
+		if ok == false {
+			continue
+		}
+
+		if ite.ChildIfdPath() != "" {
+			children = append(children, ite)
+		}
+	}
+
+	if isTruncated == false {
+		if tableEnd+4 <= dataLength {
+			nextIfdOffset = sv.byteOrder.Uint32(sv.data[tableEnd:])
+		} else {
+			d := Diagnostic{
+				Severity:  DiagnosticWarning,
+				Code:      DiagnosticIfdTruncated,
+				FqIfdPath: ii.String(),
+				Offset:    ifdOffset,
+				Message:   "next-IFD pointer is cut off",
+			}
+
+			sv.med.addDiagnostic(d)
+		}
+	}
+
+	for _, ite := range children {
+		currentIfdTag := ii.IfdTag()
+
+		childIfdTag :=
+			exifcommon.NewIfdTag(
+				&currentIfdTag,
+				ite.TagId(),
+				ite.ChildIfdName())
+
+		iiChild := ii.NewChild(childIfdTag, 0)
+
+		childOffset := ite.getValueOffset()
+
+		if sv.isPlausibleNextIfd(childOffset) == false {
+			d := Diagnostic{
+				Severity:  DiagnosticError,
+				Code:      DiagnosticIfdUnreachable,
+				FqIfdPath: iiChild.String(),
+				TagId:     ite.TagId(),
+				Offset:    childOffset,
+				Message:   "child-IFD pointer does not point to an IFD",
+			}
+
+			sv.med.addDiagnostic(d)
+
+			continue
+		}
+
+		sv.salvageChain(iiChild, childOffset, false)
+	}
+
+	return nextIfdOffset, uint32(tableEnd + 4), true
+}
+
+// salvageEntry parses one IFD entry and recovers its value.
+func (sv *salvager) salvageEntry(ii *exifcommon.IfdIdentity, tagPosition int, entryOffset uint32) (ite *IfdTagEntry, ok bool) {
+	bp, err := newByteParser(sv.data, sv.byteOrder, entryOffset)
+	log.PanicIf(err)
+
+	ite, err = sv.ie.parseTag(ii, tagPosition, bp)
+	if err != nil {
+		if errors.Is(err, ErrTagTypeNotValid) == true {
+			sv.med.addTagDiagnostic(
+				DiagnosticError, DiagnosticTagTypeNotValid, ite,
+				"tag at position (%d) has invalid type (%d) and was skipped",
+				tagPosition, ite.TagType())
+
+			return nil, false
+		}
+
+		log.Panic(err)
+	}
+
+	// This records any diagnostics. The tag is kept, regardless.
+	err = sv.ie.postparseTag(ite, sv.med)
+	if err != nil && err != ErrTagNotFound && err != ErrTagNotValidForIfd && err != ErrTagTypeMismatch {
+		log.Panic(err)
+	}
+
+	st := SalvagedTag{
+		ExifTag: ExifTag{
+			IfdPath:      ii.String(),
+			TagId:        ite.TagId(),
+			TagName:      ite.TagName(),
+			UnitCount:    ite.UnitCount(),
+			TagTypeId:    ite.TagType(),
+			TagTypeName:  ite.TagType().String(),
+			ChildIfdPath: ite.ChildIfdPath(),
+		},
+	}
+
+	tagType := ite.TagType()
+
+	unitSize := uint64(1)
+	if tagType != exifcommon.TypeUndefined {
+		unitSize = uint64(tagType.Size())
+	}
+
+	byteCount := unitSize * uint64(ite.UnitCount())
+
+	if byteCount <= 4 {
+		st.ValueBytes = ite.rawValueOffset[:byteCount]
+	} else {
+		valueOffset := uint64(ite.getValueOffset())
+		dataLength := uint64(len(sv.data))
+
+		availableCount := uint64(0)
+		if valueOffset < dataLength {
+			availableCount = (dataLength - valueOffset) / unitSize
+		}
+
+		if availableCount == 0 {
+			sv.med.addTagDiagnostic(
+				DiagnosticError, DiagnosticValueUnreachable, ite,
+				"value at offset (0x%08x) is outside of the data; the tag was skipped",
+				valueOffset)
+
+			return ite, true
+		}
+
+		if availableCount < uint64(ite.UnitCount()) {
+			sv.med.addTagDiagnostic(
+				DiagnosticWarning, DiagnosticValueTruncated, ite,
+				"value at offset (0x%08x) is cut off; only (%d) of (%d) units are present",
+				valueOffset, availableCount, ite.UnitCount())
+
+			st.Partial = true
+			byteCount = availableCount * unitSize
+		}
+
+		st.ValueBytes = sv.data[valueOffset : valueOffset+byteCount]
+		sv.claim(valueOffset, byteCount)
+	}
+
+	if st.Partial == true {
+		sv.salvagePartialValue(ite, &st)
+	} else {
+		sv.salvageValue(ite, &st)
+	}
+
+	sv.tags = append(sv.tags, st)
+
+	return ite, true
+}
+
+// salvageValue decodes a complete value.
+func (sv *salvager) salvageValue(ite *IfdTagEntry, st *SalvagedTag) {
+	value, err := ite.Value()
+	if err != nil {
+		if err == exifcommon.ErrUnhandledUndefinedTypedTag {
+			st.Value = exifundefined.UnparseableUnknownTagValuePlaceholder
+		} else {
+			sv.med.addTagDiagnostic(
+				DiagnosticWarning, DiagnosticValueUnparseable, ite,
+				"value could not be decoded: %s", err.Error())
+
+			st.Value = st.ValueBytes
+		}
+	} else {
+		st.Value = value
+	}
+
+	phrase, err := exifcommon.FormatFromType(st.Value, false)
+	if err == nil {
+		st.Formatted = phrase
+	}
+
+	phrase, err = exifcommon.FormatFromType(st.Value, true)
+	if err == nil {
+		st.FormattedFirst = phrase
+	}
+}
+
+// salvagePartialValue decodes the units of a value that are present.
+func (sv *salvager) salvagePartialValue(ite *IfdTagEntry, st *SalvagedTag) {
+	tagType := ite.TagType()
+
+	if tagType == exifcommon.TypeUndefined {
+		st.Value = st.ValueBytes
+	} else {
+		value, err := decodeExifTagValueBytes(ite.IfdIdentity().UnindexedString(), ite.TagId(), tagType, st.ValueBytes, sv.byteOrder)
+		if err != nil {
+			st.Value = st.ValueBytes
+		} else {
+			st.Value = value
+		}
+	}
+
+	phrase, err := exifcommon.FormatFromType(st.Value, false)
+	if err == nil {
+		st.Formatted = phrase
+	}
+
+	phrase, err = exifcommon.FormatFromType(st.Value, true)
+	if err == nil {
+		st.FormattedFirst = phrase
+	}
+}
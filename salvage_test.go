@@ -0,0 +1,228 @@
+package exif
+
+import (
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func getSalvageTestDiagnostic(sr *SalvageResult, code DiagnosticCode) (d Diagnostic, found bool) {
+	for _, d := range sr.Damage {
+		if d.Code == code {
+			return d, true
+		}
+	}
+
+	return d, false
+}
+
+func getSalvageTestTags(sr *SalvageResult) map[string]SalvagedTag {
+	tags := make(map[string]SalvagedTag)
+	for _, st := range sr.Tags {
+		tags[st.IfdPath+"/"+st.TagName] = st
+	}
+
+	return tags
+}
+
+func TestSalvageFlatExifData_Intact(t *testing.T) {
+	exifData := getTestExifData()
+
+	exifTags, err := GetFlatExifDataFromBytes(exifData)
+	log.PanicIf(err)
+
+	sr, err := SalvageFlatExifData(exifData)
+	log.PanicIf(err)
+
+	if sr.IsDamaged() == true {
+		t.Fatalf("Intact data should not be damaged: %v", sr.Damage)
+	} else if sr.ByteOrder != binary.LittleEndian || sr.FirstIfdOffset != 8 {
+		t.Fatalf("Header not correct: [%v] (%d)", sr.ByteOrder, sr.FirstIfdOffset)
+	} else if len(sr.Tags) != len(exifTags) {
+		t.Fatalf("Tag count not correct: (%d) != (%d)", len(sr.Tags), len(exifTags))
+	}
+
+	tags := getSalvageTestTags(sr)
+
+	for _, et := range exifTags {
+		st, found := tags[et.IfdPath+"/"+et.TagName]
+		if found == false {
+			t.Fatalf("Tag not salvaged: %s", et)
+		} else if st.Partial == true {
+			t.Fatalf("Tag should not be partial: %s", et)
+		} else if et.TagTypeId != exifcommon.TypeUndefined && reflect.DeepEqual(st.Value, et.Value) == false {
+			t.Fatalf("Value not correct for [%s]: %v != %v", et.TagName, st.Value, et.Value)
+		}
+	}
+}
+
+func TestSalvageFlatExifData_Truncated(t *testing.T) {
+	exifData := getTestExifData()
+
+	tags := getSalvageTestTags(func() *SalvageResult {
+		sr, err := SalvageFlatExifData(exifData)
+		log.PanicIf(err)
+
+		return sr
+	}())
+
+	// Cut it off in the middle of the value of IFD0's Make.
+
+	make_ := tags["IFD/Make"]
+
+	eh, err := ParseExifHeader(exifData)
+	log.PanicIf(err)
+
+	ie := getLimitsTestEnumerate(exifData)
+
+	index, err := ie.Collect(eh.FirstIfdOffset)
+	log.PanicIf(err)
+
+	results, err := index.RootIfd.FindTagWithName("Make")
+	log.PanicIf(err)
+
+	makeOffset := results[0].getValueOffset()
+
+	truncated := exifData[:makeOffset+3]
+
+	sr, err := SalvageFlatExifData(truncated)
+	log.PanicIf(err)
+
+	if sr.IsDamaged() == false {
+		t.Fatalf("Expected damage.")
+	}
+
+	truncatedTags := getSalvageTestTags(sr)
+
+	st, found := truncatedTags["IFD/Make"]
+	if found == false {
+		t.Fatalf("Make not salvaged.")
+	} else if st.Partial != true {
+		t.Fatalf("Make should be partial.")
+	} else if st.Value != make_.Value.(string)[:3] {
+		t.Fatalf("Partial value not correct: [%v]", st.Value)
+	}
+
+	if d, found := getSalvageTestDiagnostic(sr, DiagnosticValueTruncated); found == false {
+		t.Fatalf("Truncated value not reported: %v", sr.Damage)
+	} else if d.TagId != 0x010f || d.FqIfdPath != "IFD" {
+		t.Fatalf("Truncated-value diagnostic not correct: %s", d)
+	}
+
+	// The embedded values are all intact.
+
+	for name, original := range tags {
+		if original.IfdPath != "IFD" || len(original.ValueBytes) > 4 {
+			continue
+		}
+
+		st, found := truncatedTags[name]
+		if found == false {
+			t.Fatalf("Embedded tag not salvaged: [%s]", name)
+		} else if reflect.DeepEqual(st.Value, original.Value) == false {
+			t.Fatalf("Embedded tag not correct: [%s]", name)
+		}
+	}
+
+	// The value of the EXIF IFD pointer survives, but the IFD doesn't.
+
+	if _, found := getSalvageTestDiagnostic(sr, DiagnosticIfdUnreachable); found == false {
+		t.Fatalf("Unreachable IFD not reported: %v", sr.Damage)
+	}
+}
+
+func TestSalvageFlatExifData_CorruptHeader(t *testing.T) {
+	exifData := getTestExifData()
+
+	corrupted := make([]byte, len(exifData))
+	copy(corrupted, exifData)
+
+	for i := 0; i < 8; i++ {
+		corrupted[i] = 0xff
+	}
+
+	sr, err := SalvageFlatExifData(corrupted)
+	log.PanicIf(err)
+
+	if sr.ByteOrder != binary.LittleEndian || sr.FirstIfdOffset != 8 {
+		t.Fatalf("Header not guessed correctly: [%v] (%d)", sr.ByteOrder, sr.FirstIfdOffset)
+	} else if len(sr.Damage) != 1 || sr.Damage[0].Code != DiagnosticHeaderCorrupt {
+		t.Fatalf("Damage not correct: %v", sr.Damage)
+	}
+
+	intact, err := SalvageFlatExifData(exifData)
+	log.PanicIf(err)
+
+	if len(sr.Tags) != len(intact.Tags) {
+		t.Fatalf("Tag count not correct: (%d) != (%d)", len(sr.Tags), len(intact.Tags))
+	}
+}
+
+func TestSalvageFlatExifData_Resync(t *testing.T) {
+	exifData := getTestExifData()
+
+	corrupted := make([]byte, len(exifData))
+	copy(corrupted, exifData)
+
+	// Overwrite IFD0's next-IFD pointer.
+
+	tagCount := binary.LittleEndian.Uint16(corrupted[8:])
+	nextIfdPointerOffset := 8 + 2 + int(tagCount)*12
+
+	ifd1Offset := binary.LittleEndian.Uint32(corrupted[nextIfdPointerOffset:])
+	binary.LittleEndian.PutUint32(corrupted[nextIfdPointerOffset:], 0xdeadbeef)
+
+	sr, err := SalvageFlatExifData(corrupted)
+	log.PanicIf(err)
+
+	d, found := getSalvageTestDiagnostic(sr, DiagnosticIfdResynced)
+	if found == false {
+		t.Fatalf("Resync not reported: %v", sr.Damage)
+	} else if d.FqIfdPath != "IFD1" || d.Offset != ifd1Offset {
+		t.Fatalf("Resync not correct (expected 0x%08x): %s", ifd1Offset, d)
+	}
+
+	intact, err := SalvageFlatExifData(exifData)
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(getSalvageTestTags(sr), getSalvageTestTags(intact)) == false {
+		t.Fatalf("Resynced tags not correct.")
+	}
+}
+
+func TestSalvageFlatExifData_TruncatedIfdTable(t *testing.T) {
+	entries := []optionsTestEntry{
+		{0x0100, exifcommon.TypeLong, 1, 100},
+		{0x0101, exifcommon.TypeLong, 1, 200},
+	}
+
+	exifData := getOptionsTestExifData(entries, nil)
+
+	// Cut off the middle of the second entry.
+	truncated := exifData[:8+2+12+6]
+
+	sr, err := SalvageFlatExifData(truncated)
+	log.PanicIf(err)
+
+	if len(sr.Tags) != 1 || sr.Tags[0].TagId != 0x0100 || reflect.DeepEqual(sr.Tags[0].Value, []uint32{100}) == false {
+		t.Fatalf("Tags not correct: %v", sr.Tags)
+	}
+
+	if d, found := getSalvageTestDiagnostic(sr, DiagnosticIfdTruncated); found == false {
+		t.Fatalf("Truncated IFD not reported: %v", sr.Damage)
+	} else if d.Severity != DiagnosticError || d.Offset != 8 {
+		t.Fatalf("Truncated-IFD diagnostic not correct: %s", d)
+	}
+}
+
+func TestSalvageFlatExifData_Nothing(t *testing.T) {
+	_, err := SalvageFlatExifData(make([]byte, 100))
+	if err != ErrNothingSalvaged {
+		t.Fatalf("Expected ErrNothingSalvaged: %v", err)
+	}
+}
@@ -1,10 +1,16 @@
 package exif
 
+//go:generate go run ./internal/cmd/generatetags -input assets/tags.yaml -output tags_standard.go
+
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+
+	"encoding/json"
 
 	"github.com/dsoprea/go-logging"
-	"gopkg.in/yaml.v2"
 
 	"github.com/imclaren/go-exif/common"
 )
@@ -68,15 +74,92 @@ var (
 	tagsLogger = log.NewLogger("exif.tags")
 )
 
+var (
+	// ErrTagAlreadyExists means that a tag with the same ID or name is already
+	// registered for the IFD.
+	ErrTagAlreadyExists = errors.New("tag already exists")
+
+	// ErrTagIndexReadOnly means that a tag was added to an index that can not
+	// be changed (see `StandardTagIndex`).
+	ErrTagIndexReadOnly = errors.New("tag index is read-only")
+)
+
+// TagMergeMode determines what happens when a tag being merged into a
+// `TagIndex` collides with one that is already there.
+type TagMergeMode int
+
+const (
+	// TagMergeFail fails with `ErrTagAlreadyExists` if the ID or the name is
+	// already registered for the IFD.
+	TagMergeFail TagMergeMode = iota
+
+	// TagMergeKeep keeps the existing tag and skips the new one.
+	TagMergeKeep
+
+	// TagMergeReplace replaces the existing tag with the same ID. The name of
+	// the new tag must not belong to a different tag.
+	TagMergeReplace
+)
+
+// String returns a descriptive string.
+func (tmm TagMergeMode) String() string {
+	switch tmm {
+	case TagMergeFail:
+		return "fail"
+	case TagMergeKeep:
+		return "keep"
+	case TagMergeReplace:
+		return "replace"
+	}
+
+	return fmt.Sprintf("TagMergeMode(%d)", int(tmm))
+}
+
 // File structures.
 
 type encodedTag struct {
 	// id is signed, here, because YAML doesn't have enough information to
 	// support unsigned.
-	Id        int      `yaml:"id"`
-	Name      string   `yaml:"name"`
-	TypeName  string   `yaml:"type_name"`
-	TypeNames []string `yaml:"type_names"`
+	Id        encodedTagId `yaml:"id" json:"id"`
+	Name      string       `yaml:"name" json:"name"`
+	TypeName  string       `yaml:"type_name" json:"type_name"`
+	TypeNames []string     `yaml:"type_names" json:"type_names"`
+}
+
+// encodedTagId is a tag-ID. JSON has no hex literals, so it may also be given
+// as a "0x"-prefixed string there.
+type encodedTagId int
+
+// UnmarshalJSON accepts a number or a string.
+func (eti *encodedTagId) UnmarshalJSON(data []byte) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	var raw interface{}
+
+	err = json.Unmarshal(data, &raw)
+	log.PanicIf(err)
+
+	switch t := raw.(type) {
+	case float64:
+		if t < 0 || t != float64(uint16(t)) {
+			log.Panicf("tag-ID not valid: %s", string(data))
+		}
+
+		*eti = encodedTagId(t)
+	case string:
+		id, err := strconv.ParseUint(t, 0, 16)
+		log.PanicIf(err)
+
+		*eti = encodedTagId(id)
+	default:
+		log.Panicf("tag-ID not valid: %s", string(data))
+	}
+
+	return nil
 }
 
 // Indexing structures.
@@ -129,8 +212,14 @@ func (it *IndexedTag) GetEncodingType(value interface{}) exifcommon.TagTypePrimi
 	supportsShort := false
 	supportsRational := false
 	supportsSignedRational := false
+	supportsAscii := false
+	supportsUtf8 := false
 	for _, supportedType := range it.SupportedTypes {
-		if supportedType == exifcommon.TypeLong {
+		if supportedType == exifcommon.TypeAscii {
+			supportsAscii = true
+		} else if supportedType == exifcommon.TypeUtf8 {
+			supportsUtf8 = true
+		} else if supportedType == exifcommon.TypeLong {
 			supportsLong = true
 		} else if supportedType == exifcommon.TypeShort {
 			supportsShort = true
@@ -143,8 +232,24 @@ func (it *IndexedTag) GetEncodingType(value interface{}) exifcommon.TagTypePrimi
 
 	// We specifically check for the cases that we know to expect.
 
+	// Some DNG tags may be stored as integers or as rationals. We use the
+	// type of the value.
+	if supportsRational == true && supportsSignedRational == false {
+		if _, ok := value.([]exifcommon.Rational); ok == true {
+			return exifcommon.TypeRational
+		}
+	}
+
 	if supportsLong == true && supportsShort == true {
 		return exifcommon.TypeLong
+	} else if supportsAscii == true && supportsUtf8 == true {
+		// Only use UTF-8 where it's needed so that readers that predate EXIF
+		// 3.0 can still read the value.
+		if s, ok := value.(string); ok == true && isAsciiString(s) == false {
+			return exifcommon.TypeUtf8
+		}
+
+		return exifcommon.TypeAscii
 	} else if supportsRational == true && supportsSignedRational == true {
 		if value == nil {
 			log.Panicf("GetEncodingType: require value to be given")
@@ -155,12 +260,25 @@ func (it *IndexedTag) GetEncodingType(value interface{}) exifcommon.TagTypePrimi
 		}
 
 		return exifcommon.TypeRational
+	} else if supportsShort == true && supportsRational == true {
+		return exifcommon.TypeShort
 	}
 
 	log.Panicf("WidestSupportedType() case is not handled for tag [%s] (0x%04x): %v", it.IfdPath, it.Id, it.SupportedTypes)
 	return 0
 }
 
+// isAsciiString returns true if the string only has 7-bit characters.
+func isAsciiString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
+
 // DoesSupportType returns true if this tag can be found/decoded with this type.
 func (it *IndexedTag) DoesSupportType(tagType exifcommon.TagTypePrimitive) bool {
 	// This is always a very small collection. So, we keep it unsorted.
@@ -177,6 +295,8 @@ func (it *IndexedTag) DoesSupportType(tagType exifcommon.TagTypePrimitive) bool
 type TagIndex struct {
 	tagsByIfd  map[string]map[uint16]*IndexedTag
 	tagsByIfdR map[string]map[string]*IndexedTag
+
+	readOnly bool
 }
 
 // NewTagIndex returns a new TagIndex struct.
@@ -193,10 +313,14 @@ func NewTagIndex() *TagIndex {
 func (ti *TagIndex) Add(it *IndexedTag) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
+	if ti.readOnly == true {
+		log.Panic(ErrTagIndexReadOnly)
+	}
+
 	// Store by ID.
 
 	family, found := ti.tagsByIfd[it.IfdPath]
@@ -228,12 +352,56 @@ func (ti *TagIndex) Add(it *IndexedTag) (err error) {
 	return nil
 }
 
+// Merge registers a tag, resolving a collision with an existing tag according
+// to the mode. `added` is false if the tag was skipped.
+func (ti *TagIndex) Merge(it *IndexedTag, mode TagMergeMode) (added bool, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if ti.readOnly == true {
+		return false, ErrTagIndexReadOnly
+	}
+
+	existing, idFound := ti.tagsByIfd[it.IfdPath][it.Id]
+	named, nameFound := ti.tagsByIfdR[it.IfdPath][it.Name]
+
+	if idFound == true || nameFound == true {
+		switch mode {
+		case TagMergeFail:
+			return false, ErrTagAlreadyExists
+		case TagMergeKeep:
+			return false, nil
+		case TagMergeReplace:
+			// We only replace by ID. Taking the name of another tag would
+			// silently drop that tag.
+			if nameFound == true && named.Id != it.Id {
+				return false, ErrTagAlreadyExists
+			}
+
+			if idFound == true {
+				delete(ti.tagsByIfd[it.IfdPath], existing.Id)
+				delete(ti.tagsByIfdR[it.IfdPath], existing.Name)
+			}
+		default:
+			log.Panicf("tag merge-mode not valid: (%d)", mode)
+		}
+	}
+
+	err = ti.Add(it)
+	log.PanicIf(err)
+
+	return true, nil
+}
+
 // Get returns information about the non-IFD tag given a tag ID. `ifdPath` must
 // not be fully-qualified.
 func (ti *TagIndex) Get(ii *exifcommon.IfdIdentity, id uint16) (it *IndexedTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -280,7 +448,7 @@ var (
 func (ti *TagIndex) FindFirst(id uint16, typeId exifcommon.TagTypePrimitive, ifdIdentities []*exifcommon.IfdIdentity) (it *IndexedTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -316,7 +484,7 @@ func (ti *TagIndex) FindFirst(id uint16, typeId exifcommon.TagTypePrimitive, ifd
 func (ti *TagIndex) GetWithName(ii *exifcommon.IfdIdentity, name string) (it *IndexedTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -336,24 +504,63 @@ func (ti *TagIndex) GetWithName(ii *exifcommon.IfdIdentity, name string) (it *In
 }
 
 // LoadStandardTags registers the tags that all devices/applications should
-// support.
+// support. These are compiled from assets/tags.yaml (see `go generate`).
 func LoadStandardTags(ti *TagIndex) (err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
-	// Read static data.
+	for i := range standardTags {
+		// Each index gets its own copy so that changes to one don't leak
+		// into the others.
+		it := standardTags[i]
 
-	encodedIfds := make(map[string][]encodedTag)
+		it.SupportedTypes = make([]exifcommon.TagTypePrimitive, len(standardTags[i].SupportedTypes))
+		copy(it.SupportedTypes, standardTags[i].SupportedTypes)
 
-	err = yaml.Unmarshal([]byte(tagsYaml), encodedIfds)
-	log.PanicIf(err)
+		err := ti.Add(&it)
+		log.PanicIf(err)
+	}
+
+	tagsLogger.Debugf(nil, "(%d) tags loaded.", len(standardTags))
+
+	return nil
+}
+
+var (
+	standardTagIndex     *TagIndex
+	standardTagIndexOnce sync.Once
+)
+
+// StandardTagIndex returns a shared index of the standard tags. It can not be
+// changed and is safe for concurrent use. Use `NewTagIndex` for an index that
+// can have other tags added to it.
+func StandardTagIndex() *TagIndex {
+	standardTagIndexOnce.Do(func() {
+		ti := NewTagIndex()
 
-	// Load structure.
+		err := LoadStandardTags(ti)
+		log.PanicIf(err)
+
+		ti.readOnly = true
+
+		standardTagIndex = ti
+	})
+
+	return standardTagIndex
+}
+
+// mergeEncodedTags registers the tags read from a definitions file. Returns
+// the number of tags that were added.
+func mergeEncodedTags(ti *TagIndex, encodedIfds map[string][]encodedTag, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
 
-	count := 0
 	for ifdPath, tags := range encodedIfds {
 		for _, tagInfo := range tags {
 			tagId := uint16(tagInfo.Id)
@@ -398,14 +605,20 @@ func LoadStandardTags(ti *TagIndex) (err error) {
 				SupportedTypes: tagTypes,
 			}
 
-			err = ti.Add(it)
-			log.PanicIf(err)
+			added, err := ti.Merge(it, mode)
+			if err != nil {
+				if err == ErrTagAlreadyExists {
+					tagsLogger.Warningf(nil, "Tag [%s] (0x%04x) [%s] being loaded collides with an existing tag.", ifdPath, tagId, tagName)
+				}
 
-			count++
+				log.Panic(err)
+			}
+
+			if added == true {
+				count++
+			}
 		}
 	}
 
-	tagsLogger.Debugf(nil, "(%d) tags loaded.", count)
-
-	return nil
+	return count, nil
 }
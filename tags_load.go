@@ -0,0 +1,219 @@
+package exif
+
+import (
+	"embed"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+
+	"encoding/json"
+	"io/fs"
+	"io/ioutil"
+
+	log "github.com/dsoprea/go-logging"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// TagPackGeoTiff has the GeoTIFF and GDAL tags.
+	TagPackGeoTiff = "geotiff"
+
+	// TagPackPhotoshop has the tags written by Adobe Photoshop.
+	TagPackPhotoshop = "photoshop"
+
+	// TagPackMicrosoft has the tags written by Microsoft Windows.
+	TagPackMicrosoft = "microsoft"
+
+	// TagPackVendor has private tags that camera vendors commonly write.
+	TagPackVendor = "vendor"
+)
+
+var (
+	// ErrTagPackNotFound means that there is no tag pack with the given name.
+	ErrTagPackNotFound = errors.New("tag pack not found")
+
+	// ErrTagDefinitionsFormatNotValid means that a definitions file has an
+	// extension other than ".yaml", ".yml", or ".json".
+	ErrTagDefinitionsFormatNotValid = errors.New("tag definitions format not valid")
+)
+
+var (
+	//go:embed tag_packs/*.yaml
+	tagPacks embed.FS
+)
+
+// Tag definitions have the same structure as the standard tags
+// (assets/tags.yaml): a mapping of each (non-indexed) IFD-path to a list of
+// tags, each with an "id", a "name", and either a "type_name" or a list of
+// "type_names". The standard tags are loaded into an empty index before any
+// others are merged so that they are not masked.
+
+// LoadTagDefinitionsYaml merges the tag definitions in the YAML document into
+// the index. Returns the number of tags that were added.
+func LoadTagDefinitionsYaml(ti *TagIndex, data []byte, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	encodedIfds := make(map[string][]encodedTag)
+
+	err = yaml.Unmarshal(data, encodedIfds)
+	log.PanicIf(err)
+
+	count, err = mergeLoadedTags(ti, encodedIfds, mode)
+	log.PanicIf(err)
+
+	return count, nil
+}
+
+// LoadTagDefinitionsJson merges the tag definitions in the JSON document into
+// the index. Tag-IDs may be numbers or "0x"-prefixed strings. Returns the
+// number of tags that were added.
+func LoadTagDefinitionsJson(ti *TagIndex, data []byte, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	encodedIfds := make(map[string][]encodedTag)
+
+	err = json.Unmarshal(data, &encodedIfds)
+	log.PanicIf(err)
+
+	count, err = mergeLoadedTags(ti, encodedIfds, mode)
+	log.PanicIf(err)
+
+	return count, nil
+}
+
+// LoadTagDefinitionsFile merges the tag definitions in the given YAML or JSON
+// file (as determined by the extension) into the index.
+func LoadTagDefinitionsFile(ti *TagIndex, filepath string, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	data, err := ioutil.ReadFile(filepath)
+	log.PanicIf(err)
+
+	count, err = loadTagDefinitionsWithName(ti, filepath, data, mode)
+	log.PanicIf(err)
+
+	return count, nil
+}
+
+// LoadTagDefinitionsFs merges the tag definitions in every file in the
+// filesystem that matches the pattern (see `fs.Glob`). The files are loaded in
+// order of their names.
+func LoadTagDefinitionsFs(ti *TagIndex, fsys fs.FS, pattern string, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	filepaths, err := fs.Glob(fsys, pattern)
+	log.PanicIf(err)
+
+	sort.Strings(filepaths)
+
+	for _, filepath := range filepaths {
+		data, err := fs.ReadFile(fsys, filepath)
+		log.PanicIf(err)
+
+		fileCount, err := loadTagDefinitionsWithName(ti, filepath, data, mode)
+		log.PanicIf(err)
+
+		count += fileCount
+	}
+
+	return count, nil
+}
+
+// TagPackNames returns the names of the tag packs that are built in.
+func TagPackNames() []string {
+	filepaths, err := fs.Glob(tagPacks, "tag_packs/*.yaml")
+	log.PanicIf(err)
+
+	names := make([]string, len(filepaths))
+	for i, filepath := range filepaths {
+		names[i] = strings.TrimSuffix(path.Base(filepath), ".yaml")
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// LoadTagPack merges one of the built-in tag packs (see `TagPackNames`) into
+// the index.
+func LoadTagPack(ti *TagIndex, name string, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	data, err := tagPacks.ReadFile("tag_packs/" + name + ".yaml")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) == true {
+			return 0, ErrTagPackNotFound
+		}
+
+		log.Panic(err)
+	}
+
+	count, err = LoadTagDefinitionsYaml(ti, data, mode)
+	log.PanicIf(err)
+
+	return count, nil
+}
+
+// loadTagDefinitionsWithName loads YAML or JSON depending on the extension of
+// the filename.
+func loadTagDefinitionsWithName(ti *TagIndex, filename string, data []byte, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".yaml", ".yml":
+		count, err = LoadTagDefinitionsYaml(ti, data, mode)
+	case ".json":
+		count, err = LoadTagDefinitionsJson(ti, data, mode)
+	default:
+		log.Panic(ErrTagDefinitionsFormatNotValid)
+	}
+
+	log.PanicIf(err)
+
+	return count, nil
+}
+
+// mergeLoadedTags makes sure that the standard tags are present before
+// merging the given ones.
+func mergeLoadedTags(ti *TagIndex, encodedIfds map[string][]encodedTag, mode TagMergeMode) (count int, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if len(ti.tagsByIfd) == 0 {
+		err := LoadStandardTags(ti)
+		log.PanicIf(err)
+	}
+
+	count, err = mergeEncodedTags(ti, encodedIfds, mode)
+	log.PanicIf(err)
+
+	return count, nil
+}
@@ -0,0 +1,239 @@
+package exif
+
+import (
+	"path"
+	"reflect"
+	"testing"
+
+	"io/ioutil"
+	"testing/fstest"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+var (
+	tagsLoadTestYaml = []byte(`IFD:
+- id: 0xc000
+  name: LoadTestTag
+  type_name: SHORT
+`)
+
+	tagsLoadTestJson = []byte(`{
+  "IFD": [
+    {"id": "0xc001", "name": "LoadTestJsonTag", "type_names": ["SHORT", "LONG"]},
+    {"id": 49154, "name": "LoadTestJsonTag2", "type_name": "ASCII"}
+  ]
+}`)
+)
+
+func TestLoadTagDefinitionsYaml(t *testing.T) {
+	ti := NewTagIndex()
+
+	count, err := LoadTagDefinitionsYaml(ti, tagsLoadTestYaml, TagMergeFail)
+	log.PanicIf(err)
+
+	if count != 1 {
+		t.Fatalf("Count not correct: (%d)", count)
+	}
+
+	it, err := ti.GetWithName(exifcommon.IfdStandardIfdIdentity, "LoadTestTag")
+	log.PanicIf(err)
+
+	if it.Id != 0xc000 {
+		t.Fatalf("Tag-ID not correct: (0x%04x)", it.Id)
+	}
+
+	// The standard tags were loaded first.
+
+	_, err = ti.GetWithName(exifcommon.IfdStandardIfdIdentity, "Make")
+	log.PanicIf(err)
+
+	// Loading again collides.
+
+	_, err = LoadTagDefinitionsYaml(ti, tagsLoadTestYaml, TagMergeFail)
+	if log.Is(err, ErrTagAlreadyExists) == false {
+		t.Fatalf("Expected ErrTagAlreadyExists: %v", err)
+	}
+
+	count, err = LoadTagDefinitionsYaml(ti, tagsLoadTestYaml, TagMergeKeep)
+	log.PanicIf(err)
+
+	if count != 0 {
+		t.Fatalf("Nothing should have been added: (%d)", count)
+	}
+}
+
+func TestLoadTagDefinitionsYaml_CollidesWithStandard(t *testing.T) {
+	ti := NewTagIndex()
+
+	data := []byte(`IFD:
+- id: 0x10f
+  name: Maker
+  type_name: ASCII
+`)
+
+	_, err := LoadTagDefinitionsYaml(ti, data, TagMergeFail)
+	if log.Is(err, ErrTagAlreadyExists) == false {
+		t.Fatalf("Expected ErrTagAlreadyExists: %v", err)
+	}
+
+	count, err := LoadTagDefinitionsYaml(ti, data, TagMergeReplace)
+	log.PanicIf(err)
+
+	if count != 1 {
+		t.Fatalf("Count not correct: (%d)", count)
+	}
+
+	it, err := ti.Get(exifcommon.IfdStandardIfdIdentity, 0x10f)
+	log.PanicIf(err)
+
+	if it.Name != "Maker" {
+		t.Fatalf("Tag not replaced: [%s]", it.Name)
+	}
+}
+
+func TestLoadTagDefinitionsJson(t *testing.T) {
+	ti := NewTagIndex()
+
+	count, err := LoadTagDefinitionsJson(ti, tagsLoadTestJson, TagMergeFail)
+	log.PanicIf(err)
+
+	if count != 2 {
+		t.Fatalf("Count not correct: (%d)", count)
+	}
+
+	it, err := ti.Get(exifcommon.IfdStandardIfdIdentity, 0xc001)
+	log.PanicIf(err)
+
+	expectedTypes := []exifcommon.TagTypePrimitive{exifcommon.TypeShort, exifcommon.TypeLong}
+	if it.Name != "LoadTestJsonTag" || reflect.DeepEqual(it.SupportedTypes, expectedTypes) == false {
+		t.Fatalf("Tag not correct: %v", it)
+	}
+
+	it, err = ti.Get(exifcommon.IfdStandardIfdIdentity, 0xc002)
+	log.PanicIf(err)
+
+	if it.Name != "LoadTestJsonTag2" {
+		t.Fatalf("Tag not correct: %v", it)
+	}
+}
+
+func TestLoadTagDefinitionsJson_IdNotValid(t *testing.T) {
+	ti := NewTagIndex()
+
+	data := []byte(`{"IFD": [{"id": 70000, "name": "LoadTestTag", "type_name": "SHORT"}]}`)
+
+	_, err := LoadTagDefinitionsJson(ti, data, TagMergeFail)
+	if err == nil {
+		t.Fatalf("Expected error for out-of-range tag-ID.")
+	}
+}
+
+func TestLoadTagDefinitionsFile(t *testing.T) {
+	tempPath := t.TempDir()
+
+	yamlFilepath := path.Join(tempPath, "tags.yml")
+
+	err := ioutil.WriteFile(yamlFilepath, tagsLoadTestYaml, 0644)
+	log.PanicIf(err)
+
+	jsonFilepath := path.Join(tempPath, "tags.json")
+
+	err = ioutil.WriteFile(jsonFilepath, tagsLoadTestJson, 0644)
+	log.PanicIf(err)
+
+	ti := NewTagIndex()
+
+	count, err := LoadTagDefinitionsFile(ti, yamlFilepath, TagMergeFail)
+	log.PanicIf(err)
+
+	if count != 1 {
+		t.Fatalf("YAML count not correct: (%d)", count)
+	}
+
+	count, err = LoadTagDefinitionsFile(ti, jsonFilepath, TagMergeFail)
+	log.PanicIf(err)
+
+	if count != 2 {
+		t.Fatalf("JSON count not correct: (%d)", count)
+	}
+
+	textFilepath := path.Join(tempPath, "tags.txt")
+
+	err = ioutil.WriteFile(textFilepath, tagsLoadTestYaml, 0644)
+	log.PanicIf(err)
+
+	_, err = LoadTagDefinitionsFile(ti, textFilepath, TagMergeFail)
+	if log.Is(err, ErrTagDefinitionsFormatNotValid) == false {
+		t.Fatalf("Expected ErrTagDefinitionsFormatNotValid: %v", err)
+	}
+}
+
+func TestLoadTagDefinitionsFs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tags/a.yaml":  {Data: tagsLoadTestYaml},
+		"tags/b.json":  {Data: tagsLoadTestJson},
+		"other/c.yaml": {Data: []byte("not: [valid")},
+	}
+
+	ti := NewTagIndex()
+
+	count, err := LoadTagDefinitionsFs(ti, fsys, "tags/*", TagMergeFail)
+	log.PanicIf(err)
+
+	if count != 3 {
+		t.Fatalf("Count not correct: (%d)", count)
+	}
+
+	for _, name := range []string{"LoadTestTag", "LoadTestJsonTag", "LoadTestJsonTag2"} {
+		_, err := ti.GetWithName(exifcommon.IfdStandardIfdIdentity, name)
+		log.PanicIf(err)
+	}
+}
+
+func TestTagPackNames(t *testing.T) {
+	names := TagPackNames()
+
+	expected := []string{
+		TagPackGeoTiff,
+		TagPackMicrosoft,
+		TagPackPhotoshop,
+		TagPackVendor,
+	}
+
+	if reflect.DeepEqual(names, expected) == false {
+		t.Fatalf("Tag-pack names not correct: %v", names)
+	}
+}
+
+func TestLoadTagPack(t *testing.T) {
+	for _, name := range TagPackNames() {
+		ti := NewTagIndex()
+
+		count, err := LoadTagPack(ti, name, TagMergeFail)
+		log.PanicIf(err)
+
+		if count == 0 {
+			t.Fatalf("No tags loaded from pack [%s].", name)
+		}
+	}
+
+	ti := NewTagIndex()
+
+	_, err := LoadTagPack(ti, TagPackVendor, TagMergeFail)
+	log.PanicIf(err)
+
+	it, err := ti.GetWithName(exifcommon.IfdStandardIfdIdentity, "SonyRawFileType")
+	log.PanicIf(err)
+
+	if it.Id != 0x7000 {
+		t.Fatalf("Tag-ID not correct: (0x%04x)", it.Id)
+	}
+
+	_, err = LoadTagPack(ti, "unknown", TagMergeFail)
+	if err != ErrTagPackNotFound {
+		t.Fatalf("Expected ErrTagPackNotFound: %v", err)
+	}
+}
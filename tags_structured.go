@@ -0,0 +1,376 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+// Some tags are stored as short lists of SHORTs or RATIONALs but have a
+// structure that depends on the count. We have typed values for these. They
+// are read via the `Ifd` accessors below and can be given directly to the
+// builder and the in-place patcher in place of the primitive values.
+
+var (
+	// ErrSubjectAreaNotValid means that the SubjectArea does not have two,
+	// three, or four values.
+	ErrSubjectAreaNotValid = errors.New("subject area not valid")
+
+	// ErrLensSpecificationNotValid means that the LensSpecification does not
+	// have four values.
+	ErrLensSpecificationNotValid = errors.New("lens specification not valid")
+
+	// ErrSourceImageNumberNotValid means that the
+	// SourceImageNumberOfCompositeImage does not have two values.
+	ErrSourceImageNumberNotValid = errors.New("source image number not valid")
+)
+
+// structuredValue is a typed value that is encoded as primitive values.
+type structuredValue interface {
+	primitiveValue() interface{}
+}
+
+// SubjectAreaShape is the kind of area that a SubjectArea describes.
+type SubjectAreaShape int
+
+const (
+	// SubjectAreaPoint is a point (two values).
+	SubjectAreaPoint SubjectAreaShape = iota
+
+	// SubjectAreaCircle is a circle (three values).
+	SubjectAreaCircle
+
+	// SubjectAreaRectangle is a rectangle (four values).
+	SubjectAreaRectangle
+)
+
+// String returns a descriptive string.
+func (sas SubjectAreaShape) String() string {
+	switch sas {
+	case SubjectAreaPoint:
+		return "point"
+	case SubjectAreaCircle:
+		return "circle"
+	case SubjectAreaRectangle:
+		return "rectangle"
+	}
+
+	return fmt.Sprintf("SubjectAreaShape(%d)", int(sas))
+}
+
+// SubjectArea is the location and area of the main subject (0x9214). The
+// coordinates are in pixels, relative to the top-left of the image, and
+// describe the center of the area.
+type SubjectArea struct {
+	Shape SubjectAreaShape
+
+	X uint16
+	Y uint16
+
+	// Diameter is only set for a circle.
+	Diameter uint16
+
+	// Width and Height are only set for a rectangle.
+	Width  uint16
+	Height uint16
+}
+
+// NewSubjectArea returns the subject area for the values of the tag.
+func NewSubjectArea(values []uint16) (sa SubjectArea, err error) {
+	switch len(values) {
+	case 2:
+		sa = SubjectArea{
+			Shape: SubjectAreaPoint,
+			X:     values[0],
+			Y:     values[1],
+		}
+	case 3:
+		sa = SubjectArea{
+			Shape:    SubjectAreaCircle,
+			X:        values[0],
+			Y:        values[1],
+			Diameter: values[2],
+		}
+	case 4:
+		sa = SubjectArea{
+			Shape:  SubjectAreaRectangle,
+			X:      values[0],
+			Y:      values[1],
+			Width:  values[2],
+			Height: values[3],
+		}
+	default:
+		return sa, ErrSubjectAreaNotValid
+	}
+
+	return sa, nil
+}
+
+// Shorts returns the values of the tag.
+func (sa SubjectArea) Shorts() []uint16 {
+	switch sa.Shape {
+	case SubjectAreaCircle:
+		return []uint16{sa.X, sa.Y, sa.Diameter}
+	case SubjectAreaRectangle:
+		return []uint16{sa.X, sa.Y, sa.Width, sa.Height}
+	}
+
+	return []uint16{sa.X, sa.Y}
+}
+
+// Bounds returns the smallest rectangle that contains the area. It's empty
+// for a point.
+func (sa SubjectArea) Bounds() image.Rectangle {
+	x := int(sa.X)
+	y := int(sa.Y)
+
+	switch sa.Shape {
+	case SubjectAreaCircle:
+		radius := int(sa.Diameter) / 2
+		return image.Rect(x-radius, y-radius, x-radius+int(sa.Diameter), y-radius+int(sa.Diameter))
+	case SubjectAreaRectangle:
+		return image.Rect(x-int(sa.Width)/2, y-int(sa.Height)/2, x-int(sa.Width)/2+int(sa.Width), y-int(sa.Height)/2+int(sa.Height))
+	}
+
+	return image.Rect(x, y, x, y)
+}
+
+// String returns a descriptive string.
+func (sa SubjectArea) String() string {
+	switch sa.Shape {
+	case SubjectAreaCircle:
+		return fmt.Sprintf("SubjectArea<SHAPE=[%s] X=(%d) Y=(%d) DIAMETER=(%d)>", sa.Shape, sa.X, sa.Y, sa.Diameter)
+	case SubjectAreaRectangle:
+		return fmt.Sprintf("SubjectArea<SHAPE=[%s] X=(%d) Y=(%d) WIDTH=(%d) HEIGHT=(%d)>", sa.Shape, sa.X, sa.Y, sa.Width, sa.Height)
+	}
+
+	return fmt.Sprintf("SubjectArea<SHAPE=[%s] X=(%d) Y=(%d)>", sa.Shape, sa.X, sa.Y)
+}
+
+func (sa SubjectArea) primitiveValue() interface{} {
+	return sa.Shorts()
+}
+
+// LensSpecification is the focal-length and f-number range of the lens
+// (0xa432). A value with a zero denominator is unknown.
+type LensSpecification struct {
+	MinFocalLength exifcommon.Rational
+	MaxFocalLength exifcommon.Rational
+
+	MinFNumberAtMinFocalLength exifcommon.Rational
+	MinFNumberAtMaxFocalLength exifcommon.Rational
+}
+
+// NewLensSpecification returns the lens specification for the values of the
+// tag.
+func NewLensSpecification(values []exifcommon.Rational) (ls LensSpecification, err error) {
+	if len(values) != 4 {
+		return ls, ErrLensSpecificationNotValid
+	}
+
+	ls = LensSpecification{
+		MinFocalLength:             values[0],
+		MaxFocalLength:             values[1],
+		MinFNumberAtMinFocalLength: values[2],
+		MinFNumberAtMaxFocalLength: values[3],
+	}
+
+	return ls, nil
+}
+
+// Rationals returns the values of the tag.
+func (ls LensSpecification) Rationals() []exifcommon.Rational {
+	return []exifcommon.Rational{
+		ls.MinFocalLength,
+		ls.MaxFocalLength,
+		ls.MinFNumberAtMinFocalLength,
+		ls.MinFNumberAtMaxFocalLength,
+	}
+}
+
+// IsPrime returns true if the lens has a fixed focal length.
+func (ls LensSpecification) IsPrime() bool {
+	return ls.MinFocalLength.Denominator != 0 && ls.MinFocalLength == ls.MaxFocalLength
+}
+
+// String returns a descriptive string, e.g. "24-70mm f/2.8".
+func (ls LensSpecification) String() string {
+	focalLength := formatLensSpecificationRange(ls.MinFocalLength, ls.MaxFocalLength)
+	fNumber := formatLensSpecificationRange(ls.MinFNumberAtMinFocalLength, ls.MinFNumberAtMaxFocalLength)
+
+	return fmt.Sprintf("%smm f/%s", focalLength, fNumber)
+}
+
+func (ls LensSpecification) primitiveValue() interface{} {
+	return ls.Rationals()
+}
+
+// formatLensSpecificationRange formats a range, collapsing it if both ends are
+// the same. Unknown values are formatted as "?".
+func formatLensSpecificationRange(min, max exifcommon.Rational) string {
+	format := func(r exifcommon.Rational) string {
+		if r.Denominator == 0 {
+			return "?"
+		}
+
+		return fmt.Sprintf("%.4g", float64(r.Numerator)/float64(r.Denominator))
+	}
+
+	minPhrase := format(min)
+	maxPhrase := format(max)
+
+	if minPhrase == maxPhrase {
+		return minPhrase
+	}
+
+	return minPhrase + "-" + maxPhrase
+}
+
+// SourceImageNumber is the number of images that a composite image was made
+// from (0xa461).
+type SourceImageNumber struct {
+	// Total is the number of source images that were captured.
+	Total uint16
+
+	// Used is the number of source images that were used in the composite.
+	Used uint16
+}
+
+// NewSourceImageNumber returns the source-image number for the values of the
+// tag.
+func NewSourceImageNumber(values []uint16) (sin SourceImageNumber, err error) {
+	if len(values) != 2 {
+		return sin, ErrSourceImageNumberNotValid
+	}
+
+	sin = SourceImageNumber{
+		Total: values[0],
+		Used:  values[1],
+	}
+
+	return sin, nil
+}
+
+// Shorts returns the values of the tag.
+func (sin SourceImageNumber) Shorts() []uint16 {
+	return []uint16{sin.Total, sin.Used}
+}
+
+// String returns a descriptive string.
+func (sin SourceImageNumber) String() string {
+	return fmt.Sprintf("SourceImageNumber<TOTAL=(%d) USED=(%d)>", sin.Total, sin.Used)
+}
+
+func (sin SourceImageNumber) primitiveValue() interface{} {
+	return sin.Shorts()
+}
+
+// findExifTagValue returns the value of the first tag with the given ID. The
+// IFD must be the EXIF IFD.
+func (ifd *Ifd) findExifTagValue(tagId uint16) (value interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	if ifd.ifdIdentity.UnindexedString() != exifcommon.IfdExifStandardIfdIdentity.UnindexedString() {
+		log.Panicf("tag (0x%04x) can only be read on EXIF IFD: [%s] != [%s]", tagId, ifd.ifdIdentity.UnindexedString(), exifcommon.IfdExifStandardIfdIdentity.UnindexedString())
+	}
+
+	tags, found := ifd.EntriesByTagId[tagId]
+	if found == false {
+		return nil, ErrTagNotFound
+	}
+
+	value, err = tags[0].Value()
+	log.PanicIf(err)
+
+	return value, nil
+}
+
+// SubjectArea returns the subject area. The IFD must be the EXIF IFD. Returns
+// `ErrTagNotFound` if there is none.
+func (ifd *Ifd) SubjectArea() (sa SubjectArea, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findExifTagValue(TagSubjectArea)
+	if err == ErrTagNotFound {
+		return sa, err
+	}
+
+	log.PanicIf(err)
+
+	values, ok := value.([]uint16)
+	if ok == false {
+		log.Panic(ErrSubjectAreaNotValid)
+	}
+
+	sa, err = NewSubjectArea(values)
+	log.PanicIf(err)
+
+	return sa, nil
+}
+
+// LensSpecification returns the lens specification. The IFD must be the EXIF
+// IFD. Returns `ErrTagNotFound` if there is none.
+func (ifd *Ifd) LensSpecification() (ls LensSpecification, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findExifTagValue(TagLensSpecification)
+	if err == ErrTagNotFound {
+		return ls, err
+	}
+
+	log.PanicIf(err)
+
+	values, ok := value.([]exifcommon.Rational)
+	if ok == false {
+		log.Panic(ErrLensSpecificationNotValid)
+	}
+
+	ls, err = NewLensSpecification(values)
+	log.PanicIf(err)
+
+	return ls, nil
+}
+
+// SourceImageNumber returns the number of images that the composite image was
+// made from. The IFD must be the EXIF IFD. Returns `ErrTagNotFound` if there
+// is none.
+func (ifd *Ifd) SourceImageNumber() (sin SourceImageNumber, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	value, err := ifd.findExifTagValue(TagSourceImageNumberOfCompositeImage)
+	if err == ErrTagNotFound {
+		return sin, err
+	}
+
+	log.PanicIf(err)
+
+	values, ok := value.([]uint16)
+	if ok == false {
+		log.Panic(ErrSourceImageNumberNotValid)
+	}
+
+	sin, err = NewSourceImageNumber(values)
+	log.PanicIf(err)
+
+	return sin, nil
+}
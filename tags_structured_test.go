@@ -0,0 +1,223 @@
+package exif
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	log "github.com/dsoprea/go-logging"
+
+	exifcommon "github.com/imclaren/go-exif/common"
+)
+
+func TestNewSubjectArea(t *testing.T) {
+	testCases := []struct {
+		values   []uint16
+		expected SubjectArea
+		phrase   string
+		bounds   image.Rectangle
+	}{
+		{
+			[]uint16{100, 200},
+			SubjectArea{Shape: SubjectAreaPoint, X: 100, Y: 200},
+			"SubjectArea<SHAPE=[point] X=(100) Y=(200)>",
+			image.Rect(100, 200, 100, 200),
+		},
+		{
+			[]uint16{100, 200, 50},
+			SubjectArea{Shape: SubjectAreaCircle, X: 100, Y: 200, Diameter: 50},
+			"SubjectArea<SHAPE=[circle] X=(100) Y=(200) DIAMETER=(50)>",
+			image.Rect(75, 175, 125, 225),
+		},
+		{
+			[]uint16{100, 200, 40, 20},
+			SubjectArea{Shape: SubjectAreaRectangle, X: 100, Y: 200, Width: 40, Height: 20},
+			"SubjectArea<SHAPE=[rectangle] X=(100) Y=(200) WIDTH=(40) HEIGHT=(20)>",
+			image.Rect(80, 190, 120, 210),
+		},
+	}
+
+	for _, testCase := range testCases {
+		sa, err := NewSubjectArea(testCase.values)
+		log.PanicIf(err)
+
+		if sa != testCase.expected {
+			t.Fatalf("Subject area not correct: %s", sa)
+		} else if sa.String() != testCase.phrase {
+			t.Fatalf("String not correct: [%s]", sa.String())
+		} else if sa.Bounds() != testCase.bounds {
+			t.Fatalf("Bounds not correct: %s", sa.Bounds())
+		} else if reflect.DeepEqual(sa.Shorts(), testCase.values) != true {
+			t.Fatalf("Shorts not correct: %v", sa.Shorts())
+		}
+	}
+
+	_, err := NewSubjectArea([]uint16{1})
+	if err != ErrSubjectAreaNotValid {
+		t.Fatalf("Expected ErrSubjectAreaNotValid: %v", err)
+	}
+}
+
+func TestNewLensSpecification(t *testing.T) {
+	values := []exifcommon.Rational{{Numerator: 24, Denominator: 1}, {Numerator: 70, Denominator: 1}, {Numerator: 28, Denominator: 10}, {Numerator: 28, Denominator: 10}}
+
+	ls, err := NewLensSpecification(values)
+	log.PanicIf(err)
+
+	if ls.MinFocalLength != values[0] || ls.MaxFocalLength != values[1] {
+		t.Fatalf("Focal lengths not correct: %v", ls)
+	} else if ls.String() != "24-70mm f/2.8" {
+		t.Fatalf("String not correct: [%s]", ls.String())
+	} else if ls.IsPrime() != false {
+		t.Fatalf("Zoom lens reported as prime.")
+	} else if reflect.DeepEqual(ls.Rationals(), values) != true {
+		t.Fatalf("Rationals not correct: %v", ls.Rationals())
+	}
+
+	ls, err = NewLensSpecification([]exifcommon.Rational{{Numerator: 50, Denominator: 1}, {Numerator: 50, Denominator: 1}, {Numerator: 0, Denominator: 0}, {Numerator: 0, Denominator: 0}})
+	log.PanicIf(err)
+
+	if ls.String() != "50mm f/?" {
+		t.Fatalf("String not correct: [%s]", ls.String())
+	} else if ls.IsPrime() != true {
+		t.Fatalf("Prime lens not reported as prime.")
+	}
+
+	_, err = NewLensSpecification(values[:3])
+	if err != ErrLensSpecificationNotValid {
+		t.Fatalf("Expected ErrLensSpecificationNotValid: %v", err)
+	}
+}
+
+func TestNewSourceImageNumber(t *testing.T) {
+	sin, err := NewSourceImageNumber([]uint16{5, 3})
+	log.PanicIf(err)
+
+	if sin.Total != 5 || sin.Used != 3 {
+		t.Fatalf("Source-image number not correct: %s", sin)
+	} else if sin.String() != "SourceImageNumber<TOTAL=(5) USED=(3)>" {
+		t.Fatalf("String not correct: [%s]", sin.String())
+	}
+
+	_, err = NewSourceImageNumber([]uint16{5})
+	if err != ErrSourceImageNumberNotValid {
+		t.Fatalf("Expected ErrSourceImageNumberNotValid: %v", err)
+	}
+}
+
+func getStructuredTestExifData(subjectArea SubjectArea) []byte {
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	exifIb, err := GetOrCreateIbFromRootIb(ib, "IFD/Exif")
+	log.PanicIf(err)
+
+	err = exifIb.SetStandardWithName("SubjectArea", subjectArea)
+	log.PanicIf(err)
+
+	ls := LensSpecification{
+		MinFocalLength:             exifcommon.Rational{Numerator: 24, Denominator: 1},
+		MaxFocalLength:             exifcommon.Rational{Numerator: 70, Denominator: 1},
+		MinFNumberAtMinFocalLength: exifcommon.Rational{Numerator: 4, Denominator: 1},
+		MinFNumberAtMaxFocalLength: exifcommon.Rational{Numerator: 4, Denominator: 1},
+	}
+
+	err = exifIb.SetStandardWithName("LensSpecification", ls)
+	log.PanicIf(err)
+
+	err = exifIb.SetStandardWithName("SourceImageNumberOfCompositeImage", SourceImageNumber{Total: 5, Used: 3})
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(ib)
+	log.PanicIf(err)
+
+	return exifData
+}
+
+func TestIfd_StructuredTags(t *testing.T) {
+	exifData := getStructuredTestExifData(SubjectArea{Shape: SubjectAreaCircle, X: 10, Y: 20, Diameter: 6})
+
+	index := getTestIndex(exifData)
+	exifIfd := index.Lookup["IFD/Exif"]
+
+	sa, err := exifIfd.SubjectArea()
+	log.PanicIf(err)
+
+	if sa.Shape != SubjectAreaCircle || sa.X != 10 || sa.Y != 20 || sa.Diameter != 6 {
+		t.Fatalf("Subject area not correct: %s", sa)
+	}
+
+	ls, err := exifIfd.LensSpecification()
+	log.PanicIf(err)
+
+	if ls.String() != "24-70mm f/4" {
+		t.Fatalf("Lens specification not correct: %s", ls)
+	}
+
+	sin, err := exifIfd.SourceImageNumber()
+	log.PanicIf(err)
+
+	if sin.Total != 5 || sin.Used != 3 {
+		t.Fatalf("Source-image number not correct: %s", sin)
+	}
+
+	_, err = index.RootIfd.SubjectArea()
+	if err == nil {
+		t.Fatalf("Expected error for non-EXIF IFD.")
+	}
+}
+
+func TestIfd_StructuredTags_NotFound(t *testing.T) {
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	exifIb, err := GetOrCreateIbFromRootIb(ib, "IFD/Exif")
+	log.PanicIf(err)
+
+	err = exifIb.SetStandardWithName("ExposureTime", []exifcommon.Rational{{Numerator: 1, Denominator: 100}})
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(ib)
+	log.PanicIf(err)
+
+	exifIfd := getTestIndex(exifData).Lookup["IFD/Exif"]
+
+	_, err = exifIfd.SubjectArea()
+	if err != ErrTagNotFound {
+		t.Fatalf("Expected ErrTagNotFound: %v", err)
+	}
+
+	_, err = exifIfd.LensSpecification()
+	if err != ErrTagNotFound {
+		t.Fatalf("Expected ErrTagNotFound: %v", err)
+	}
+}
+
+func TestInPlacePatcher_Patch_SubjectArea(t *testing.T) {
+	exifData := getStructuredTestExifData(SubjectArea{Shape: SubjectAreaRectangle, X: 10, Y: 20, Width: 4, Height: 2})
+
+	index := getTestIndex(exifData)
+
+	ws := &bytesWriterAt{b: exifData}
+	ipp := NewInPlacePatcher(ws, 0)
+
+	updated := SubjectArea{Shape: SubjectAreaRectangle, X: 30, Y: 40, Width: 8, Height: 6}
+
+	err := ipp.Patch(getInPlaceTestTag(index, "IFD/Exif", "SubjectArea"), updated)
+	log.PanicIf(err)
+
+	sa, err := getTestIndex(exifData).Lookup["IFD/Exif"].SubjectArea()
+	log.PanicIf(err)
+
+	if sa != updated {
+		t.Fatalf("Subject area not correct: %s", sa)
+	}
+}
@@ -2,6 +2,7 @@ package exif
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -182,6 +183,41 @@ func TestIndexedTag_GetEncodingType_BothRationalTypes(t *testing.T) {
 	}
 }
 
+func TestIndexedTag_GetEncodingType_IntegerOrRational(t *testing.T) {
+	it := &IndexedTag{
+		Id:      0xc61a,
+		Name:    "BlackLevel",
+		IfdPath: "IFD",
+		SupportedTypes: []exifcommon.TagTypePrimitive{
+			exifcommon.TypeShort,
+			exifcommon.TypeLong,
+			exifcommon.TypeRational,
+		},
+	}
+
+	if it.GetEncodingType([]uint32{1}) != exifcommon.TypeLong {
+		t.Fatalf("Expected LONG for integers.")
+	} else if it.GetEncodingType([]exifcommon.Rational{{Numerator: 1, Denominator: 2}}) != exifcommon.TypeRational {
+		t.Fatalf("Expected RATIONAL for rationals.")
+	}
+
+	it = &IndexedTag{
+		Id:      0xc628,
+		Name:    "AsShotNeutral",
+		IfdPath: "IFD",
+		SupportedTypes: []exifcommon.TagTypePrimitive{
+			exifcommon.TypeShort,
+			exifcommon.TypeRational,
+		},
+	}
+
+	if it.GetEncodingType([]uint16{1}) != exifcommon.TypeShort {
+		t.Fatalf("Expected SHORT for integers.")
+	} else if it.GetEncodingType([]exifcommon.Rational{{Numerator: 1, Denominator: 2}}) != exifcommon.TypeRational {
+		t.Fatalf("Expected RATIONAL for rationals.")
+	}
+}
+
 func TestIndexedTag_GetEncodingType_Timestamp(t *testing.T) {
 	it := &IndexedTag{
 		SupportedTypes: []exifcommon.TagTypePrimitive{
@@ -401,3 +437,194 @@ func TestLoadStandardTags(t *testing.T) {
 		t.Fatalf("tagsByIfdR should be non-empty at the end.")
 	}
 }
+
+func TestTagIndex_Merge(t *testing.T) {
+	ti := NewTagIndex()
+
+	ifdPath := exifcommon.IfdStandardIfdIdentity.UnindexedString()
+
+	it := &IndexedTag{
+		Id:             0x10f,
+		Name:           "Make",
+		IfdPath:        ifdPath,
+		SupportedTypes: []exifcommon.TagTypePrimitive{exifcommon.TypeAscii},
+	}
+
+	err := ti.Add(it)
+	log.PanicIf(err)
+
+	replacement := &IndexedTag{
+		Id:             0x10f,
+		Name:           "Manufacturer",
+		IfdPath:        ifdPath,
+		SupportedTypes: []exifcommon.TagTypePrimitive{exifcommon.TypeAscii},
+	}
+
+	_, err = ti.Merge(replacement, TagMergeFail)
+	if err != ErrTagAlreadyExists {
+		t.Fatalf("Expected ErrTagAlreadyExists: %v", err)
+	}
+
+	added, err := ti.Merge(replacement, TagMergeKeep)
+	log.PanicIf(err)
+
+	if added != false {
+		t.Fatalf("Tag should have been skipped.")
+	} else if ti.tagsByIfd[ifdPath][0x10f] != it {
+		t.Fatalf("Existing tag should have been kept.")
+	}
+
+	added, err = ti.Merge(replacement, TagMergeReplace)
+	log.PanicIf(err)
+
+	if added != true {
+		t.Fatalf("Tag should have been added.")
+	} else if ti.tagsByIfd[ifdPath][0x10f] != replacement {
+		t.Fatalf("Tag not replaced in forward lookup.")
+	} else if ti.tagsByIfdR[ifdPath]["Manufacturer"] != replacement {
+		t.Fatalf("Tag not replaced in reverse lookup.")
+	} else if _, found := ti.tagsByIfdR[ifdPath]["Make"]; found == true {
+		t.Fatalf("Old name still in reverse lookup.")
+	}
+}
+
+func TestTagIndex_Merge_NameCollision(t *testing.T) {
+	ti := NewTagIndex()
+
+	ifdPath := exifcommon.IfdStandardIfdIdentity.UnindexedString()
+
+	err := ti.Add(&IndexedTag{
+		Id:             0x10f,
+		Name:           "Make",
+		IfdPath:        ifdPath,
+		SupportedTypes: []exifcommon.TagTypePrimitive{exifcommon.TypeAscii},
+	})
+
+	log.PanicIf(err)
+
+	// A different ID with a name that's taken is never merged since it would
+	// remove the other tag.
+	_, err = ti.Merge(&IndexedTag{
+		Id:             0xc000,
+		Name:           "Make",
+		IfdPath:        ifdPath,
+		SupportedTypes: []exifcommon.TagTypePrimitive{exifcommon.TypeAscii},
+	}, TagMergeReplace)
+
+	if err != ErrTagAlreadyExists {
+		t.Fatalf("Expected ErrTagAlreadyExists: %v", err)
+	}
+}
+
+func TestLoadStandardTags_Constants(t *testing.T) {
+	ti := NewTagIndex()
+
+	err := LoadStandardTags(ti)
+	log.PanicIf(err)
+
+	it, err := ti.Get(exifcommon.IfdExifStandardIfdIdentity, TagExposureTime)
+	log.PanicIf(err)
+
+	if it.Name != "ExposureTime" {
+		t.Fatalf("Tag not correct: %v", it)
+	}
+
+	// The TIFF/EP tag reuses the name of an EXIF tag with a different ID.
+
+	it, err = ti.Get(exifcommon.IfdStandardIfdIdentity, TagIfdCFAPattern)
+	log.PanicIf(err)
+
+	if it.Name != "CFAPattern" || TagCFAPattern != 0xa302 {
+		t.Fatalf("Tag not correct: %v", it)
+	}
+}
+
+func TestLoadStandardTags_Independent(t *testing.T) {
+	ti1 := NewTagIndex()
+
+	err := LoadStandardTags(ti1)
+	log.PanicIf(err)
+
+	ti2 := NewTagIndex()
+
+	err = LoadStandardTags(ti2)
+	log.PanicIf(err)
+
+	it1, err := ti1.Get(exifcommon.IfdStandardIfdIdentity, TagMake)
+	log.PanicIf(err)
+
+	it1.SupportedTypes[0] = exifcommon.TypeByte
+
+	it2, err := ti2.Get(exifcommon.IfdStandardIfdIdentity, TagMake)
+	log.PanicIf(err)
+
+	if it2.SupportedTypes[0] != exifcommon.TypeAscii {
+		t.Fatalf("Tags are shared between indexes.")
+	}
+}
+
+func TestStandardTagIndex(t *testing.T) {
+	ti := StandardTagIndex()
+
+	if StandardTagIndex() != ti {
+		t.Fatalf("Standard index is not shared.")
+	}
+
+	it := &IndexedTag{
+		Id:             0xc000,
+		Name:           "StandardTagIndexTest",
+		IfdPath:        exifcommon.IfdStandardIfdIdentity.UnindexedString(),
+		SupportedTypes: []exifcommon.TagTypePrimitive{exifcommon.TypeShort},
+	}
+
+	err := ti.Add(it)
+	if log.Is(err, ErrTagIndexReadOnly) == false {
+		t.Fatalf("Expected ErrTagIndexReadOnly from Add: %v", err)
+	}
+
+	_, err = ti.Merge(it, TagMergeReplace)
+	if err != ErrTagIndexReadOnly {
+		t.Fatalf("Expected ErrTagIndexReadOnly from Merge: %v", err)
+	}
+
+	_, err = LoadTagPack(ti, TagPackVendor, TagMergeKeep)
+	if log.Is(err, ErrTagIndexReadOnly) == false {
+		t.Fatalf("Expected ErrTagIndexReadOnly from LoadTagPack: %v", err)
+	}
+}
+
+func TestStandardTagIndex_Concurrent(t *testing.T) {
+	wg := new(sync.WaitGroup)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			it, err := StandardTagIndex().GetWithName(exifcommon.IfdExifStandardIfdIdentity, "ExposureTime")
+			log.PanicIf(err)
+
+			if it.Id != TagExposureTime {
+				t.Errorf("Tag not correct: %v", it)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestIndexedTag_GetEncodingType_AsciiOrUtf8(t *testing.T) {
+	it := &IndexedTag{
+		Id:             0x013b,
+		Name:           "Artist",
+		IfdPath:        exifcommon.IfdStandardIfdIdentity.UnindexedString(),
+		SupportedTypes: []exifcommon.TagTypePrimitive{exifcommon.TypeAscii, exifcommon.TypeUtf8},
+	}
+
+	if tagType := it.GetEncodingType("Jane Doe"); tagType != exifcommon.TypeAscii {
+		t.Fatalf("Expected ASCII for 7-bit string: [%s]", tagType)
+	} else if tagType := it.GetEncodingType("Jürgen"); tagType != exifcommon.TypeUtf8 {
+		t.Fatalf("Expected UTF-8 for 8-bit string: [%s]", tagType)
+	}
+}
@@ -0,0 +1,53 @@
+package exif
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// The Windows "XP" tags (XPTitle, XPComment, XPAuthor, XPKeywords, XPSubject)
+// are declared as BYTE but hold a NUL-terminated UTF-16LE string, regardless
+// of the byte-order of the EXIF data. We read them as Go strings and encode
+// strings back to the same form.
+
+// isXpTag returns true if the tag holds a Windows UTF-16LE string.
+func isXpTag(ifdPath string, tagId uint16) bool {
+	if ifdPath != "IFD" {
+		return false
+	}
+
+	switch tagId {
+	case TagXPTitle, TagXPComment, TagXPAuthor, TagXPKeywords, TagXPSubject:
+		return true
+	}
+
+	return false
+}
+
+// decodeXpString decodes a UTF-16LE string. It stops at the first NUL and
+// ignores a trailing odd byte. Unpaired surrogates become U+FFFD.
+func decodeXpString(rawBytes []byte) string {
+	units := make([]uint16, 0, len(rawBytes)/2)
+	for i := 0; i+1 < len(rawBytes); i += 2 {
+		unit := binary.LittleEndian.Uint16(rawBytes[i:])
+		if unit == 0 {
+			break
+		}
+
+		units = append(units, unit)
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// encodeXpString encodes a string as NUL-terminated UTF-16LE.
+func encodeXpString(s string) []byte {
+	units := utf16.Encode([]rune(s))
+
+	encoded := make([]byte, (len(units)+1)*2)
+	for i, unit := range units {
+		binary.LittleEndian.PutUint16(encoded[i*2:], unit)
+	}
+
+	return encoded
+}
@@ -0,0 +1,143 @@
+package exif
+
+import (
+	"bytes"
+	"testing"
+
+	"encoding/binary"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+func TestEncodeXpString(t *testing.T) {
+	encoded := encodeXpString("a;b")
+
+	expected := []byte{'a', 0, ';', 0, 'b', 0, 0, 0}
+	if bytes.Equal(encoded, expected) == false {
+		t.Fatalf("Encoding not correct: %v", encoded)
+	}
+}
+
+func TestDecodeXpString(t *testing.T) {
+	original := "Grüße 📷"
+
+	recovered := decodeXpString(encodeXpString(original))
+	if recovered != original {
+		t.Fatalf("Value not recovered correctly: [%s]", recovered)
+	}
+
+	// No NUL and a dangling byte.
+
+	recovered = decodeXpString([]byte{'a', 0, 'b', 0, 'c'})
+	if recovered != "ab" {
+		t.Fatalf("Unterminated value not correct: [%s]", recovered)
+	}
+
+	// Padding after the NUL is ignored.
+
+	recovered = decodeXpString([]byte{'a', 0, 0, 0, 'x', 0})
+	if recovered != "a" {
+		t.Fatalf("Padded value not correct: [%s]", recovered)
+	}
+}
+
+func TestIsXpTag(t *testing.T) {
+	if isXpTag("IFD", 0x9c9e) != true {
+		t.Fatalf("XPKeywords should be an XP tag.")
+	} else if isXpTag("IFD/Exif", 0x9c9e) != false {
+		t.Fatalf("XP tags are only in IFD0.")
+	} else if isXpTag("IFD", TagMake) != false {
+		t.Fatalf("Make is not an XP tag.")
+	}
+}
+
+func TestIfdBuilder_XpTags(t *testing.T) {
+	for _, byteOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		im := NewIfdMappingWithStandard()
+		ti := NewTagIndex()
+
+		ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, byteOrder)
+
+		err := ib.SetStandardWithName("XPKeywords", "a;b")
+		log.PanicIf(err)
+
+		err = ib.SetStandardWithName("XPTitle", "Grüße")
+		log.PanicIf(err)
+
+		ibe := NewIfdByteEncoder()
+
+		exifData, err := ibe.EncodeToExif(ib)
+		log.PanicIf(err)
+
+		exifTags, err := GetFlatExifDataFromBytes(exifData)
+		log.PanicIf(err)
+
+		if len(exifTags) != 2 {
+			t.Fatalf("Tag count not correct: (%d)", len(exifTags))
+		}
+
+		for _, et := range exifTags {
+			switch et.TagName {
+			case "XPKeywords":
+				expected := []byte{'a', 0, ';', 0, 'b', 0, 0, 0}
+				if bytes.Equal(et.ValueBytes, expected) == false {
+					t.Fatalf("XPKeywords bytes not correct (%s): %v", byteOrder, et.ValueBytes)
+				} else if et.Value != "a;b" || et.Formatted != "a;b" {
+					t.Fatalf("XPKeywords value not correct (%s): [%v] [%s]", byteOrder, et.Value, et.Formatted)
+				}
+			case "XPTitle":
+				if et.TagTypeId != exifcommon.TypeByte || et.Value != "Grüße" {
+					t.Fatalf("XPTitle not correct (%s): %s", byteOrder, et)
+				}
+			default:
+				t.Fatalf("Tag not expected: %s", et)
+			}
+		}
+	}
+}
+
+func TestInPlacePatcher_Patch_XpTag(t *testing.T) {
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	err := ib.SetStandardWithName("XPComment", "original")
+	log.PanicIf(err)
+
+	ibe := NewIfdByteEncoder()
+
+	exifData, err := ibe.EncodeToExif(ib)
+	log.PanicIf(err)
+
+	index := getTestIndex(exifData)
+
+	ws := &bytesWriterAt{b: exifData}
+	ipp := NewInPlacePatcher(ws, 0)
+
+	err = ipp.Patch(getInPlaceTestTag(index, "IFD", "XPComment"), "short")
+	log.PanicIf(err)
+
+	value, err := getInPlaceTestTag(getTestIndex(exifData), "IFD", "XPComment").Value()
+	log.PanicIf(err)
+
+	if value != "short" {
+		t.Fatalf("Value not correct: [%v]", value)
+	}
+
+	err = ipp.Patch(getInPlaceTestTag(index, "IFD", "XPComment"), "much longer than before")
+	if err != ErrInPlaceValueTooLarge {
+		t.Fatalf("Expected ErrInPlaceValueTooLarge: %v", err)
+	}
+}
+
+// bytesWriterAt writes into a byte-slice that is already large enough.
+type bytesWriterAt struct {
+	b []byte
+}
+
+func (bwa *bytesWriterAt) WriteAt(p []byte, off int64) (n int, err error) {
+	return copy(bwa.b[off:], p), nil
+}
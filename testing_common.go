@@ -19,7 +19,7 @@ var (
 func getExifSimpleTestIb() *IfdBuilder {
 	defer func() {
 		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
+			err := log.Wrap(state)
 			log.Panic(err)
 		}
 	}()
@@ -50,7 +50,7 @@ func getExifSimpleTestIb() *IfdBuilder {
 func getExifSimpleTestIbBytes() []byte {
 	defer func() {
 		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
+			err := log.Wrap(state)
 			log.Panic(err)
 		}
 	}()
@@ -86,7 +86,7 @@ func getExifSimpleTestIbBytes() []byte {
 func validateExifSimpleTestIb(exifData []byte, t *testing.T) {
 	defer func() {
 		if state := recover(); state != nil {
-			err := log.Wrap(state.(error))
+			err := log.Wrap(state)
 			log.Panic(err)
 		}
 	}()
@@ -183,3 +183,68 @@ func getTestGpsImageFilepath() string {
 	testGpsImageFilepath := path.Join(assetsPath, "gps.jpg")
 	return testGpsImageFilepath
 }
+
+// getTestIndex parses the EXIF data with the standard IFDs and tags.
+func getTestIndex(exifData []byte) IfdIndex {
+	im := NewIfdMapping()
+
+	err := LoadStandardIfds(im)
+	log.PanicIf(err)
+
+	ti := NewTagIndex()
+
+	s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, index, err := Collect(s, im, ti)
+	log.PanicIf(err)
+
+	return index
+}
+
+// getTestRootIb returns a builder loaded with the test EXIF data.
+func getTestRootIb() *IfdBuilder {
+	index := getTestIndex(getTestExifData())
+	return NewIfdBuilderFromExistingChain(index.RootIfd)
+}
+
+// GetTestRootIfd encodes the given IFD0 tags and parses them back. It's
+// exported for the tests of the subpackages.
+func GetTestRootIfd(tags map[string]interface{}) *Ifd {
+	im := NewIfdMappingWithStandard()
+	ti := NewTagIndex()
+
+	ib := NewIfdBuilder(im, ti, exifcommon.IfdStandardIfdIdentity, exifcommon.TestDefaultByteOrder)
+
+	for name, value := range tags {
+		err := ib.SetStandardWithName(name, value)
+		log.PanicIf(err)
+	}
+
+	exifData, err := NewIfdByteEncoder().EncodeToExif(ib)
+	log.PanicIf(err)
+
+	s, err := NewScannerLimitFromBytes(exifData, DefaultStartLimit, DefaultScanLimit)
+	log.PanicIf(err)
+
+	_, index, err := Collect(s, im, ti)
+	log.PanicIf(err)
+
+	return index.RootIfd
+}
+
+// getTestTagValue returns the value of the first occurrence of the tag.
+func getTestTagValue(t *testing.T, index IfdIndex, fqIfdPath string, tagId uint16) interface{} {
+	ifd, found := index.Lookup[fqIfdPath]
+	if found == false {
+		t.Fatalf("IFD [%s] not found.", fqIfdPath)
+	}
+
+	results, err := ifd.FindTagWithId(tagId)
+	log.PanicIf(err)
+
+	value, err := results[0].Value()
+	log.PanicIf(err)
+
+	return value
+}
@@ -0,0 +1,122 @@
+package exifundefined
+
+import (
+	"fmt"
+	"strings"
+
+	"encoding/csv"
+	"io"
+
+	"github.com/dsoprea/go-logging"
+)
+
+// CfaColor is the color of one filter in a color filter array (as defined by
+// TIFF/EP).
+type CfaColor byte
+
+const (
+	CfaRed     CfaColor = 0
+	CfaGreen   CfaColor = 1
+	CfaBlue    CfaColor = 2
+	CfaCyan    CfaColor = 3
+	CfaMagenta CfaColor = 4
+	CfaYellow  CfaColor = 5
+	CfaWhite   CfaColor = 6
+)
+
+var (
+	cfaColorLetters = map[CfaColor]string{
+		CfaRed:     "R",
+		CfaGreen:   "G",
+		CfaBlue:    "B",
+		CfaCyan:    "C",
+		CfaMagenta: "M",
+		CfaYellow:  "Y",
+		CfaWhite:   "W",
+	}
+)
+
+// String returns the letter for the color (e.g. "R"). Colors that are not
+// defined are returned as a number.
+func (cc CfaColor) String() string {
+	if letter, found := cfaColorLetters[cc]; found == true {
+		return letter
+	}
+
+	return fmt.Sprintf("%d", byte(cc))
+}
+
+// CfaGrid is the repeating pattern of a color filter array, by row and then
+// column.
+type CfaGrid [][]CfaColor
+
+// NewCfaGrid arranges the colors of a pattern with the given dimensions into
+// rows.
+func NewCfaGrid(columns, rows int, values []byte) (cg CfaGrid, err error) {
+	if columns <= 0 || rows <= 0 || len(values) != columns*rows {
+		return nil, ErrMatrixNotValid
+	}
+
+	cg = make(CfaGrid, rows)
+	for i := range cg {
+		cg[i] = make([]CfaColor, columns)
+		for j := range cg[i] {
+			cg[i][j] = CfaColor(values[i*columns+j])
+		}
+	}
+
+	return cg, nil
+}
+
+// Layout returns the colors in row order as one string, e.g. "RGGB" for a
+// Bayer pattern that starts with red.
+func (cg CfaGrid) Layout() string {
+	b := new(strings.Builder)
+
+	for _, row := range cg {
+		for _, color := range row {
+			b.WriteString(color.String())
+		}
+	}
+
+	return b.String()
+}
+
+// IsBayer returns true if this is a 2x2 pattern of red, blue, and two greens
+// on a diagonal.
+func (cg CfaGrid) IsBayer() bool {
+	switch cg.Layout() {
+	case "RGGB", "BGGR", "GRBG", "GBRG":
+		return true
+	}
+
+	return false
+}
+
+// WriteCsv writes the grid as CSV, one row per line.
+func (cg CfaGrid) WriteCsv(w io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	cw := csv.NewWriter(w)
+
+	for _, row := range cg {
+		record := make([]string, len(row))
+		for i, color := range row {
+			record[i] = color.String()
+		}
+
+		err := cw.Write(record)
+		log.PanicIf(err)
+	}
+
+	cw.Flush()
+
+	err = cw.Error()
+	log.PanicIf(err)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package exifundefined
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dsoprea/go-logging"
+)
+
+func TestCfaColor_String(t *testing.T) {
+	if CfaRed.String() != "R" {
+		t.Fatalf("String not correct: [%s]", CfaRed.String())
+	} else if CfaColor(9).String() != "9" {
+		t.Fatalf("String not correct: [%s]", CfaColor(9).String())
+	}
+}
+
+func TestNewCfaGrid(t *testing.T) {
+	cg, err := NewCfaGrid(2, 2, []byte{2, 1, 1, 0})
+	log.PanicIf(err)
+
+	expected := CfaGrid{{CfaBlue, CfaGreen}, {CfaGreen, CfaRed}}
+	if reflect.DeepEqual(cg, expected) != true {
+		t.Fatalf("Grid not correct: %v", cg)
+	} else if cg.Layout() != "BGGR" {
+		t.Fatalf("Layout not correct: [%s]", cg.Layout())
+	} else if cg.IsBayer() != true {
+		t.Fatalf("Grid not reported as Bayer.")
+	}
+
+	_, err = NewCfaGrid(2, 2, []byte{2, 1, 1})
+	if err != ErrMatrixNotValid {
+		t.Fatalf("Expected ErrMatrixNotValid: %v", err)
+	}
+}
+
+func TestCfaGrid_IsBayer(t *testing.T) {
+	cg, err := NewCfaGrid(2, 2, []byte{3, 5, 5, 4})
+	log.PanicIf(err)
+
+	if cg.IsBayer() != false {
+		t.Fatalf("CMY grid reported as Bayer.")
+	}
+
+	cg, err = NewCfaGrid(3, 1, []byte{0, 1, 2})
+	log.PanicIf(err)
+
+	if cg.IsBayer() != false {
+		t.Fatalf("Striped grid reported as Bayer.")
+	}
+}
+
+func TestCfaGrid_WriteCsv(t *testing.T) {
+	cg, err := NewCfaGrid(2, 2, []byte{0, 1, 1, 2})
+	log.PanicIf(err)
+
+	b := new(bytes.Buffer)
+
+	err = cg.WriteCsv(b)
+	log.PanicIf(err)
+
+	if b.String() != "R,G\nG,B\n" {
+		t.Fatalf("CSV not correct: [%s]", b.String())
+	}
+}
@@ -3,8 +3,10 @@ package exifundefined
 import (
 	"bytes"
 	"fmt"
+	"math"
 
 	"encoding/binary"
+	"io"
 
 	"github.com/dsoprea/go-logging"
 
@@ -26,13 +28,48 @@ func (oecf Tag8828Oecf) EncoderName() string {
 	return "Codec8828Oecf"
 }
 
+// Matrix returns the values as a matrix with a row for each of `Rows`.
+func (oecf Tag8828Oecf) Matrix() (m Matrix, err error) {
+	if int(oecf.Columns) != len(oecf.ColumnNames) {
+		return m, ErrMatrixNotValid
+	}
+
+	values := make([]float64, len(oecf.Values))
+	for i, value := range oecf.Values {
+		if value.Denominator == 0 {
+			values[i] = math.NaN()
+		} else {
+			values[i] = float64(value.Numerator) / float64(value.Denominator)
+		}
+	}
+
+	return newMatrix(oecf.ColumnNames, int(oecf.Rows), values)
+}
+
+// WriteCsv writes the matrix as CSV.
+func (oecf Tag8828Oecf) WriteCsv(w io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	m, err := oecf.Matrix()
+	log.PanicIf(err)
+
+	err = m.WriteCsv(w)
+	log.PanicIf(err)
+
+	return nil
+}
+
 type Codec8828Oecf struct {
 }
 
 func (Codec8828Oecf) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -73,7 +110,7 @@ func (Codec8828Oecf) Encode(value interface{}, byteOrder binary.ByteOrder) (enco
 func (Codec8828Oecf) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -82,10 +119,16 @@ func (Codec8828Oecf) Decode(valueContext *exifcommon.ValueContext) (value Encode
 	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
 
 	valueBytes, err := valueContext.ReadBytes()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	oecf := Tag8828Oecf{}
 
+	if len(valueBytes) < 4 {
+		return nil, newTruncatedError(valueContext, 4, len(valueBytes))
+	}
+
 	oecf.Columns = valueContext.ByteOrder().Uint16(valueBytes[0:2])
 	oecf.Rows = valueContext.ByteOrder().Uint16(valueBytes[2:4])
 
@@ -100,6 +143,10 @@ func (Codec8828Oecf) Decode(valueContext *exifcommon.ValueContext) (value Encode
 	currentColumnNumber := uint16(0)
 
 	for currentColumnNumber < oecf.Columns {
+		if offset >= len(valueBytes) {
+			return nil, newTruncatedError(valueContext, offset+1, len(valueBytes))
+		}
+
 		if valueBytes[offset] == 0 {
 			columnName := string(valueBytes[startAt:offset])
 			if len(columnName) == 0 {
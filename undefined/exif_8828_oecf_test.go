@@ -2,6 +2,7 @@ package exifundefined
 
 import (
 	"bytes"
+	"math"
 	"reflect"
 	"testing"
 
@@ -86,3 +87,40 @@ func TestCodec8828Oecf_Decode(t *testing.T) {
 		t.Fatalf("Decoded value not correct: %s", value)
 	}
 }
+
+func TestTag8828Oecf_Matrix(t *testing.T) {
+	ut := Tag8828Oecf{
+		Columns:     2,
+		Rows:        2,
+		ColumnNames: []string{"Lux", "Value"},
+		Values: []exifcommon.SignedRational{
+			{Numerator: 1, Denominator: 2}, {Numerator: -3, Denominator: 1},
+			{Numerator: 5, Denominator: 1}, {Numerator: 1, Denominator: 0},
+		},
+	}
+
+	m, err := ut.Matrix()
+	log.PanicIf(err)
+
+	if m.Rows[0][0] != 0.5 || m.Rows[0][1] != -3 || m.Rows[1][0] != 5 {
+		t.Fatalf("Matrix not correct: %v", m.Rows)
+	} else if math.IsNaN(m.Rows[1][1]) != true {
+		t.Fatalf("Zero denominator not NaN: %v", m.Rows[1][1])
+	}
+
+	b := new(bytes.Buffer)
+
+	err = ut.WriteCsv(b)
+	log.PanicIf(err)
+
+	if b.String() != "Lux,Value\n0.5,-3\n5,\n" {
+		t.Fatalf("CSV not correct: [%s]", b.String())
+	}
+
+	ut.Rows = 3
+
+	_, err = ut.Matrix()
+	if err != ErrMatrixNotValid {
+		t.Fatalf("Expected ErrMatrixNotValid: %v", err)
+	}
+}
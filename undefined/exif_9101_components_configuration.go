@@ -69,7 +69,7 @@ type CodecExif9101ComponentsConfiguration struct {
 func (CodecExif9101ComponentsConfiguration) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -84,14 +84,16 @@ func (CodecExif9101ComponentsConfiguration) Encode(value interface{}, byteOrder
 func (CodecExif9101ComponentsConfiguration) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
 
 	valueBytes, err := valueContext.ReadBytes()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	for configurationId, configurationBytes := range TagUndefinedType_9101_ComponentsConfiguration_Configurations {
 		if bytes.Equal(configurationBytes, valueBytes) == true {
@@ -44,7 +44,7 @@ type Codec927CMakerNote struct {
 func (Codec927CMakerNote) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -61,7 +61,7 @@ func (Codec927CMakerNote) Encode(value interface{}, byteOrder binary.ByteOrder)
 func (Codec927CMakerNote) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -72,7 +72,9 @@ func (Codec927CMakerNote) Decode(valueContext *exifcommon.ValueContext) (value E
 	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
 
 	valueBytes, err := valueContext.ReadBytes()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	// TODO(dustin): Doesn't work, but here as an example.
 	//             ie := NewIfdEnumerate(valueBytes, byteOrder)
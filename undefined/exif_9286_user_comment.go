@@ -3,10 +3,14 @@ package exifundefined
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"encoding/binary"
+	"unicode/utf16"
 
 	"github.com/dsoprea/go-logging"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
 
 	"github.com/imclaren/go-exif/common"
 )
@@ -38,9 +42,41 @@ var (
 	}
 )
 
+// Tag9286UserComment is a user comment. When decoded, `Text` has the comment
+// in whichever character set it was stored with. When encoded, `EncodingBytes`
+// are stored as they are or, if empty, `Text` is stored using `EncodingType`
+// (see `NewTag9286UserComment`). UNICODE bytes without a BOM are re-encoded
+// from `Text` if they were read with a different byte-order.
 type Tag9286UserComment struct {
 	EncodingType  int
 	EncodingBytes []byte
+
+	// Text is the decoded comment without trailing NULs or spaces. It is
+	// empty if the encoding is UNDEFINED or could not be decoded.
+	Text string
+}
+
+// NewTag9286UserComment returns a comment that will be stored using the
+// smallest character set that can represent the text: ASCII if it is 7-bit
+// and otherwise JIS or UNICODE.
+func NewTag9286UserComment(text string) Tag9286UserComment {
+	encodingType := TagUndefinedType_9286_UserComment_Encoding_ASCII
+
+	if isUserCommentAscii(text) == false {
+		encodingType = TagUndefinedType_9286_UserComment_Encoding_UNICODE
+
+		unicodeSize := len(utf16.Encode([]rune(text))) * 2
+
+		jisBytes, err := japanese.ISO2022JP.NewEncoder().Bytes([]byte(text))
+		if err == nil && len(jisBytes) < unicodeSize {
+			encodingType = TagUndefinedType_9286_UserComment_Encoding_JIS
+		}
+	}
+
+	return Tag9286UserComment{
+		EncodingType: encodingType,
+		Text:         text,
+	}
 }
 
 func (Tag9286UserComment) EncoderName() string {
@@ -50,7 +86,9 @@ func (Tag9286UserComment) EncoderName() string {
 func (uc Tag9286UserComment) String() string {
 	var valuePhrase string
 
-	if uc.EncodingType == TagUndefinedType_9286_UserComment_Encoding_ASCII {
+	if uc.Text != "" {
+		return fmt.Sprintf("[%s] %s", TagUndefinedType_9286_UserComment_Encoding_Names[uc.EncodingType], uc.Text)
+	} else if uc.EncodingType == TagUndefinedType_9286_UserComment_Encoding_ASCII {
 		return fmt.Sprintf("[ASCII] %s", string(uc.EncodingBytes))
 	} else {
 		if len(uc.EncodingBytes) <= 8 {
@@ -69,7 +107,7 @@ type Codec9286UserComment struct {
 func (Codec9286UserComment) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -83,10 +121,25 @@ func (Codec9286UserComment) Encode(value interface{}, byteOrder binary.ByteOrder
 		log.Panicf("encoding-type not valid for unknown-type tag 9286 (UserComment): (%d)", uc.EncodingType)
 	}
 
-	encoded = make([]byte, len(uc.EncodingBytes)+8)
+	encodingBytes := uc.EncodingBytes
+	if len(encodingBytes) == 0 && uc.Text != "" {
+		encodingBytes, err = encodeUserCommentText(uc.EncodingType, uc.Text, byteOrder)
+		log.PanicIf(err)
+	} else if uc.EncodingType == TagUndefinedType_9286_UserComment_Encoding_UNICODE && uc.Text != "" && hasUserCommentBom(encodingBytes) == false {
+		// Without a BOM, the existing bytes are in whichever byte-order they
+		// were read with. If they don't read back the same in the byte-order
+		// that we're writing, re-encode the text.
+		text, err := decodeUserCommentText(uc.EncodingType, encodingBytes, byteOrder)
+		if err != nil || text != uc.Text {
+			encodingBytes, err = encodeUserCommentText(uc.EncodingType, uc.Text, byteOrder)
+			log.PanicIf(err)
+		}
+	}
+
+	encoded = make([]byte, len(encodingBytes)+8)
 
 	copy(encoded[:8], encodingTypeBytes)
-	copy(encoded[8:], uc.EncodingBytes)
+	copy(encoded[8:], encodingBytes)
 
 	// TODO(dustin): Confirm this size against the specification.
 
@@ -96,14 +149,16 @@ func (Codec9286UserComment) Encode(value interface{}, byteOrder binary.ByteOrder
 func (Codec9286UserComment) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
 
 	valueBytes, err := valueContext.ReadBytes()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(valueBytes) < 8 {
 		return nil, ErrUnparseableValue
@@ -122,6 +177,13 @@ func (Codec9286UserComment) Decode(valueContext *exifcommon.ValueContext) (value
 				EncodingBytes: valueBytes[8:],
 			}
 
+			text, err := decodeUserCommentText(encodingIndex, uc.EncodingBytes, valueContext.ByteOrder())
+			if err != nil {
+				exif9286Logger.Warningf(nil, "User-comment could not be decoded as [%s]: %s", TagUndefinedType_9286_UserComment_Encoding_Names[encodingIndex], err.Error())
+			} else {
+				uc.Text = text
+			}
+
 			return uc, nil
 		}
 	}
@@ -130,6 +192,116 @@ func (Codec9286UserComment) Decode(valueContext *exifcommon.ValueContext) (value
 	return unknownUc, nil
 }
 
+// isUserCommentAscii returns true if the text only has 7-bit characters.
+func isUserCommentAscii(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasUserCommentBom returns true if the UNICODE text starts with a BOM.
+func hasUserCommentBom(encodingBytes []byte) bool {
+	if len(encodingBytes) < 2 {
+		return false
+	}
+
+	return (encodingBytes[0] == 0xfe && encodingBytes[1] == 0xff) || (encodingBytes[0] == 0xff && encodingBytes[1] == 0xfe)
+}
+
+// decodeUserCommentText decodes the comment bytes for the given encoding.
+//
+// JIS text is usually ISO-2022-JP (JIS X 0208 with escape sequences), but some
+// cameras write Shift-JIS instead. We decide by whether there are escapes or
+// 8-bit bytes. UNICODE text is UCS-2 in the byte-order of the EXIF data unless
+// it starts with a BOM.
+func decodeUserCommentText(encodingType int, encodingBytes []byte, byteOrder binary.ByteOrder) (text string, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch encodingType {
+	case TagUndefinedType_9286_UserComment_Encoding_ASCII:
+		text = string(encodingBytes)
+	case TagUndefinedType_9286_UserComment_Encoding_JIS:
+		var decoder *encoding.Decoder
+
+		if bytes.IndexByte(encodingBytes, 0x1b) != -1 {
+			decoder = japanese.ISO2022JP.NewDecoder()
+		} else if isUserCommentAscii(string(encodingBytes)) == false {
+			decoder = japanese.ShiftJIS.NewDecoder()
+		}
+
+		if decoder == nil {
+			text = string(encodingBytes)
+		} else {
+			decoded, err := decoder.Bytes(encodingBytes)
+			log.PanicIf(err)
+
+			text = string(decoded)
+		}
+	case TagUndefinedType_9286_UserComment_Encoding_UNICODE:
+		if hasUserCommentBom(encodingBytes) == true {
+			if encodingBytes[0] == 0xfe {
+				byteOrder = binary.BigEndian
+			} else {
+				byteOrder = binary.LittleEndian
+			}
+
+			encodingBytes = encodingBytes[2:]
+		}
+
+		units := make([]uint16, len(encodingBytes)/2)
+		for i := range units {
+			units[i] = byteOrder.Uint16(encodingBytes[i*2:])
+		}
+
+		text = string(utf16.Decode(units))
+	default:
+		return "", nil
+	}
+
+	return strings.TrimRight(text, "\x00 "), nil
+}
+
+// encodeUserCommentText encodes the text with the given encoding. UNICODE
+// text is written in the byte-order of the EXIF data, without a BOM.
+func encodeUserCommentText(encodingType int, text string, byteOrder binary.ByteOrder) (encodingBytes []byte, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	switch encodingType {
+	case TagUndefinedType_9286_UserComment_Encoding_ASCII:
+		if isUserCommentAscii(text) == false {
+			log.Panicf("user-comment can not be encoded as ASCII: [%s]", text)
+		}
+
+		encodingBytes = []byte(text)
+	case TagUndefinedType_9286_UserComment_Encoding_JIS:
+		encodingBytes, err = japanese.ISO2022JP.NewEncoder().Bytes([]byte(text))
+		log.PanicIf(err)
+	case TagUndefinedType_9286_UserComment_Encoding_UNICODE:
+		units := utf16.Encode([]rune(text))
+
+		encodingBytes = make([]byte, len(units)*2)
+		for i, unit := range units {
+			byteOrder.PutUint16(encodingBytes[i*2:], unit)
+		}
+	default:
+		encodingBytes = []byte(text)
+	}
+
+	return encodingBytes, nil
+}
+
 func init() {
 	registerEncoder(
 		Tag9286UserComment{},
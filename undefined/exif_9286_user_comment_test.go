@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 
+	"encoding/binary"
+
 	"github.com/dsoprea/go-logging"
 
 	"github.com/imclaren/go-exif/common"
@@ -86,9 +88,206 @@ func TestCodec9286UserComment_Decode(t *testing.T) {
 	expectedUt := Tag9286UserComment{
 		EncodingType:  TagUndefinedType_9286_UserComment_Encoding_ASCII,
 		EncodingBytes: []byte(comment),
+		Text:          comment,
 	}
 
 	if reflect.DeepEqual(decoded, expectedUt) != true {
 		t.Fatalf("Decoded struct not correct.")
 	}
 }
+
+func decodeUserCommentTestBytes(encoded []byte, byteOrder binary.ByteOrder) Tag9286UserComment {
+	valueContext := exifcommon.NewValueContext(
+		"",
+		0,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		byteOrder)
+
+	codec := Codec9286UserComment{}
+
+	decoded, err := codec.Decode(valueContext)
+	log.PanicIf(err)
+
+	return decoded.(Tag9286UserComment)
+}
+
+func TestCodec9286UserComment_Decode_Jis(t *testing.T) {
+	header := TagUndefinedType_9286_UserComment_Encodings[TagUndefinedType_9286_UserComment_Encoding_JIS]
+
+	// ISO-2022-JP.
+
+	encoded := append([]byte{}, header...)
+	encoded = append(encoded, 0x1b, 0x24, 0x42, 0x45, 0x6c, 0x35, 0x7e, 0x1b, 0x28, 0x42, 0x00)
+
+	uc := decodeUserCommentTestBytes(encoded, exifcommon.TestDefaultByteOrder)
+	if uc.Text != "東京" {
+		t.Fatalf("ISO-2022-JP text not correct: [%s]", uc.Text)
+	}
+
+	// Shift-JIS.
+
+	encoded = append([]byte{}, header...)
+	encoded = append(encoded, 0x93, 0x8c, 0x8b, 0x9e, 0x20, 0x20)
+
+	uc = decodeUserCommentTestBytes(encoded, exifcommon.TestDefaultByteOrder)
+	if uc.Text != "東京" {
+		t.Fatalf("Shift-JIS text not correct: [%s]", uc.Text)
+	} else if uc.String() != "[JIS] 東京" {
+		t.Fatalf("String not correct: [%s]", uc.String())
+	}
+}
+
+func TestCodec9286UserComment_Decode_Unicode(t *testing.T) {
+	header := TagUndefinedType_9286_UserComment_Encodings[TagUndefinedType_9286_UserComment_Encoding_UNICODE]
+
+	testCases := []struct {
+		name      string
+		textBytes []byte
+		byteOrder binary.ByteOrder
+	}{
+		{"big-endian", []byte{0x00, 0x41, 0x00, 0xe9, 0x00, 0x00}, binary.BigEndian},
+		{"little-endian", []byte{0x41, 0x00, 0xe9, 0x00}, binary.LittleEndian},
+		{"big-endian BOM", []byte{0xfe, 0xff, 0x00, 0x41, 0x00, 0xe9}, binary.LittleEndian},
+		{"little-endian BOM", []byte{0xff, 0xfe, 0x41, 0x00, 0xe9, 0x00}, binary.BigEndian},
+	}
+
+	for _, testCase := range testCases {
+		encoded := append([]byte{}, header...)
+		encoded = append(encoded, testCase.textBytes...)
+
+		uc := decodeUserCommentTestBytes(encoded, testCase.byteOrder)
+		if uc.Text != "Aé" {
+			t.Fatalf("Text not correct for %s: [%s]", testCase.name, uc.Text)
+		}
+	}
+}
+
+func TestCodec9286UserComment_Decode_Undefined(t *testing.T) {
+	encoded := append([]byte{}, TagUndefinedType_9286_UserComment_Encodings[TagUndefinedType_9286_UserComment_Encoding_UNDEFINED]...)
+	encoded = append(encoded, 0x01, 0x02)
+
+	uc := decodeUserCommentTestBytes(encoded, exifcommon.TestDefaultByteOrder)
+	if uc.Text != "" {
+		t.Fatalf("Text not expected: [%s]", uc.Text)
+	} else if bytes.Equal(uc.EncodingBytes, []byte{0x01, 0x02}) != true {
+		t.Fatalf("Bytes not correct: %v", uc.EncodingBytes)
+	}
+}
+
+func TestNewTag9286UserComment(t *testing.T) {
+	testCases := []struct {
+		text         string
+		encodingType int
+	}{
+		{"some comment", TagUndefinedType_9286_UserComment_Encoding_ASCII},
+		{"東京タワー", TagUndefinedType_9286_UserComment_Encoding_UNICODE},
+		{"Tokyo 東京", TagUndefinedType_9286_UserComment_Encoding_UNICODE},
+		{"My trip to Tokyo: 東", TagUndefinedType_9286_UserComment_Encoding_JIS},
+		{"café", TagUndefinedType_9286_UserComment_Encoding_UNICODE},
+	}
+
+	for _, testCase := range testCases {
+		uc := NewTag9286UserComment(testCase.text)
+		if uc.EncodingType != testCase.encodingType {
+			t.Fatalf("Encoding not correct for [%s]: [%s]", testCase.text, TagUndefinedType_9286_UserComment_Encoding_Names[uc.EncodingType])
+		}
+	}
+}
+
+func TestCodec9286UserComment_Encode_Text(t *testing.T) {
+	codec := Codec9286UserComment{}
+
+	texts := []string{
+		"some comment",
+		"東京タワー",
+		"My trip to Tokyo: 東",
+	}
+
+	byteOrders := []binary.ByteOrder{
+		binary.BigEndian,
+		binary.LittleEndian,
+	}
+
+	for _, text := range texts {
+		for _, byteOrder := range byteOrders {
+			uc := NewTag9286UserComment(text)
+
+			encoded, _, err := codec.Encode(uc, byteOrder)
+			log.PanicIf(err)
+
+			typeBytes := TagUndefinedType_9286_UserComment_Encodings[uc.EncodingType]
+			if bytes.Equal(encoded[:8], typeBytes) != true {
+				t.Fatalf("Encoding type not correct for [%s].", text)
+			}
+
+			decoded := decodeUserCommentTestBytes(encoded, byteOrder)
+			if decoded.Text != text {
+				t.Fatalf("Text not correct after round-trip: [%s] != [%s]", decoded.Text, text)
+			} else if decoded.EncodingType != uc.EncodingType {
+				t.Fatalf("Encoding type not correct after round-trip for [%s].", text)
+			}
+		}
+	}
+}
+
+func TestCodec9286UserComment_Encode_UnicodeByteOrder(t *testing.T) {
+	codec := Codec9286UserComment{}
+
+	encoded := append([]byte{}, TagUndefinedType_9286_UserComment_Encodings[TagUndefinedType_9286_UserComment_Encoding_UNICODE]...)
+	encoded = append(encoded, 0x41, 0x00, 0xe9, 0x00)
+
+	uc := decodeUserCommentTestBytes(encoded, binary.LittleEndian)
+
+	swapped, _, err := codec.Encode(uc, binary.BigEndian)
+	log.PanicIf(err)
+
+	if bytes.Equal(swapped[8:], []byte{0x00, 0x41, 0x00, 0xe9}) != true {
+		t.Fatalf("Swapped bytes not correct: %v", swapped[8:])
+	}
+
+	decoded := decodeUserCommentTestBytes(swapped, binary.BigEndian)
+	if decoded.Text != "Aé" {
+		t.Fatalf("Text not correct after swapping byte-order: [%s]", decoded.Text)
+	}
+
+	// The original bytes should be kept when the byte-order doesn't change.
+
+	unchanged, _, err := codec.Encode(uc, binary.LittleEndian)
+	log.PanicIf(err)
+
+	if bytes.Equal(unchanged, encoded) != true {
+		t.Fatalf("Bytes not preserved: %v", unchanged)
+	}
+
+	// A BOM makes the bytes independent of the byte-order.
+
+	bomEncoded := append([]byte{}, encoded[:8]...)
+	bomEncoded = append(bomEncoded, 0xff, 0xfe, 0x41, 0x00, 0xe9, 0x00)
+
+	uc = decodeUserCommentTestBytes(bomEncoded, binary.LittleEndian)
+
+	swapped, _, err = codec.Encode(uc, binary.BigEndian)
+	log.PanicIf(err)
+
+	if bytes.Equal(swapped, bomEncoded) != true {
+		t.Fatalf("BOM bytes not preserved: %v", swapped)
+	}
+}
+
+func TestCodec9286UserComment_Encode_AsciiNotValid(t *testing.T) {
+	uc := Tag9286UserComment{
+		EncodingType: TagUndefinedType_9286_UserComment_Encoding_ASCII,
+		Text:         "café",
+	}
+
+	codec := Codec9286UserComment{}
+
+	_, _, err := codec.Encode(uc, exifcommon.TestDefaultByteOrder)
+	if err == nil {
+		t.Fatalf("Expected error for non-ASCII text.")
+	}
+}
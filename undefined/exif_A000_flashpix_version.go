@@ -26,7 +26,7 @@ type CodecA000FlashpixVersion struct {
 func (CodecA000FlashpixVersion) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -41,14 +41,16 @@ func (CodecA000FlashpixVersion) Encode(value interface{}, byteOrder binary.ByteO
 func (CodecA000FlashpixVersion) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeAsciiNoNul)
 
 	valueString, err := valueContext.ReadAsciiNoNul()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	fv := TagA000FlashpixVersion{
 		FlashpixVersion: valueString,
@@ -3,8 +3,10 @@ package exifundefined
 import (
 	"bytes"
 	"fmt"
+	"math"
 
 	"encoding/binary"
+	"io"
 
 	"github.com/dsoprea/go-logging"
 
@@ -26,13 +28,48 @@ func (sfr TagA20CSpatialFrequencyResponse) String() string {
 	return fmt.Sprintf("CodecA20CSpatialFrequencyResponse<COLUMNS=(%d) ROWS=(%d)>", sfr.Columns, sfr.Rows)
 }
 
+// Matrix returns the values as a matrix with a row for each of `Rows`.
+func (sfr TagA20CSpatialFrequencyResponse) Matrix() (m Matrix, err error) {
+	if int(sfr.Columns) != len(sfr.ColumnNames) {
+		return m, ErrMatrixNotValid
+	}
+
+	values := make([]float64, len(sfr.Values))
+	for i, value := range sfr.Values {
+		if value.Denominator == 0 {
+			values[i] = math.NaN()
+		} else {
+			values[i] = float64(value.Numerator) / float64(value.Denominator)
+		}
+	}
+
+	return newMatrix(sfr.ColumnNames, int(sfr.Rows), values)
+}
+
+// WriteCsv writes the matrix as CSV.
+func (sfr TagA20CSpatialFrequencyResponse) WriteCsv(w io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	m, err := sfr.Matrix()
+	log.PanicIf(err)
+
+	err = m.WriteCsv(w)
+	log.PanicIf(err)
+
+	return nil
+}
+
 type CodecA20CSpatialFrequencyResponse struct {
 }
 
 func (CodecA20CSpatialFrequencyResponse) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -81,7 +118,7 @@ func (CodecA20CSpatialFrequencyResponse) Encode(value interface{}, byteOrder bin
 func (CodecA20CSpatialFrequencyResponse) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -92,10 +129,16 @@ func (CodecA20CSpatialFrequencyResponse) Decode(valueContext *exifcommon.ValueCo
 	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
 
 	valueBytes, err := valueContext.ReadBytes()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	sfr := TagA20CSpatialFrequencyResponse{}
 
+	if len(valueBytes) < 4 {
+		return nil, newTruncatedError(valueContext, 4, len(valueBytes))
+	}
+
 	sfr.Columns = byteOrder.Uint16(valueBytes[0:2])
 	sfr.Rows = byteOrder.Uint16(valueBytes[2:4])
 
@@ -110,6 +153,10 @@ func (CodecA20CSpatialFrequencyResponse) Decode(valueContext *exifcommon.ValueCo
 	currentColumnNumber := uint16(0)
 
 	for currentColumnNumber < sfr.Columns {
+		if offset >= len(valueBytes) {
+			return nil, newTruncatedError(valueContext, offset+1, len(valueBytes))
+		}
+
 		if valueBytes[offset] == 0 {
 			columnName := string(valueBytes[startAt:offset])
 			if len(columnName) == 0 {
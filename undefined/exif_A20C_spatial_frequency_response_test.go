@@ -2,6 +2,7 @@ package exifundefined
 
 import (
 	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -102,3 +103,58 @@ func TestCodecA20CSpatialFrequencyResponse_Decode(t *testing.T) {
 		t.Fatalf("Decoded struct not correct.")
 	}
 }
+
+func TestCodecA20CSpatialFrequencyResponse_Decode_Truncated(t *testing.T) {
+	// The second column name is never terminated.
+	encoded := []byte{
+		0x00, 0x02,
+		0x00, 0x09,
+		0x63, 0x6f, 0x6c, 0x31, 0x00,
+		0x63, 0x6f, 0x6c, 0x32,
+	}
+
+	valueContext := exifcommon.NewValueContext(
+		"IFD/Exif",
+		0xa20c,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		exifcommon.TestDefaultByteOrder)
+
+	codec := CodecA20CSpatialFrequencyResponse{}
+
+	_, err := codec.Decode(valueContext)
+	if errors.Is(err, exifcommon.ErrNotEnoughData) == false {
+		t.Fatalf("Expected truncated error: %v", err)
+	}
+}
+
+func TestTagA20CSpatialFrequencyResponse_Matrix(t *testing.T) {
+	ut := TagA20CSpatialFrequencyResponse{
+		Columns:     3,
+		Rows:        1,
+		ColumnNames: []string{"Frequency", "H", "V"},
+		Values: []exifcommon.Rational{
+			{Numerator: 1, Denominator: 4}, {Numerator: 9, Denominator: 10}, {Numerator: 8, Denominator: 10},
+		},
+	}
+
+	m, err := ut.Matrix()
+	log.PanicIf(err)
+
+	values, found := m.Column("H")
+	if found != true || len(values) != 1 || values[0] != 0.9 {
+		t.Fatalf("Column not correct: %v", values)
+	}
+
+	b := new(bytes.Buffer)
+
+	err = ut.WriteCsv(b)
+	log.PanicIf(err)
+
+	if b.String() != "Frequency,H,V\n0.25,0.9,0.8\n" {
+		t.Fatalf("CSV not correct: [%s]", b.String())
+	}
+}
@@ -33,7 +33,7 @@ type CodecExifA300FileSource struct {
 func (CodecExifA300FileSource) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -55,14 +55,20 @@ func (CodecExifA300FileSource) Encode(value interface{}, byteOrder binary.ByteOr
 func (CodecExifA300FileSource) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeLong)
 
 	valueLongs, err := valueContext.ReadLongs()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valueLongs) == 0 {
+		return nil, newTruncatedError(valueContext, 4, 0)
+	}
 
 	return TagExifA300FileSource(valueLongs[0]), nil
 }
@@ -30,7 +30,7 @@ type CodecExifA301SceneType struct {
 func (CodecExifA301SceneType) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -52,14 +52,20 @@ func (CodecExifA301SceneType) Encode(value interface{}, byteOrder binary.ByteOrd
 func (CodecExifA301SceneType) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeLong)
 
 	valueLongs, err := valueContext.ReadLongs()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valueLongs) == 0 {
+		return nil, newTruncatedError(valueContext, 4, 0)
+	}
 
 	return TagExifA301SceneType(valueLongs[0]), nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"encoding/binary"
+	"io"
 
 	"github.com/dsoprea/go-logging"
 
@@ -25,13 +26,45 @@ func (cp TagA302CfaPattern) String() string {
 	return fmt.Sprintf("TagA302CfaPattern<HORZ-REPEAT=(%d) VERT-REPEAT=(%d) CFA-VALUE=(%d)>", cp.HorizontalRepeat, cp.VerticalRepeat, len(cp.CfaValue))
 }
 
+// Grid returns the pattern as rows of colors.
+func (cp TagA302CfaPattern) Grid() (cg CfaGrid, err error) {
+	return NewCfaGrid(int(cp.HorizontalRepeat), int(cp.VerticalRepeat), cp.CfaValue)
+}
+
+// Layout returns the colors in row order as one string, e.g. "RGGB".
+func (cp TagA302CfaPattern) Layout() (layout string, err error) {
+	cg, err := cp.Grid()
+	if err != nil {
+		return "", err
+	}
+
+	return cg.Layout(), nil
+}
+
+// WriteCsv writes the grid as CSV.
+func (cp TagA302CfaPattern) WriteCsv(w io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	cg, err := cp.Grid()
+	log.PanicIf(err)
+
+	err = cg.WriteCsv(w)
+	log.PanicIf(err)
+
+	return nil
+}
+
 type CodecA302CfaPattern struct {
 }
 
 func (CodecA302CfaPattern) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -63,7 +96,7 @@ func (CodecA302CfaPattern) Encode(value interface{}, byteOrder binary.ByteOrder)
 func (CodecA302CfaPattern) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -72,14 +105,24 @@ func (CodecA302CfaPattern) Decode(valueContext *exifcommon.ValueContext) (value
 	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
 
 	valueBytes, err := valueContext.ReadBytes()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valueBytes) < 4 {
+		return nil, newTruncatedError(valueContext, 4, len(valueBytes))
+	}
 
 	cp := TagA302CfaPattern{}
 
 	cp.HorizontalRepeat = valueContext.ByteOrder().Uint16(valueBytes[0:2])
 	cp.VerticalRepeat = valueContext.ByteOrder().Uint16(valueBytes[2:4])
 
-	expectedLength := int(cp.HorizontalRepeat * cp.VerticalRepeat)
+	expectedLength := int(cp.HorizontalRepeat) * int(cp.VerticalRepeat)
+	if len(valueBytes) < 4+expectedLength {
+		return nil, newTruncatedError(valueContext, 4+expectedLength, len(valueBytes))
+	}
+
 	cp.CfaValue = valueBytes[4 : 4+expectedLength]
 
 	return cp, nil
@@ -2,6 +2,7 @@ package exifundefined
 
 import (
 	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -92,3 +93,66 @@ func TestCodecA302CfaPattern_Decode(t *testing.T) {
 		t.Fatalf("Decoded value not correct: %s", value)
 	}
 }
+
+func TestCodecA302CfaPattern_Decode_Truncated(t *testing.T) {
+	// Claims a 3x3 pattern but only has six values.
+	encoded := []byte{
+		0x00, 0x03,
+		0x00, 0x03,
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05,
+	}
+
+	valueContext := exifcommon.NewValueContext(
+		"IFD/Exif",
+		0xa302,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		exifcommon.TestDefaultByteOrder)
+
+	codec := CodecA302CfaPattern{}
+
+	_, err := codec.Decode(valueContext)
+
+	te, ok := err.(*exifcommon.TruncatedError)
+	if ok == false {
+		t.Fatalf("Expected truncated error: %v", err)
+	} else if errors.Is(err, exifcommon.ErrNotEnoughData) == false {
+		t.Fatalf("Truncated error does not wrap ErrNotEnoughData.")
+	} else if te.IfdPath != "IFD/Exif" || te.TagId != 0xa302 || te.Needed != 13 || te.Available != 10 {
+		t.Fatalf("Truncated error not correct: %v", te)
+	}
+}
+
+func TestTagA302CfaPattern_Grid(t *testing.T) {
+	ut := TagA302CfaPattern{
+		HorizontalRepeat: 2,
+		VerticalRepeat:   2,
+		CfaValue:         []byte{0, 1, 1, 2},
+	}
+
+	layout, err := ut.Layout()
+	log.PanicIf(err)
+
+	if layout != "RGGB" {
+		t.Fatalf("Layout not correct: [%s]", layout)
+	}
+
+	b := new(bytes.Buffer)
+
+	err = ut.WriteCsv(b)
+	log.PanicIf(err)
+
+	if b.String() != "R,G\nG,B\n" {
+		t.Fatalf("CSV not correct: [%s]", b.String())
+	}
+
+	ut.CfaValue = ut.CfaValue[:3]
+
+	_, err = ut.Grid()
+	if err != ErrMatrixNotValid {
+		t.Fatalf("Expected ErrMatrixNotValid: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package exifundefined
+
+import (
+	"bytes"
+	"fmt"
+
+	"encoding/binary"
+	"unicode/utf16"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+// TagExifA40BDeviceSettingDescription describes the picture-taking conditions
+// of a particular camera model. It's a table of strings (`Columns` x `Rows`),
+// stored as NUL-terminated UCS-2 in the byte-order of the EXIF data.
+type TagExifA40BDeviceSettingDescription struct {
+	Columns  uint16
+	Rows     uint16
+	Settings []string
+}
+
+func (dsd TagExifA40BDeviceSettingDescription) String() string {
+	return fmt.Sprintf("TagExifA40BDeviceSettingDescription<COLUMNS=(%d) ROWS=(%d) SETTINGS=%q>", dsd.Columns, dsd.Rows, dsd.Settings)
+}
+
+func (dsd TagExifA40BDeviceSettingDescription) EncoderName() string {
+	return "CodecExifA40BDeviceSettingDescription"
+}
+
+type CodecExifA40BDeviceSettingDescription struct {
+}
+
+func (CodecExifA40BDeviceSettingDescription) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	dsd, ok := value.(TagExifA40BDeviceSettingDescription)
+	if ok == false {
+		log.Panicf("can only encode a TagExifA40BDeviceSettingDescription")
+	}
+
+	b := new(bytes.Buffer)
+
+	err = binary.Write(b, byteOrder, dsd.Columns)
+	log.PanicIf(err)
+
+	err = binary.Write(b, byteOrder, dsd.Rows)
+	log.PanicIf(err)
+
+	for _, setting := range dsd.Settings {
+		units := utf16.Encode([]rune(setting))
+		units = append(units, 0)
+
+		err := binary.Write(b, byteOrder, units)
+		log.PanicIf(err)
+	}
+
+	return b.Bytes(), uint32(b.Len()), nil
+}
+
+func (CodecExifA40BDeviceSettingDescription) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
+
+	valueBytes, err := valueContext.ReadBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valueBytes) < 4 {
+		return nil, newTruncatedError(valueContext, 4, len(valueBytes))
+	}
+
+	byteOrder := valueContext.ByteOrder()
+
+	dsd := TagExifA40BDeviceSettingDescription{
+		Columns:  byteOrder.Uint16(valueBytes[0:2]),
+		Rows:     byteOrder.Uint16(valueBytes[2:4]),
+		Settings: make([]string, 0),
+	}
+
+	// Each setting ends with a NUL. We tolerate a missing NUL on the last one
+	// and ignore a trailing odd byte.
+
+	units := make([]uint16, 0)
+	for i := 4; i+1 < len(valueBytes); i += 2 {
+		unit := byteOrder.Uint16(valueBytes[i:])
+		if unit == 0 {
+			dsd.Settings = append(dsd.Settings, string(utf16.Decode(units)))
+			units = units[:0]
+
+			continue
+		}
+
+		units = append(units, unit)
+	}
+
+	if len(units) > 0 {
+		dsd.Settings = append(dsd.Settings, string(utf16.Decode(units)))
+	}
+
+	return dsd, nil
+}
+
+func init() {
+	registerEncoder(
+		TagExifA40BDeviceSettingDescription{},
+		CodecExifA40BDeviceSettingDescription{})
+
+	registerDecoder(
+		exifcommon.IfdExifStandardIfdIdentity.UnindexedString(),
+		0xa40b,
+		CodecExifA40BDeviceSettingDescription{})
+}
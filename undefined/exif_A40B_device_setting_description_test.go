@@ -0,0 +1,109 @@
+package exifundefined
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+func TestTagExifA40BDeviceSettingDescription_String(t *testing.T) {
+	ut := TagExifA40BDeviceSettingDescription{
+		Columns:  1,
+		Rows:     2,
+		Settings: []string{"aa", "bb"},
+	}
+
+	s := ut.String()
+	if s != "TagExifA40BDeviceSettingDescription<COLUMNS=(1) ROWS=(2) SETTINGS=[\"aa\" \"bb\"]>" {
+		t.Fatalf("String not correct: [%s]", s)
+	}
+}
+
+func TestCodecExifA40BDeviceSettingDescription_Encode(t *testing.T) {
+	ut := TagExifA40BDeviceSettingDescription{
+		Columns:  1,
+		Rows:     2,
+		Settings: []string{"a", "é"},
+	}
+
+	codec := CodecExifA40BDeviceSettingDescription{}
+
+	encoded, unitCount, err := codec.Encode(ut, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	expectedEncoded := []byte{
+		0x00, 0x01,
+		0x00, 0x02,
+		0x00, 0x61, 0x00, 0x00,
+		0x00, 0xe9, 0x00, 0x00,
+	}
+
+	if bytes.Equal(encoded, expectedEncoded) != true {
+		exifcommon.DumpBytesClause(encoded)
+
+		t.Fatalf("Encoding not correct.")
+	} else if unitCount != uint32(len(expectedEncoded)) {
+		t.Fatalf("Unit-count not correct: (%d)", unitCount)
+	}
+}
+
+func TestCodecExifA40BDeviceSettingDescription_Decode(t *testing.T) {
+	encoded := []byte{
+		0x01, 0x00,
+		0x02, 0x00,
+		0x61, 0x00, 0x00, 0x00,
+		0xe9, 0x00,
+	}
+
+	valueContext := exifcommon.NewValueContext(
+		"",
+		0,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		binary.LittleEndian)
+
+	codec := CodecExifA40BDeviceSettingDescription{}
+
+	decoded, err := codec.Decode(valueContext)
+	log.PanicIf(err)
+
+	expectedUt := TagExifA40BDeviceSettingDescription{
+		Columns:  1,
+		Rows:     2,
+		Settings: []string{"a", "é"},
+	}
+
+	if reflect.DeepEqual(decoded, expectedUt) != true {
+		t.Fatalf("Decoded struct not correct: %v", decoded)
+	}
+}
+
+func TestCodecExifA40BDeviceSettingDescription_Decode_Truncated(t *testing.T) {
+	encoded := []byte{0x00, 0x01}
+
+	valueContext := exifcommon.NewValueContext(
+		"",
+		0,
+		uint32(len(encoded)),
+		0,
+		encoded,
+		nil,
+		exifcommon.TypeUndefined,
+		exifcommon.TestDefaultByteOrder)
+
+	codec := CodecExifA40BDeviceSettingDescription{}
+
+	_, err := codec.Decode(valueContext)
+	if err == nil {
+		t.Fatalf("Expected error for truncated value.")
+	}
+}
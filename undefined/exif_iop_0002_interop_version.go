@@ -26,7 +26,7 @@ type Codec0002InteropVersion struct {
 func (Codec0002InteropVersion) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -41,14 +41,16 @@ func (Codec0002InteropVersion) Encode(value interface{}, byteOrder binary.ByteOr
 func (Codec0002InteropVersion) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeAsciiNoNul)
 
 	valueString, err := valueContext.ReadAsciiNoNul()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	iv := Tag0002InteropVersion{
 		InteropVersion: valueString,
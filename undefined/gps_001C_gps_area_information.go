@@ -26,7 +26,7 @@ type Codec001CGPSAreaInformation struct {
 func (Codec001CGPSAreaInformation) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -41,14 +41,16 @@ func (Codec001CGPSAreaInformation) Encode(value interface{}, byteOrder binary.By
 func (Codec001CGPSAreaInformation) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
 	valueContext.SetUndefinedValueType(exifcommon.TypeAsciiNoNul)
 
 	valueString, err := valueContext.ReadAsciiNoNul()
-	log.PanicIf(err)
+	if err != nil {
+		return nil, err
+	}
 
 	return Tag001CGPSAreaInformation{valueString}, nil
 }
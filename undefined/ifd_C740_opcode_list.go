@@ -0,0 +1,465 @@
+package exifundefined
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"encoding/binary"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+var (
+	opcodeListLogger = log.NewLogger("exifundefined.ifd_C740_opcode_list")
+)
+
+// The DNG opcode lists (OpcodeList1, OpcodeList2, and OpcodeList3) are always
+// big-endian, regardless of the byte-order of the EXIF data.
+
+var (
+	// ErrOpcodeNotValid means that the parameters of an opcode do not have
+	// the structure that the opcode requires.
+	ErrOpcodeNotValid = errors.New("opcode not valid")
+)
+
+// OpcodeId identifies a DNG opcode.
+type OpcodeId uint32
+
+const (
+	OpcodeWarpRectilinear      OpcodeId = 1
+	OpcodeWarpFisheye          OpcodeId = 2
+	OpcodeFixVignetteRadial    OpcodeId = 3
+	OpcodeFixBadPixelsConstant OpcodeId = 4
+	OpcodeFixBadPixelsList     OpcodeId = 5
+	OpcodeTrimBounds           OpcodeId = 6
+	OpcodeMapTable             OpcodeId = 7
+	OpcodeMapPolynomial        OpcodeId = 8
+	OpcodeGainMap              OpcodeId = 9
+	OpcodeDeltaPerRow          OpcodeId = 10
+	OpcodeDeltaPerColumn       OpcodeId = 11
+	OpcodeScalePerRow          OpcodeId = 12
+	OpcodeScalePerColumn       OpcodeId = 13
+	OpcodeWarpRectilinear2     OpcodeId = 14
+)
+
+var (
+	opcodeNames = map[OpcodeId]string{
+		OpcodeWarpRectilinear:      "WarpRectilinear",
+		OpcodeWarpFisheye:          "WarpFisheye",
+		OpcodeFixVignetteRadial:    "FixVignetteRadial",
+		OpcodeFixBadPixelsConstant: "FixBadPixelsConstant",
+		OpcodeFixBadPixelsList:     "FixBadPixelsList",
+		OpcodeTrimBounds:           "TrimBounds",
+		OpcodeMapTable:             "MapTable",
+		OpcodeMapPolynomial:        "MapPolynomial",
+		OpcodeGainMap:              "GainMap",
+		OpcodeDeltaPerRow:          "DeltaPerRow",
+		OpcodeDeltaPerColumn:       "DeltaPerColumn",
+		OpcodeScalePerRow:          "ScalePerRow",
+		OpcodeScalePerColumn:       "ScalePerColumn",
+		OpcodeWarpRectilinear2:     "WarpRectilinear2",
+	}
+)
+
+// String returns the name of the opcode (e.g. "GainMap"). Opcodes that are
+// not defined are returned as a number.
+func (oi OpcodeId) String() string {
+	if name, found := opcodeNames[oi]; found == true {
+		return name
+	}
+
+	return fmt.Sprintf("Opcode(%d)", uint32(oi))
+}
+
+const (
+	// OpcodeFlagOptional means that a reader that does not support the
+	// opcode may skip it.
+	OpcodeFlagOptional = 1
+
+	// OpcodeFlagPreviewSkippable means that the opcode may be skipped when
+	// rendering a preview.
+	OpcodeFlagPreviewSkippable = 2
+)
+
+// Opcode is one entry of an opcode list.
+type Opcode struct {
+	Id OpcodeId
+
+	// DngVersion is the earliest DNG version that supports the opcode.
+	DngVersion [4]byte
+
+	Flags uint32
+
+	// Parameters are the encoded parameters. These are what is written when
+	// the list is encoded.
+	Parameters []byte
+
+	// Record is the decoded parameters for the opcodes that we understand
+	// (`WarpRectilinear`, `FixVignetteRadial`, and `GainMap`). It's nil for
+	// the others and for those whose parameters are not valid.
+	Record interface{}
+
+	// RecordError is why the parameters of an opcode that we understand
+	// could not be decoded. The opcode is still kept (and encoded) as it is.
+	RecordError error
+}
+
+// IsOptional returns true if the opcode may be skipped by a reader that does
+// not support it.
+func (o Opcode) IsOptional() bool {
+	return o.Flags&OpcodeFlagOptional != 0
+}
+
+// IsPreviewSkippable returns true if the opcode may be skipped when rendering
+// a preview.
+func (o Opcode) IsPreviewSkippable() bool {
+	return o.Flags&OpcodeFlagPreviewSkippable != 0
+}
+
+// String returns a descriptive string.
+func (o Opcode) String() string {
+	return fmt.Sprintf("Opcode<ID=[%s] DNG-VERSION=[%d.%d.%d.%d] FLAGS=(%d) PARAMETERS=(%d)>", o.Id, o.DngVersion[0], o.DngVersion[1], o.DngVersion[2], o.DngVersion[3], o.Flags, len(o.Parameters))
+}
+
+// WarpRectilinearPlane has the radial and tangential distortion coefficients
+// of one plane.
+type WarpRectilinearPlane struct {
+	Kr [4]float64
+	Kt [2]float64
+}
+
+// WarpRectilinear corrects the geometric distortion and lateral chromatic
+// aberration of a rectilinear lens. The center is relative to the image, from
+// (0, 0) (top-left) to (1, 1) (bottom-right).
+type WarpRectilinear struct {
+	Planes []WarpRectilinearPlane
+
+	CenterX float64
+	CenterY float64
+}
+
+// FixVignetteRadial corrects radially-symmetric vignetting. The gain is
+// 1 + k0*r^2 + k1*r^4 + k2*r^6 + k3*r^8 + k4*r^10.
+type FixVignetteRadial struct {
+	K [5]float64
+
+	CenterX float64
+	CenterY float64
+}
+
+// Gain returns the gain at the given normalized distance from the center.
+func (fvr FixVignetteRadial) Gain(r float64) float64 {
+	r2 := r * r
+
+	gain := 1.0
+	term := 1.0
+	for _, k := range fvr.K {
+		term *= r2
+		gain += k * term
+	}
+
+	return gain
+}
+
+// GainMap is a two-dimensional map of gains that is applied to an area of the
+// image.
+type GainMap struct {
+	Top    uint32
+	Left   uint32
+	Bottom uint32
+	Right  uint32
+
+	Plane  uint32
+	Planes uint32
+
+	RowPitch    uint32
+	ColumnPitch uint32
+
+	MapPointsV uint32
+	MapPointsH uint32
+
+	MapSpacingV float64
+	MapSpacingH float64
+	MapOriginV  float64
+	MapOriginH  float64
+
+	MapPlanes uint32
+
+	// Gains are ordered by row, then column, then plane.
+	Gains []float32
+}
+
+// Gain returns the gain of the given map point.
+func (gm GainMap) Gain(row, column, plane int) float32 {
+	i := (row*int(gm.MapPointsH)+column)*int(gm.MapPlanes) + plane
+	return gm.Gains[i]
+}
+
+// opcodeReader reads big-endian values and panics with `ErrOpcodeNotValid` if
+// there are not enough bytes.
+type opcodeReader struct {
+	data   []byte
+	offset int
+}
+
+func (or *opcodeReader) next(size int) []byte {
+	if or.offset+size > len(or.data) {
+		log.Panic(ErrOpcodeNotValid)
+	}
+
+	b := or.data[or.offset : or.offset+size]
+	or.offset += size
+
+	return b
+}
+
+func (or *opcodeReader) uint32() uint32 {
+	return binary.BigEndian.Uint32(or.next(4))
+}
+
+func (or *opcodeReader) float32() float32 {
+	return math.Float32frombits(or.uint32())
+}
+
+func (or *opcodeReader) float64() float64 {
+	return math.Float64frombits(binary.BigEndian.Uint64(or.next(8)))
+}
+
+// parseOpcodeRecord decodes the parameters of the opcodes that we understand.
+// Returns nil for the others.
+func parseOpcodeRecord(id OpcodeId, parameters []byte) (record interface{}, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	or := &opcodeReader{
+		data: parameters,
+	}
+
+	switch id {
+	case OpcodeWarpRectilinear:
+		planeCount := or.uint32()
+
+		// Don't allocate anything based on the count until we know that the
+		// data is there.
+		if uint64(planeCount)*6*8+2*8 != uint64(len(parameters)-4) {
+			log.Panic(ErrOpcodeNotValid)
+		}
+
+		wr := WarpRectilinear{
+			Planes: make([]WarpRectilinearPlane, planeCount),
+		}
+
+		for i := range wr.Planes {
+			plane := &wr.Planes[i]
+
+			for j := range plane.Kr {
+				plane.Kr[j] = or.float64()
+			}
+
+			for j := range plane.Kt {
+				plane.Kt[j] = or.float64()
+			}
+		}
+
+		wr.CenterX = or.float64()
+		wr.CenterY = or.float64()
+
+		return wr, nil
+	case OpcodeFixVignetteRadial:
+		if len(parameters) != 7*8 {
+			log.Panic(ErrOpcodeNotValid)
+		}
+
+		fvr := FixVignetteRadial{}
+
+		for i := range fvr.K {
+			fvr.K[i] = or.float64()
+		}
+
+		fvr.CenterX = or.float64()
+		fvr.CenterY = or.float64()
+
+		return fvr, nil
+	case OpcodeGainMap:
+		gm := GainMap{
+			Top:         or.uint32(),
+			Left:        or.uint32(),
+			Bottom:      or.uint32(),
+			Right:       or.uint32(),
+			Plane:       or.uint32(),
+			Planes:      or.uint32(),
+			RowPitch:    or.uint32(),
+			ColumnPitch: or.uint32(),
+			MapPointsV:  or.uint32(),
+			MapPointsH:  or.uint32(),
+			MapSpacingV: or.float64(),
+			MapSpacingH: or.float64(),
+			MapOriginV:  or.float64(),
+			MapOriginH:  or.float64(),
+			MapPlanes:   or.uint32(),
+		}
+
+		// The product of the three counts can overflow, so compare against
+		// the number of gains that the remaining bytes can actually hold.
+		remaining := uint64(len(parameters) - or.offset)
+		pointCount := uint64(gm.MapPointsV) * uint64(gm.MapPointsH)
+
+		if gm.MapPlanes != 0 && pointCount > remaining/4/uint64(gm.MapPlanes) {
+			log.Panic(ErrOpcodeNotValid)
+		}
+
+		gainCount := pointCount * uint64(gm.MapPlanes)
+		if gainCount*4 != remaining {
+			log.Panic(ErrOpcodeNotValid)
+		}
+
+		gm.Gains = make([]float32, gainCount)
+		for i := range gm.Gains {
+			gm.Gains[i] = or.float32()
+		}
+
+		return gm, nil
+	}
+
+	return nil, nil
+}
+
+// TagIfdOpcodeList is a list of processing steps that are applied to the raw
+// image data (0xc740, 0xc741, and 0xc74e).
+type TagIfdOpcodeList struct {
+	Opcodes []Opcode
+}
+
+func (ol TagIfdOpcodeList) String() string {
+	names := make([]string, len(ol.Opcodes))
+	for i, o := range ol.Opcodes {
+		names[i] = o.Id.String()
+	}
+
+	return fmt.Sprintf("TagIfdOpcodeList<COUNT=(%d) OPCODES=[%s]>", len(ol.Opcodes), strings.Join(names, ", "))
+}
+
+func (ol TagIfdOpcodeList) EncoderName() string {
+	return "CodecIfdOpcodeList"
+}
+
+type CodecIfdOpcodeList struct {
+}
+
+func (CodecIfdOpcodeList) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	ol, ok := value.(TagIfdOpcodeList)
+	if ok == false {
+		log.Panicf("can only encode a TagIfdOpcodeList")
+	}
+
+	b := new(bytes.Buffer)
+
+	err = binary.Write(b, binary.BigEndian, uint32(len(ol.Opcodes)))
+	log.PanicIf(err)
+
+	for _, o := range ol.Opcodes {
+		header := []uint32{
+			uint32(o.Id),
+			binary.BigEndian.Uint32(o.DngVersion[:]),
+			o.Flags,
+			uint32(len(o.Parameters)),
+		}
+
+		err := binary.Write(b, binary.BigEndian, header)
+		log.PanicIf(err)
+
+		_, err = b.Write(o.Parameters)
+		log.PanicIf(err)
+	}
+
+	return b.Bytes(), uint32(b.Len()), nil
+}
+
+func (CodecIfdOpcodeList) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	valueContext.SetUndefinedValueType(exifcommon.TypeByte)
+
+	valueBytes, err := valueContext.ReadBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valueBytes) < 4 {
+		return nil, newTruncatedError(valueContext, 4, len(valueBytes))
+	}
+
+	count := binary.BigEndian.Uint32(valueBytes[0:4])
+
+	ol := TagIfdOpcodeList{
+		Opcodes: make([]Opcode, 0),
+	}
+
+	offset := 4
+	for i := uint32(0); i < count; i++ {
+		if offset+16 > len(valueBytes) {
+			return nil, newTruncatedError(valueContext, offset+16, len(valueBytes))
+		}
+
+		o := Opcode{
+			Id:    OpcodeId(binary.BigEndian.Uint32(valueBytes[offset:])),
+			Flags: binary.BigEndian.Uint32(valueBytes[offset+8:]),
+		}
+
+		copy(o.DngVersion[:], valueBytes[offset+4:offset+8])
+
+		parametersSize := int(binary.BigEndian.Uint32(valueBytes[offset+12:]))
+		offset += 16
+
+		if parametersSize < 0 || offset+parametersSize > len(valueBytes) {
+			return nil, newTruncatedError(valueContext, offset+parametersSize, len(valueBytes))
+		}
+
+		o.Parameters = valueBytes[offset : offset+parametersSize]
+		offset += parametersSize
+
+		// A bad record doesn't invalidate the rest of the list.
+		o.Record, err = parseOpcodeRecord(o.Id, o.Parameters)
+		if err != nil {
+			opcodeListLogger.Warningf(nil, "Parameters of opcode [%s] (%d) not valid: %s", o.Id, i, err.Error())
+
+			o.Record = nil
+			o.RecordError = err
+		}
+
+		ol.Opcodes = append(ol.Opcodes, o)
+	}
+
+	return ol, nil
+}
+
+func init() {
+	registerEncoder(
+		TagIfdOpcodeList{},
+		CodecIfdOpcodeList{})
+
+	for _, tagId := range []uint16{0xc740, 0xc741, 0xc74e} {
+		registerDecoder(
+			exifcommon.IfdStandardIfdIdentity.UnindexedString(),
+			tagId,
+			CodecIfdOpcodeList{})
+	}
+}
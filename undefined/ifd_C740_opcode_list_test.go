@@ -0,0 +1,289 @@
+package exifundefined
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/binary"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+func getTestOpcodeParameters(values ...interface{}) []byte {
+	b := new(bytes.Buffer)
+
+	for _, value := range values {
+		err := binary.Write(b, binary.BigEndian, value)
+		log.PanicIf(err)
+	}
+
+	return b.Bytes()
+}
+
+func getTestOpcodeList() TagIfdOpcodeList {
+	warpRectilinear := getTestOpcodeParameters(
+		uint32(1),
+		[]float64{1, 0.01, -0.02, 0.003, 0.0001, -0.0002},
+		[]float64{0.5, 0.5})
+
+	fixVignetteRadial := getTestOpcodeParameters(
+		[]float64{0.1, 0.2, 0.3, 0.4, 0.5},
+		[]float64{0.5, 0.5})
+
+	gainMap := getTestOpcodeParameters(
+		[]uint32{0, 0, 100, 200, 0, 1, 1, 1, 2, 2},
+		[]float64{1, 1, 0, 0},
+		uint32(1),
+		[]float32{1, 1.25, 1.5, 2})
+
+	return TagIfdOpcodeList{
+		Opcodes: []Opcode{
+			{
+				Id:         OpcodeWarpRectilinear,
+				DngVersion: [4]byte{1, 3, 0, 0},
+				Flags:      0,
+				Parameters: warpRectilinear,
+				Record: WarpRectilinear{
+					Planes: []WarpRectilinearPlane{
+						{
+							Kr: [4]float64{1, 0.01, -0.02, 0.003},
+							Kt: [2]float64{0.0001, -0.0002},
+						},
+					},
+					CenterX: 0.5,
+					CenterY: 0.5,
+				},
+			},
+			{
+				Id:         OpcodeFixVignetteRadial,
+				DngVersion: [4]byte{1, 3, 0, 0},
+				Flags:      OpcodeFlagOptional,
+				Parameters: fixVignetteRadial,
+				Record: FixVignetteRadial{
+					K:       [5]float64{0.1, 0.2, 0.3, 0.4, 0.5},
+					CenterX: 0.5,
+					CenterY: 0.5,
+				},
+			},
+			{
+				Id:         OpcodeGainMap,
+				DngVersion: [4]byte{1, 3, 0, 0},
+				Flags:      OpcodeFlagOptional | OpcodeFlagPreviewSkippable,
+				Parameters: gainMap,
+				Record: GainMap{
+					Bottom:      100,
+					Right:       200,
+					Planes:      1,
+					RowPitch:    1,
+					ColumnPitch: 1,
+					MapPointsV:  2,
+					MapPointsH:  2,
+					MapSpacingV: 1,
+					MapSpacingH: 1,
+					MapPlanes:   1,
+					Gains:       []float32{1, 1.25, 1.5, 2},
+				},
+			},
+			{
+				Id:         OpcodeTrimBounds,
+				DngVersion: [4]byte{1, 3, 0, 0},
+				Flags:      0,
+				Parameters: getTestOpcodeParameters([]uint32{1, 2, 3, 4}),
+			},
+		},
+	}
+}
+
+func TestOpcodeId_String(t *testing.T) {
+	if OpcodeGainMap.String() != "GainMap" {
+		t.Fatalf("Name not correct: [%s]", OpcodeGainMap.String())
+	} else if OpcodeId(99).String() != "Opcode(99)" {
+		t.Fatalf("Name not correct for undefined opcode: [%s]", OpcodeId(99).String())
+	}
+}
+
+func TestOpcode_Flags(t *testing.T) {
+	o := Opcode{
+		Flags: OpcodeFlagPreviewSkippable,
+	}
+
+	if o.IsOptional() != false {
+		t.Fatalf("Opcode should not be optional.")
+	} else if o.IsPreviewSkippable() != true {
+		t.Fatalf("Opcode should be preview-skippable.")
+	}
+}
+
+func TestFixVignetteRadial_Gain(t *testing.T) {
+	fvr := FixVignetteRadial{
+		K: [5]float64{1, 1, 0, 0, 0},
+	}
+
+	if fvr.Gain(0) != 1 {
+		t.Fatalf("Gain at center not correct: (%f)", fvr.Gain(0))
+	} else if fvr.Gain(1) != 3 {
+		t.Fatalf("Gain at edge not correct: (%f)", fvr.Gain(1))
+	}
+}
+
+func TestGainMap_Gain(t *testing.T) {
+	gm := getTestOpcodeList().Opcodes[2].Record.(GainMap)
+
+	if gm.Gain(0, 1, 0) != 1.25 {
+		t.Fatalf("Gain not correct: (%f)", gm.Gain(0, 1, 0))
+	} else if gm.Gain(1, 0, 0) != 1.5 {
+		t.Fatalf("Gain not correct: (%f)", gm.Gain(1, 0, 0))
+	}
+}
+
+func TestTagIfdOpcodeList_String(t *testing.T) {
+	s := getTestOpcodeList().String()
+	if s != "TagIfdOpcodeList<COUNT=(4) OPCODES=[WarpRectilinear, FixVignetteRadial, GainMap, TrimBounds]>" {
+		t.Fatalf("String not correct: [%s]", s)
+	}
+}
+
+func TestCodecIfdOpcodeList_Encode_Decode(t *testing.T) {
+	ol := getTestOpcodeList()
+
+	codec := CodecIfdOpcodeList{}
+
+	// The list is always big-endian, regardless of what we're given.
+	encoded, unitCount, err := codec.Encode(ol, binary.LittleEndian)
+	log.PanicIf(err)
+
+	if bytes.Equal(encoded[:4], []byte{0, 0, 0, 4}) != true {
+		t.Fatalf("Count not encoded correctly: %v", encoded[:4])
+	} else if unitCount != uint32(len(encoded)) {
+		t.Fatalf("Unit-count not correct: (%d)", unitCount)
+	}
+
+	valueContext := exifcommon.NewValueContext(
+		"",
+		0,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		binary.LittleEndian)
+
+	decoded, err := codec.Decode(valueContext)
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(decoded, ol) != true {
+		t.Fatalf("Decoded struct not correct: %v", decoded)
+	}
+}
+
+func TestCodecIfdOpcodeList_Decode_Truncated(t *testing.T) {
+	ol := getTestOpcodeList()
+
+	codec := CodecIfdOpcodeList{}
+
+	encoded, _, err := codec.Encode(ol, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	encoded = encoded[:len(encoded)-1]
+
+	valueContext := exifcommon.NewValueContext(
+		"",
+		0,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		exifcommon.TestDefaultByteOrder)
+
+	_, err = codec.Decode(valueContext)
+	if err == nil {
+		t.Fatalf("Expected error for truncated value.")
+	}
+}
+
+func decodeTestOpcodeList(ol TagIfdOpcodeList) TagIfdOpcodeList {
+	codec := CodecIfdOpcodeList{}
+
+	encoded, _, err := codec.Encode(ol, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	valueContext := exifcommon.NewValueContext(
+		"",
+		0,
+		uint32(len(encoded)),
+		0,
+		nil,
+		encoded,
+		exifcommon.TypeUndefined,
+		exifcommon.TestDefaultByteOrder)
+
+	decoded, err := codec.Decode(valueContext)
+	log.PanicIf(err)
+
+	return decoded.(TagIfdOpcodeList)
+}
+
+func TestCodecIfdOpcodeList_Decode_NotValid(t *testing.T) {
+	expected := getTestOpcodeList()
+
+	ol := TagIfdOpcodeList{
+		Opcodes: []Opcode{
+			{
+				Id:         OpcodeFixVignetteRadial,
+				Parameters: getTestOpcodeParameters([]float64{0.1, 0.2}),
+			},
+			expected.Opcodes[0],
+		},
+	}
+
+	decoded := decodeTestOpcodeList(ol)
+
+	if len(decoded.Opcodes) != 2 {
+		t.Fatalf("Opcode count not correct: (%d)", len(decoded.Opcodes))
+	}
+
+	bad := decoded.Opcodes[0]
+	if bad.Record != nil {
+		t.Fatalf("Record not expected for opcode with bad parameters: %v", bad.Record)
+	} else if errors.Is(bad.RecordError, ErrOpcodeNotValid) == false {
+		t.Fatalf("Record error not correct: %v", bad.RecordError)
+	} else if bytes.Equal(bad.Parameters, ol.Opcodes[0].Parameters) != true {
+		t.Fatalf("Parameters not kept: %v", bad.Parameters)
+	}
+
+	if reflect.DeepEqual(decoded.Opcodes[1], expected.Opcodes[0]) != true {
+		t.Fatalf("Opcode after the bad one not correct: %v", decoded.Opcodes[1])
+	}
+}
+
+func TestCodecIfdOpcodeList_Decode_GainMapOverflow(t *testing.T) {
+	// 0x80000000 * 0x80000000 gains of four bytes each is 2^64, which wraps
+	// to zero and would match a map with no gains.
+	gainMap := getTestOpcodeParameters(
+		[]uint32{0, 0, 100, 200, 0, 1, 1, 1, 0x80000000, 0x80000000},
+		[]float64{1, 1, 0, 0},
+		uint32(1))
+
+	ol := TagIfdOpcodeList{
+		Opcodes: []Opcode{
+			{
+				Id:         OpcodeGainMap,
+				Parameters: gainMap,
+			},
+		},
+	}
+
+	decoded := decodeTestOpcodeList(ol)
+
+	o := decoded.Opcodes[0]
+	if o.Record != nil {
+		t.Fatalf("Record not expected for overflowing gain-map: %v", o.Record)
+	} else if errors.Is(o.RecordError, ErrOpcodeNotValid) == false {
+		t.Fatalf("Record error not correct: %v", o.RecordError)
+	}
+}
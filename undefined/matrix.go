@@ -0,0 +1,95 @@
+package exifundefined
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"encoding/csv"
+	"io"
+
+	"github.com/dsoprea/go-logging"
+)
+
+var (
+	// ErrMatrixNotValid means that the number of values does not match the
+	// number of columns and rows.
+	ErrMatrixNotValid = errors.New("matrix not valid")
+)
+
+// Matrix is a table of values with named columns, as stored by the OECF and
+// SpatialFrequencyResponse tags. Values with a zero denominator are NaN.
+type Matrix struct {
+	ColumnNames []string
+	Rows        [][]float64
+}
+
+// newMatrix arranges the values into rows.
+func newMatrix(columnNames []string, rowCount int, values []float64) (m Matrix, err error) {
+	if len(values) != len(columnNames)*rowCount {
+		return m, ErrMatrixNotValid
+	}
+
+	m.ColumnNames = columnNames
+	m.Rows = make([][]float64, rowCount)
+
+	for i := range m.Rows {
+		m.Rows[i] = values[i*len(columnNames) : (i+1)*len(columnNames)]
+	}
+
+	return m, nil
+}
+
+// Column returns the values in the column with the given name.
+func (m Matrix) Column(name string) (values []float64, found bool) {
+	for i, columnName := range m.ColumnNames {
+		if columnName != name {
+			continue
+		}
+
+		values = make([]float64, len(m.Rows))
+		for j, row := range m.Rows {
+			values[j] = row[i]
+		}
+
+		return values, true
+	}
+
+	return nil, false
+}
+
+// WriteCsv writes the column names and then the rows as CSV. NaN values are
+// written as empty fields.
+func (m Matrix) WriteCsv(w io.Writer) (err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	cw := csv.NewWriter(w)
+
+	err = cw.Write(m.ColumnNames)
+	log.PanicIf(err)
+
+	record := make([]string, len(m.ColumnNames))
+	for _, row := range m.Rows {
+		for i, value := range row {
+			if math.IsNaN(value) == true {
+				record[i] = ""
+			} else {
+				record[i] = strconv.FormatFloat(value, 'g', -1, 64)
+			}
+		}
+
+		err := cw.Write(record)
+		log.PanicIf(err)
+	}
+
+	cw.Flush()
+
+	err = cw.Error()
+	log.PanicIf(err)
+
+	return nil
+}
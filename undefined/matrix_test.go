@@ -0,0 +1,57 @@
+package exifundefined
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/dsoprea/go-logging"
+)
+
+func TestNewMatrix(t *testing.T) {
+	m, err := newMatrix([]string{"a", "b"}, 2, []float64{1, 2, 3, 4})
+	log.PanicIf(err)
+
+	expected := [][]float64{{1, 2}, {3, 4}}
+	if reflect.DeepEqual(m.Rows, expected) != true {
+		t.Fatalf("Rows not correct: %v", m.Rows)
+	}
+
+	_, err = newMatrix([]string{"a", "b"}, 2, []float64{1, 2, 3})
+	if err != ErrMatrixNotValid {
+		t.Fatalf("Expected ErrMatrixNotValid: %v", err)
+	}
+}
+
+func TestMatrix_Column(t *testing.T) {
+	m, err := newMatrix([]string{"a", "b"}, 2, []float64{1, 2, 3, 4})
+	log.PanicIf(err)
+
+	values, found := m.Column("b")
+	if found != true {
+		t.Fatalf("Column not found.")
+	} else if reflect.DeepEqual(values, []float64{2, 4}) != true {
+		t.Fatalf("Column not correct: %v", values)
+	}
+
+	_, found = m.Column("c")
+	if found != false {
+		t.Fatalf("Column not expected to be found.")
+	}
+}
+
+func TestMatrix_WriteCsv(t *testing.T) {
+	m, err := newMatrix([]string{"a", "b,c"}, 2, []float64{1, 0.25, math.NaN(), -3})
+	log.PanicIf(err)
+
+	b := new(bytes.Buffer)
+
+	err = m.WriteCsv(b)
+	log.PanicIf(err)
+
+	expected := "a,\"b,c\"\n1,0.25\n,-3\n"
+	if b.String() != expected {
+		t.Fatalf("CSV not correct: [%s]", b.String())
+	}
+}
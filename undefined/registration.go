@@ -1,7 +1,13 @@
 package exifundefined
 
 import (
+	"sync"
+
+	"encoding/binary"
+
 	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
 )
 
 // UndefinedTagHandle defines one undefined-type tag with a corresponding
@@ -11,32 +17,254 @@ type UndefinedTagHandle struct {
 	TagId   uint16
 }
 
+// Registry holds the codecs for undefined-type tags. Decoders are registered
+// per (IFD-path, tag-ID). Encoders are registered either per encoder-name (see
+// `EncodeableValue`) or per (IFD-path, tag-ID), the latter taking precedence.
+// It is safe for concurrent use.
+type Registry struct {
+	mutex       sync.RWMutex
+	readOnly    bool
+	encoders    map[string]UndefinedValueEncoder
+	tagEncoders map[UndefinedTagHandle]UndefinedValueEncoder
+	decoders    map[UndefinedTagHandle]UndefinedValueDecoder
+}
+
+// NewRegistry returns an empty registry. Use `DefaultRegistry().Clone()` to
+// start with the built-in codecs.
+func NewRegistry() *Registry {
+	return &Registry{
+		encoders:    make(map[string]UndefinedValueEncoder),
+		tagEncoders: make(map[UndefinedTagHandle]UndefinedValueEncoder),
+		decoders:    make(map[UndefinedTagHandle]UndefinedValueDecoder),
+	}
+}
+
+// DefaultRegistry returns the registry with the built-in codecs. It's used
+// wherever no other registry was given. It is shared by the whole process and
+// is therefore read-only: registering with it returns `ErrRegistryReadOnly`.
+// Register with `DefaultRegistry().Clone()` and pass that along, instead.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Clone returns an independent, writable copy of the registry.
+func (r *Registry) Clone() *Registry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	clone := NewRegistry()
+
+	for name, encoder := range r.encoders {
+		clone.encoders[name] = encoder
+	}
+
+	for uth, encoder := range r.tagEncoders {
+		clone.tagEncoders[uth] = encoder
+	}
+
+	for uth, decoder := range r.decoders {
+		clone.decoders[uth] = decoder
+	}
+
+	return clone
+}
+
+// RegisterEncoder registers the encoder for the type of the given value,
+// replacing any existing one.
+func (r *Registry) RegisterEncoder(entity EncodeableValue, encoder UndefinedValueEncoder) (err error) {
+	if r.readOnly == true {
+		return ErrRegistryReadOnly
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.encoders[entity.EncoderName()] = encoder
+
+	return nil
+}
+
+// RegisterTagEncoder registers the encoder for the given tag, replacing any
+// existing one. It is used instead of the encoder registered for the type of
+// the value.
+func (r *Registry) RegisterTagEncoder(ifdPath string, tagId uint16, encoder UndefinedValueEncoder) (err error) {
+	if r.readOnly == true {
+		return ErrRegistryReadOnly
+	}
+
+	uth := UndefinedTagHandle{
+		IfdPath: ifdPath,
+		TagId:   tagId,
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.tagEncoders[uth] = encoder
+
+	return nil
+}
+
+// RegisterDecoder registers the decoder for the given tag, replacing any
+// existing one.
+func (r *Registry) RegisterDecoder(ifdPath string, tagId uint16, decoder UndefinedValueDecoder) (err error) {
+	if r.readOnly == true {
+		return ErrRegistryReadOnly
+	}
+
+	uth := UndefinedTagHandle{
+		IfdPath: ifdPath,
+		TagId:   tagId,
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.decoders[uth] = decoder
+
+	return nil
+}
+
+// Decoder returns the decoder for the given tag, if there is one.
+func (r *Registry) Decoder(ifdPath string, tagId uint16) (decoder UndefinedValueDecoder, found bool) {
+	uth := UndefinedTagHandle{
+		IfdPath: ifdPath,
+		TagId:   tagId,
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	decoder, found = r.decoders[uth]
+	return decoder, found
+}
+
+// Encoder returns the encoder with the given name, if there is one.
+func (r *Registry) Encoder(encoderName string) (encoder UndefinedValueEncoder, found bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	encoder, found = r.encoders[encoderName]
+	return encoder, found
+}
+
+// TagEncoder returns the encoder registered for the given tag, if there is
+// one.
+func (r *Registry) TagEncoder(ifdPath string, tagId uint16) (encoder UndefinedValueEncoder, found bool) {
+	uth := UndefinedTagHandle{
+		IfdPath: ifdPath,
+		TagId:   tagId,
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	encoder, found = r.tagEncoders[uth]
+	return encoder, found
+}
+
+// EncodeTag encodes the given value of the given tag to bytes. The encoder
+// registered for the tag is used if there is one, otherwise the one registered
+// for the type of the value.
+func (r *Registry) EncodeTag(ifdPath string, tagId uint16, value EncodeableValue, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	encoder, found := r.TagEncoder(ifdPath, tagId)
+	if found == false {
+		encoded, unitCount, err = r.Encode(value, byteOrder)
+		log.PanicIf(err)
+
+		return encoded, unitCount, nil
+	}
+
+	encoded, unitCount, err = encoder.Encode(value, byteOrder)
+	log.PanicIf(err)
+
+	return encoded, unitCount, nil
+}
+
+// Encode encodes the given encodeable undefined value to bytes.
+func (r *Registry) Encode(value EncodeableValue, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	encoderName := value.EncoderName()
+
+	encoder, found := r.Encoder(encoderName)
+	if found == false {
+		log.Panicf("no encoder registered for type [%s]", encoderName)
+	}
+
+	encoded, unitCount, err = encoder.Encode(value, byteOrder)
+	log.PanicIf(err)
+
+	return encoded, unitCount, nil
+}
+
+// Decode constructs a value from raw encoded bytes.
+func (r *Registry) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	decoder, found := r.Decoder(valueContext.IfdPath(), valueContext.TagId())
+	if found == false {
+		// We have no choice but to return the error. We have no way of knowing how
+		// much data there is without already knowing what data-type this tag is.
+		return nil, exifcommon.ErrUnhandledUndefinedTypedTag
+	}
+
+	value, err = decoder.Decode(valueContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// registerEncoder registers a built-in encoder.
 func registerEncoder(entity EncodeableValue, encoder UndefinedValueEncoder) {
 	typeName := entity.EncoderName()
 
-	_, found := encoders[typeName]
-	if found == true {
+	if _, found := defaultRegistry.Encoder(typeName); found == true {
 		log.Panicf("encoder already registered: %v", typeName)
 	}
 
-	encoders[typeName] = encoder
+	defaultRegistry.encoders[typeName] = encoder
 }
 
+// registerDecoder registers a built-in decoder.
 func registerDecoder(ifdPath string, tagId uint16, decoder UndefinedValueDecoder) {
 	uth := UndefinedTagHandle{
 		IfdPath: ifdPath,
 		TagId:   tagId,
 	}
 
-	_, found := decoders[uth]
-	if found == true {
-		log.Panicf("decoder already registered: %v", uth)
+	if _, found := defaultRegistry.decoders[uth]; found == true {
+		log.Panicf("decoder already registered: [%s] (0x%04x)", ifdPath, tagId)
 	}
 
-	decoders[uth] = decoder
+	defaultRegistry.decoders[uth] = decoder
 }
 
 var (
-	encoders = make(map[string]UndefinedValueEncoder)
-	decoders = make(map[UndefinedTagHandle]UndefinedValueDecoder)
+	// defaultRegistry is only written to by the `init()` functions of the
+	// built-in codecs, which bypass the read-only flag.
+	defaultRegistry = newReadOnlyRegistry()
 )
+
+func newReadOnlyRegistry() *Registry {
+	r := NewRegistry()
+	r.readOnly = true
+
+	return r
+}
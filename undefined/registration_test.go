@@ -0,0 +1,228 @@
+package exifundefined
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"encoding/binary"
+
+	"github.com/dsoprea/go-logging"
+
+	"github.com/imclaren/go-exif/common"
+)
+
+type registrationTestValue struct {
+	s string
+}
+
+func (registrationTestValue) EncoderName() string {
+	return "registrationTestCodec"
+}
+
+func (rtv registrationTestValue) String() string {
+	return rtv.s
+}
+
+// registrationTestCodec stores the string upper-cased and reads it back
+// lower-cased.
+type registrationTestCodec struct {
+}
+
+func (registrationTestCodec) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	s := strings.ToUpper(value.(registrationTestValue).s)
+	return []byte(s), uint32(len(s)), nil
+}
+
+func (registrationTestCodec) Decode(valueContext *exifcommon.ValueContext) (value EncodeableValue, err error) {
+	valueContext.SetUndefinedValueType(exifcommon.TypeAsciiNoNul)
+
+	s, err := valueContext.ReadAsciiNoNul()
+	if err != nil {
+		return nil, err
+	}
+
+	return registrationTestValue{s: strings.ToLower(s)}, nil
+}
+
+func getRegistrationTestValueContext(ifdPath string, tagId uint16, encoded []byte) *exifcommon.ValueContext {
+	return exifcommon.NewValueContext(
+		ifdPath,
+		tagId,
+		uint32(len(encoded)),
+		0,
+		encoded,
+		nil,
+		exifcommon.TypeUndefined,
+		exifcommon.TestDefaultByteOrder)
+}
+
+func TestRegistry_Empty(t *testing.T) {
+	r := NewRegistry()
+
+	vc := getRegistrationTestValueContext(exifcommon.IfdExifStandardIfdIdentity.UnindexedString(), 0x9000, []byte("0230"))
+
+	_, err := r.Decode(vc)
+	if err != exifcommon.ErrUnhandledUndefinedTypedTag {
+		t.Fatalf("Expected ErrUnhandledUndefinedTypedTag: %v", err)
+	}
+
+	_, _, err = r.Encode(Tag9000ExifVersion{"0230"}, exifcommon.TestDefaultByteOrder)
+	if err == nil {
+		t.Fatalf("Expected error for missing encoder.")
+	}
+}
+
+func TestRegistry_RegisterAndOverride(t *testing.T) {
+	ifdPath := exifcommon.IfdExifStandardIfdIdentity.UnindexedString()
+
+	r := DefaultRegistry().Clone()
+
+	// Add a codec for a tag that has none.
+
+	err := r.RegisterEncoder(registrationTestValue{}, registrationTestCodec{})
+	log.PanicIf(err)
+
+	err = r.RegisterDecoder(ifdPath, 0xc000, registrationTestCodec{})
+	log.PanicIf(err)
+
+	encoded, unitCount, err := r.Encode(registrationTestValue{s: "abc"}, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if bytes.Equal(encoded, []byte("ABC")) == false || unitCount != 3 {
+		t.Fatalf("Encoding not correct: %v (%d)", encoded, unitCount)
+	}
+
+	value, err := r.Decode(getRegistrationTestValueContext(ifdPath, 0xc000, encoded))
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, registrationTestValue{s: "abc"}) == false {
+		t.Fatalf("Decoded value not correct: %v", value)
+	}
+
+	// Override a built-in one.
+
+	vc := getRegistrationTestValueContext(ifdPath, 0x9000, []byte("0230"))
+
+	err = r.RegisterDecoder(ifdPath, 0x9000, registrationTestCodec{})
+	log.PanicIf(err)
+
+	value, err = r.Decode(vc)
+	log.PanicIf(err)
+
+	if _, ok := value.(registrationTestValue); ok == false {
+		t.Fatalf("Override not used: %v", value)
+	}
+
+	// The default registry is not affected.
+
+	value, err = Decode(getRegistrationTestValueContext(ifdPath, 0x9000, []byte("0230")))
+	log.PanicIf(err)
+
+	if reflect.DeepEqual(value, Tag9000ExifVersion{"0230"}) == false {
+		t.Fatalf("Default registry affected: %v", value)
+	} else if _, found := DefaultRegistry().Decoder(ifdPath, 0xc000); found == true {
+		t.Fatalf("Default registry affected by registration.")
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	ifdPath := exifcommon.IfdExifStandardIfdIdentity.UnindexedString()
+
+	r := DefaultRegistry().Clone()
+
+	wg := new(sync.WaitGroup)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(tagId uint16) {
+			defer wg.Done()
+
+			err := r.RegisterDecoder(ifdPath, tagId, registrationTestCodec{})
+			log.PanicIf(err)
+		}(uint16(0xc000 + i))
+
+		go func() {
+			defer wg.Done()
+
+			_, err := r.Decode(getRegistrationTestValueContext(ifdPath, 0x9000, []byte("0230")))
+			log.PanicIf(err)
+		}()
+	}
+
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		if _, found := r.Decoder(ifdPath, uint16(0xc000+i)); found == false {
+			t.Fatalf("Decoder (%d) not registered.", i)
+		}
+	}
+}
+
+func TestRegistry_DefaultIsReadOnly(t *testing.T) {
+	ifdPath := exifcommon.IfdExifStandardIfdIdentity.UnindexedString()
+
+	r := DefaultRegistry()
+
+	if err := r.RegisterEncoder(registrationTestValue{}, registrationTestCodec{}); err != ErrRegistryReadOnly {
+		t.Fatalf("Expected ErrRegistryReadOnly for encoder: %v", err)
+	} else if err := r.RegisterTagEncoder(ifdPath, 0x9000, registrationTestCodec{}); err != ErrRegistryReadOnly {
+		t.Fatalf("Expected ErrRegistryReadOnly for tag encoder: %v", err)
+	} else if err := r.RegisterDecoder(ifdPath, 0x9000, registrationTestCodec{}); err != ErrRegistryReadOnly {
+		t.Fatalf("Expected ErrRegistryReadOnly for decoder: %v", err)
+	}
+
+	// A clone of it is writable.
+
+	err := r.Clone().RegisterDecoder(ifdPath, 0x9000, registrationTestCodec{})
+	log.PanicIf(err)
+}
+
+func TestRegistry_EncodeTag(t *testing.T) {
+	ifdPath := exifcommon.IfdExifStandardIfdIdentity.UnindexedString()
+
+	r := DefaultRegistry().Clone()
+
+	err := r.RegisterEncoder(registrationTestValue{}, registrationTestCodec{})
+	log.PanicIf(err)
+
+	// Without a tag encoder, the one for the type of the value is used.
+
+	encoded, _, err := r.EncodeTag(ifdPath, 0xc000, Tag9000ExifVersion{"0230"}, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if bytes.Equal(encoded, []byte("0230")) == false {
+		t.Fatalf("Encoding not correct (1): %v", encoded)
+	}
+
+	// A tag encoder takes precedence, but only for its tag.
+
+	err = r.RegisterTagEncoder(ifdPath, 0xc000, tagEncoderTestCodec{})
+	log.PanicIf(err)
+
+	encoded, unitCount, err := r.EncodeTag(ifdPath, 0xc000, registrationTestValue{s: "abc"}, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if bytes.Equal(encoded, []byte("abc!")) == false || unitCount != 4 {
+		t.Fatalf("Encoding not correct (2): %v (%d)", encoded, unitCount)
+	}
+
+	encoded, _, err = r.EncodeTag(ifdPath, 0xc001, registrationTestValue{s: "abc"}, exifcommon.TestDefaultByteOrder)
+	log.PanicIf(err)
+
+	if bytes.Equal(encoded, []byte("ABC")) == false {
+		t.Fatalf("Encoding not correct (3): %v", encoded)
+	}
+}
+
+// tagEncoderTestCodec appends an exclamation mark to the string.
+type tagEncoderTestCodec struct {
+}
+
+func (tagEncoderTestCodec) Encode(value interface{}, byteOrder binary.ByteOrder) (encoded []byte, unitCount uint32, err error) {
+	s := value.(registrationTestValue).s + "!"
+	return []byte(s), uint32(len(s)), nil
+}
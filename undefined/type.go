@@ -22,8 +22,24 @@ var (
 	// ErrUnparseableValue is the error for a value that we should have been
 	// able to parse but were not able to.
 	ErrUnparseableValue = errors.New("unparseable undefined tag")
+
+	// ErrRegistryReadOnly is returned when registering a codec with the
+	// default registry. Register with a clone of it, instead.
+	ErrRegistryReadOnly = errors.New("codec registry is read-only")
 )
 
+// newTruncatedError returns an `exifcommon.TruncatedError` for a value that is
+// shorter than its structure requires.
+func newTruncatedError(valueContext *exifcommon.ValueContext, needed, available int) error {
+	return &exifcommon.TruncatedError{
+		IfdPath:   valueContext.IfdPath(),
+		TagId:     valueContext.TagId(),
+		Offset:    valueContext.ValueOffset(),
+		Needed:    needed,
+		Available: available,
+	}
+}
+
 // UndefinedValueEncoder knows how to encode an undefined-type tag's value to
 // bytes.
 type UndefinedValueEncoder interface {
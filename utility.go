@@ -27,7 +27,7 @@ var (
 func ParseExifFullTimestamp(fullTimestampPhrase string) (timestamp time.Time, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -135,13 +135,11 @@ func (et ExifTag) String() string {
 		len(et.ValueBytes), et.ChildIfdPath)
 }
 
-// RELEASE(dustin): In the next release, add an options struct to Scan() and GetFlatExifData(), and put the MiscellaneousExifData in the return.
-
 // GetFlatExifData returns a simple, flat representation of all tags.
 func GetFlatExifDataFromBytes(exifDataIn []byte) (exifTags []ExifTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 
@@ -153,6 +151,26 @@ func GetFlatExifDataFromBytes(exifDataIn []byte) (exifTags []ExifTag, err error)
 	return s.GetFlatExifData()
 }
 
+// GetFlatExifDataFromBytesWithOptions returns a simple, flat representation of
+// all tags along with the additional data collected during the parse.
+func GetFlatExifDataFromBytesWithOptions(exifDataIn []byte, options ScanOptions) (exifTags []ExifTag, med *MiscellaneousExifData, err error) {
+	defer func() {
+		if state := recover(); state != nil {
+			err = log.Wrap(state)
+		}
+	}()
+
+	r := bytes.NewReader(exifDataIn)
+
+	s, err := NewScanner(r, int64(len(exifDataIn)))
+	log.PanicIf(err)
+
+	exifTags, med, err = s.GetFlatExifDataWithOptions(options)
+	log.PanicIf(err)
+
+	return exifTags, med, nil
+}
+
 // GetFlatExifDataNoLimit returns a simple, flat representation of all tags.
 // The scan will have with no size limit.
 // All the contents of exifDataIn from the start of the exif block (if any)
@@ -160,7 +178,7 @@ func GetFlatExifDataFromBytes(exifDataIn []byte) (exifTags []ExifTag, err error)
 func GetFlatExifDataFromBytesNoLimit(exifDataIn []byte) (exifTags []ExifTag, err error) {
 	defer func() {
 		if state := recover(); state != nil {
-			err = log.Wrap(state.(error))
+			err = log.Wrap(state)
 		}
 	}()
 